@@ -6,8 +6,10 @@ import (
 )
 
 var cli struct {
-	GenerateProto commands.GenerateProtoCmd `cmd:"" help:"Generates proto files."`
-	GenerateUML   commands.GenerateUMLCmd   `cmd:"" help:"Generates proto files."`
+	GenerateProto         commands.GenerateProtoCmd         `cmd:"" help:"Generates proto files."`
+	GenerateUML           commands.GenerateUMLCmd           `cmd:"" help:"Generates proto files."`
+	GenerateJSONLDContext commands.GenerateJSONLDContextCmd `cmd:"" name:"generate-jsonld-context" help:"Generates a JSON-LD context for the JSON representation of the proto messages."`
+	GenerateJSONSchema    commands.GenerateJSONSchemaCmd    `cmd:"" name:"generate-jsonschema" help:"Generates a JSON schema for the JSON representation of the proto messages."`
 }
 
 func main() {
@@ -1,9 +1,10 @@
 package commands
 
 import (
-	"encoding/xml"
+	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 
 	"github.com/lmittmann/tint"
 	"github.com/oxisto/owl2proto/ontology"
@@ -13,15 +14,38 @@ import (
 type GenerateCmd struct {
 	OwlFile          string `arg:""`
 	RootResourceName string `required:""`
+
+	// InputFormat is the serialization format of the ontology file. If set to "auto", the format is derived from the
+	// file extension or, if the extension is ambiguous, from the file content.
+	InputFormat string `optional:"" enum:"auto,owlxml,rdfxml,turtle,functional" default:"auto"`
+
+	// Catalog is an XML catalog that maps the IRIs of imported ontologies to local files. If not set, a
+	// catalog-v001.xml next to the ontology file is used, if it exists.
+	Catalog string `optional:"" type:"path"`
+
+	// ReferenceConfig is a YAML or JSON file that specifies per object property or per target class whether the target
+	// is embedded or referenced by its ID or IRI. It takes precedence over o2p:reference annotations in the ontology.
+	ReferenceConfig string `optional:"" type:"path"`
+
+	// TypeMap is a YAML or JSON file that maps datatypes to protobuf types and the files that need to be imported for
+	// them. It takes precedence over the built-in mapping of the XML schema datatypes.
+	TypeMap string `optional:"" type:"path"`
+
+	// Strict treats warnings, e.g., unknown datatypes or undeclared prefixes, as errors.
+	Strict bool `optional:""`
+
 	preparedOntology *ontology.OntologyPrepared
+
+	// warnings is the number of warnings that occurred during the generation
+	warnings int
 }
 
-// prepare prepares the ontology for further processing.
-func (cmd *GenerateCmd) prepare() {
+// prepare prepares the ontology for further processing. All diagnostics of the ontology are logged, and an error
+// is returned if there are any.
+func (cmd *GenerateCmd) prepare() (err error) {
 	var (
-		b   []byte
-		err error
-		ont owl.Ontology
+		ont     *owl.Ontology
+		catalog *owl.Catalog
 	)
 
 	// Set up logging
@@ -31,19 +55,96 @@ func (cmd *GenerateCmd) prepare() {
 		}),
 	))
 
-	// Read Ontology XML
-	b, err = os.ReadFile(cmd.OwlFile)
+	// Read ontology file in the selected format
+	ont, err = owl.Load(cmd.OwlFile, cmd.format())
 	if err != nil {
-		slog.Error("error reading ontology file", "location", cmd.OwlFile, tint.Err(err))
-		return
+		return fmt.Errorf("error while loading ontology %s: %w", cmd.OwlFile, err)
 	}
 
-	// Unmarshal file content (Ontology xml file)
-	err = xml.Unmarshal(b, &ont)
-	if err != nil {
-		slog.Error("error while un-marshalling XML", tint.Err(err))
-		return
+	// Resolve imported ontologies, if any
+	if len(ont.Imports) > 0 {
+		catalog, err = cmd.catalog()
+		if err != nil {
+			return err
+		}
+
+		err = owl.ResolveImports(ont, cmd.OwlFile, catalog)
+		if err != nil {
+			return fmt.Errorf("error while resolving imports: %w", err)
+		}
+	}
+
+	var types ontology.TypeMap
+	if cmd.TypeMap != "" {
+		types, err = ontology.ReadTypeMap(cmd.TypeMap)
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.preparedOntology, err = ontology.PrepareWithTypeMap(ont, cmd.RootResourceName, types)
+	if diags, ok := err.(ontology.Diagnostics); ok {
+		for _, d := range diags {
+			slog.Error(d.Message, "axiom", d.Axiom, "iri", d.IRI, "position", d.Position)
+		}
+
+		return fmt.Errorf("ontology contains %d invalid axiom(s)", len(diags))
+	} else if err != nil {
+		return err
+	}
+
+	if cmd.ReferenceConfig != "" {
+		config, err := ontology.ReadReferenceConfig(cmd.ReferenceConfig)
+		if err != nil {
+			return fmt.Errorf("error while reading reference config %s: %w", cmd.ReferenceConfig, err)
+		}
+
+		cmd.preparedOntology.ApplyReferenceConfig(config)
+	}
+
+	for _, d := range cmd.preparedOntology.Warnings {
+		cmd.warn(d.Message, "axiom", d.Axiom, "iri", d.IRI, "position", d.Position)
+	}
+
+	return nil
+}
+
+// warn logs a warning and counts it, so that it can be treated as error in strict mode.
+func (cmd *GenerateCmd) warn(msg string, args ...any) {
+	cmd.warnings++
+	slog.Warn(msg, args...)
+}
+
+// checkWarnings returns an error if warnings occurred in strict mode.
+func (cmd *GenerateCmd) checkWarnings() error {
+	if cmd.Strict && cmd.warnings > 0 {
+		return fmt.Errorf("%d warning(s) occurred in strict mode", cmd.warnings)
+	}
+
+	return nil
+}
+
+// format returns the serialization format of the ontology file selected by the user. An empty format means that it
+// is implied by the file extension or detected from the content.
+func (cmd *GenerateCmd) format() owl.Format {
+	if cmd.InputFormat == "auto" {
+		return ""
+	}
+
+	return owl.Format(cmd.InputFormat)
+}
+
+// catalog returns the catalog selected by the user or the catalog next to the ontology file. If neither exists, nil
+// is returned.
+func (cmd *GenerateCmd) catalog() (*owl.Catalog, error) {
+	if cmd.Catalog != "" {
+		return owl.ReadCatalog(cmd.Catalog)
+	}
+
+	path := filepath.Join(filepath.Dir(cmd.OwlFile), owl.CatalogFile)
+	if _, err := os.Stat(path); err != nil {
+		return nil, nil
 	}
 
-	cmd.preparedOntology = ontology.Prepare(&ont, cmd.RootResourceName)
+	return owl.ReadCatalog(path)
 }
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"testing"
+)
+
+func TestGenerateCmd_prepare(t *testing.T) {
+	type fields struct {
+		OwlFile          string
+		RootResourceName string
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		wantErr      bool
+		wantWarnings int
+	}{
+		{
+			name: "Happy path",
+			fields: fields{
+				OwlFile:          "../example/cloud.owx",
+				RootResourceName: "ex:Resource",
+			},
+		},
+		{
+			name: "Missing ontology file",
+			fields: fields{
+				OwlFile:          "../example/missing.owx",
+				RootResourceName: "ex:Resource",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Undeclared root resource",
+			fields: fields{
+				OwlFile:          "../example/cloud.owx",
+				RootResourceName: "ex:Thing",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &GenerateCmd{
+				OwlFile:          tt.fields.OwlFile,
+				RootResourceName: tt.fields.RootResourceName,
+				InputFormat:      "auto",
+			}
+			if err := cmd.prepare(); (err != nil) != tt.wantErr {
+				t.Errorf("GenerateCmd.prepare() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if cmd.warnings != tt.wantWarnings {
+				t.Errorf("GenerateCmd.prepare() warnings = %v, want %v", cmd.warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestGenerateCmd_checkWarnings(t *testing.T) {
+	type fields struct {
+		Strict   bool
+		warnings int
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name:   "Warnings without strict mode",
+			fields: fields{warnings: 2},
+		},
+		{
+			name:   "No warnings in strict mode",
+			fields: fields{Strict: true},
+		},
+		{
+			name:    "Warnings in strict mode",
+			fields:  fields{Strict: true, warnings: 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &GenerateCmd{
+				Strict:   tt.fields.Strict,
+				warnings: tt.fields.warnings,
+			}
+			if err := cmd.checkWarnings(); (err != nil) != tt.wantErr {
+				t.Errorf("GenerateCmd.checkWarnings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
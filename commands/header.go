@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// versionPattern matches path segments that denote a version, e.g., "1.2.0" or "v2", and captures the major version.
+var versionPattern = regexp.MustCompile(`^[vV]?(\d+)(?:\.\d+)*$`)
+
+// ontologyExtensions contains the file extensions that are removed from the last path segment of an ontology IRI.
+var ontologyExtensions = []string{".owl", ".owx", ".rdf", ".ttl", ".ofn"}
+
+// header returns the header of the proto file, i.e., the syntax, package and file options. It is either read from the
+// header file or generated based on the package flags.
+func (cmd *GenerateProtoCmd) header() (string, error) {
+	if cmd.HeaderFile != "" {
+		if cmd.ProtoPackage != "" || cmd.PackageFromIRI || cmd.GoPackage != "" || cmd.JavaPackage != "" ||
+			cmd.CsharpNamespace != "" {
+			return "", errors.New("header file cannot be combined with package flags")
+		}
+
+		// Read header content from file
+		b, err := os.ReadFile(cmd.HeaderFile)
+		if err != nil {
+			return "", fmt.Errorf("error reading header file: %w", err)
+		}
+
+		return string(b), nil
+	}
+
+	pkg := cmd.ProtoPackage
+	if pkg == "" && cmd.PackageFromIRI {
+		var err error
+
+		pkg, err = packageFromIRI(cmd.preparedOntology.IRI, cmd.preparedOntology.VersionIRI)
+		if err != nil {
+			return "", err
+		}
+	} else if pkg == "" {
+		cmd.warn("no proto package specified, use --proto-package or --package-from-iri")
+	}
+
+	return emitHeader(pkg, map[string]string{
+		"csharp_namespace": cmd.CsharpNamespace,
+		"go_package":       cmd.GoPackage,
+		"java_package":     cmd.JavaPackage,
+	}), nil
+}
+
+// emitHeader returns the syntax, the package (if not empty) and the non-empty file options, sorted by their name.
+func emitHeader(pkg string, options map[string]string) (output string) {
+	output = "syntax = \"proto3\";\n"
+
+	if pkg != "" {
+		output += fmt.Sprintf("\npackage %s;\n", pkg)
+	}
+
+	var names []string
+	for name, value := range options {
+		if value != "" {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	if len(names) > 0 {
+		output += "\n"
+	}
+
+	for _, name := range names {
+		output += fmt.Sprintf("option %s = %q;\n", name, options[name])
+	}
+
+	return
+}
+
+// packageFromIRI derives the proto package from the IRI of the ontology, e.g., "com.example.cloud.v1" from
+// "http://example.com/cloud". The reversed host name is followed by the path segments and the major version, which is
+// taken from the version IRI, e.g., "http://example.com/cloud/1.2.0". If the ontology has no IRI, the version IRI is
+// used instead.
+func packageFromIRI(iri string, versionIRI string) (string, error) {
+	var (
+		components []string
+		version    string
+	)
+
+	if iri == "" {
+		iri = versionIRI
+	}
+
+	u, err := url.Parse(iri)
+	if err != nil {
+		return "", fmt.Errorf("could not derive package from ontology IRI: %w", err)
+	}
+
+	if u.Opaque != "" {
+		// URNs, e.g., "urn:example:cloud"
+		components = strings.Split(u.Opaque, ":")
+	} else {
+		hosts := strings.Split(u.Hostname(), ".")
+		slices.Reverse(hosts)
+		components = append(hosts, strings.Split(u.Path, "/")...)
+	}
+
+	// The version IRI usually extends the IRI by the version
+	if versionIRI != "" {
+		if rest, ok := strings.CutPrefix(versionIRI, strings.TrimRight(iri, "/#")); ok && rest != "" {
+			version = majorVersion(strings.FieldsFunc(rest, isSeparator))
+		} else {
+			version = majorVersion([]string{path.Base(versionIRI)})
+		}
+	}
+
+	var pkg []string
+	for _, c := range components {
+		for _, ext := range ontologyExtensions {
+			c = strings.TrimSuffix(c, ext)
+		}
+
+		if m := versionPattern.FindStringSubmatch(c); m != nil {
+			// Versions within the IRI itself are only used if there is no version IRI
+			if version == "" {
+				version = "v" + m[1]
+			}
+			continue
+		}
+
+		if c = packageComponent(c); c != "" && c != "www" {
+			pkg = append(pkg, c)
+		}
+	}
+
+	if len(pkg) == 0 {
+		return "", fmt.Errorf("could not derive package from ontology IRI %q", iri)
+	}
+
+	if version != "" {
+		pkg = append(pkg, version)
+	}
+
+	return strings.Join(pkg, "."), nil
+}
+
+// majorVersion returns the major version, e.g., "v1", of the first segment that is a version.
+func majorVersion(segments []string) string {
+	for _, s := range segments {
+		if m := versionPattern.FindStringSubmatch(s); m != nil {
+			return "v" + m[1]
+		}
+	}
+
+	return ""
+}
+
+// packageComponent converts a segment of an IRI into a valid component of a proto package, i.e., a lower case
+// identifier.
+func packageComponent(s string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else if b.Len() > 0 {
+			b.WriteRune('_')
+		}
+	}
+
+	s = strings.TrimRight(b.String(), "_")
+	if s != "" && s[0] >= '0' && s[0] <= '9' {
+		s = "_" + s
+	}
+
+	return s
+}
+
+// isSeparator returns true for characters that separate the segments of an IRI.
+func isSeparator(r rune) bool {
+	return r == '/' || r == '#' || r == ':'
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"testing"
+)
+
+func Test_emitHeader(t *testing.T) {
+	type args struct {
+		pkg     string
+		options map[string]string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Only syntax",
+			args: args{options: map[string]string{"go_package": ""}},
+			want: "syntax = \"proto3\";\n",
+		},
+		{
+			name: "Package and options",
+			args: args{
+				pkg: "example.v1",
+				options: map[string]string{
+					"java_package":     "com.example",
+					"go_package":       "github.com/oxisto/owl2proto/example",
+					"csharp_namespace": "",
+				},
+			},
+			want: "syntax = \"proto3\";\n\npackage example.v1;\n\noption go_package = \"github.com/oxisto/owl2proto/example\";\noption java_package = \"com.example\";\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := emitHeader(tt.args.pkg, tt.args.options); got != tt.want {
+				t.Errorf("emitHeader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_packageFromIRI(t *testing.T) {
+	type args struct {
+		iri        string
+		versionIRI string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Without version",
+			args: args{iri: "http://www.example.com/cloud-ontology.owl#"},
+			want: "com.example.cloud_ontology",
+		},
+		{
+			name: "Version IRI extends IRI",
+			args: args{iri: "http://example.com/cloud", versionIRI: "http://example.com/cloud/2.1.0"},
+			want: "com.example.cloud.v2",
+		},
+		{
+			name: "Version IRI with different path",
+			args: args{iri: "http://example.com/cloud", versionIRI: "http://example.com/releases/v3/cloud.owl"},
+			want: "com.example.cloud",
+		},
+		{
+			name: "Version IRI with version as last segment",
+			args: args{iri: "http://example.com/cloud", versionIRI: "http://example.org/cloud/v3"},
+			want: "com.example.cloud.v3",
+		},
+		{
+			name: "Version within IRI",
+			args: args{iri: "https://example.com/1.0/cloud"},
+			want: "com.example.cloud.v1",
+		},
+		{
+			name: "Only version IRI",
+			args: args{versionIRI: "https://example.com/cloud/1.0"},
+			want: "com.example.cloud.v1",
+		},
+		{
+			name: "URN",
+			args: args{iri: "urn:webprotege:ontology:43d2ae46"},
+			want: "webprotege.ontology._43d2ae46",
+		},
+		{
+			name:    "No IRI",
+			args:    args{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := packageFromIRI(tt.args.iri, tt.args.versionIRI)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("packageFromIRI() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("packageFromIRI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
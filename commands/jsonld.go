@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+
+	"github.com/oxisto/owl2proto/internal/util"
+	"github.com/oxisto/owl2proto/ontology"
+)
+
+// nestKeyword is the JSON-LD keyword for properties whose value contains properties of the enclosing node.
+const nestKeyword = "@nest"
+
+type GenerateJSONLDContextCmd struct {
+	GenerateCmd
+
+	// Base is the IRI against which the IDs of referenced resources are resolved, e.g.,
+	// "http://example.com/resources/". If not specified, the IDs are resolved against the location of the document.
+	Base string `optional:""`
+
+	OutputPath string `optional:"" default:"api/ontology.jsonld"`
+}
+
+func (cmd *GenerateJSONLDContextCmd) Run() (err error) {
+	err = cmd.prepare()
+	if err != nil {
+		return err
+	}
+
+	// Generate the context
+	context := cmd.createContext()
+
+	err = cmd.checkWarnings()
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(map[string]any{"@context": context}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error while creating JSON-LD context: %w", err)
+	}
+
+	// Write context
+	err = util.WriteFile(cmd.OutputPath, string(b)+"\n")
+	if err != nil {
+		return fmt.Errorf("error writing JSON-LD context to storage: %w", err)
+	}
+
+	slog.Info("JSON-LD context written to storage", slog.String("output folder", cmd.OutputPath))
+	return
+}
+
+// createContext creates the JSON-LD context of the JSON representation of the generated messages, as produced by
+// protojson. It maps the name of each message to the IRI of its class and the JSON name of each field to the IRI of
+// its property. Both the lowerCamelCase name and the original field name are contained, since protojson can emit
+// either of them. Fields that reference resources by their ID or IRI are coerced to "@id". Enum fields are coerced to
+// "@vocab" and the names of the enum values are mapped to the IRIs of their individuals. The fields of the oneof
+// "type" of abstract classes are nested, so that their properties belong to the node of the abstract class.
+func (cmd *GenerateJSONLDContextCmd) createContext() map[string]any {
+	var (
+		ont     = cmd.preparedOntology
+		context = map[string]any{"@version": 1.1}
+	)
+
+	if cmd.Base != "" {
+		context["@base"] = cmd.Base
+	}
+
+	// The empty prefix cannot be used in compact IRIs, see compactIRI
+	for short, prefix := range ont.Prefixes {
+		if short != "" {
+			context[short] = prefix.IRI
+		}
+	}
+
+	for _, iri := range util.SortMapKeys(ont.Resources) {
+		// Enumerations are emitted as enum instead of a message
+		if _, ok := ont.Enums[iri]; ok {
+			continue
+		}
+
+		class := ont.Resources[iri]
+		cmd.define(context, class.Name, cmd.compactIRI(iri))
+
+		if len(class.SubResources) > 0 {
+			for _, leaf := range findAllLeafs(iri, ont) {
+				cmd.defineField(context, util.ToSnakeCase(leaf.Name), nestKeyword)
+			}
+
+			continue
+		}
+
+		for _, r := range ont.FindAllDataProperties(iri) {
+			if r.Typ != "" && r.Name != "" && !r.Cardinality.Prohibited() {
+				cmd.defineField(context, util.ToSnakeCase(r.Name), cmd.compactIRI(r.IRI))
+			}
+		}
+
+		for _, o := range ont.FindAllObjectProperties(iri) {
+			if o.Name == "" || o.ObjectProperty == "" || o.Cardinality.Prohibited() {
+				continue
+			}
+
+			value, typ, name := ont.GetObjectDetail(o)
+			if typ == "" || (value == "" && name == "") {
+				continue
+			}
+
+			if _, ok := ont.Enums[o.To]; ok {
+				cmd.defineField(context, util.ToSnakeCase(name), map[string]string{
+					"@id":   cmd.compactIRI(o.ObjectProperty),
+					"@type": "@vocab",
+				})
+			} else if ont.ReferenceOf(o) != ontology.ReferenceEmbed {
+				cmd.defineField(context, util.ToSnakeCase(name), map[string]string{
+					"@id":   cmd.compactIRI(o.ObjectProperty),
+					"@type": "@id",
+				})
+			} else {
+				cmd.defineField(context, util.ToSnakeCase(name), cmd.compactIRI(o.ObjectProperty))
+			}
+		}
+	}
+
+	// The values of enum fields are resolved as terms. Values of enumerations of literals are not individuals and
+	// stay literals.
+	for _, iri := range util.SortMapKeys(ont.Enums) {
+		for _, v := range ont.Enums[iri].Values {
+			if v.IRI != "" {
+				cmd.define(context, v.Name, cmd.compactIRI(v.IRI))
+			}
+		}
+	}
+
+	return context
+}
+
+// defineField defines the JSON name of the field as well as its original name.
+func (cmd *GenerateJSONLDContextCmd) defineField(context map[string]any, name string, definition any) {
+	cmd.define(context, jsonName(name), definition)
+	if jsonName(name) != name {
+		cmd.define(context, name, definition)
+	}
+}
+
+// define adds the term definition to the context. Since a term can only have one definition, a warning is issued if
+// the term is already defined differently, e.g., if fields of different messages have the same name but different
+// properties. In this case, the first definition is kept.
+func (cmd *GenerateJSONLDContextCmd) define(context map[string]any, term string, definition any) {
+	if existing, ok := context[term]; ok {
+		if !reflect.DeepEqual(existing, definition) {
+			cmd.warn("JSON-LD term is already defined differently", "term", term, "definition", existing)
+		}
+
+		return
+	}
+
+	context[term] = definition
+}
+
+// compactIRI returns the IRI abbreviated by one of the prefixes of the context. Since the empty prefix cannot be used
+// in JSON-LD, IRIs of the empty prefix are returned in full.
+func (cmd *GenerateJSONLDContextCmd) compactIRI(iri string) string {
+	abbreviated := cmd.preparedOntology.AbbreviateIRI(iri)
+	if strings.HasPrefix(abbreviated, ":") {
+		return iri
+	}
+
+	return abbreviated
+}
+
+// jsonName returns the JSON name of a field, as derived by protoc, i.e., underscores are removed and the following
+// letter is capitalized, e.g., "block_storage_ids" returns "blockStorageIds".
+func jsonName(name string) string {
+	var (
+		b     strings.Builder
+		upper bool
+	)
+
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+
+		if upper && r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		upper = false
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
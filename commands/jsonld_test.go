@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oxisto/owl2proto/ontology"
+	"github.com/oxisto/owl2proto/owl"
+)
+
+func TestGenerateJSONLDContextCmd_createContext(t *testing.T) {
+	const (
+		ex  = "http://example.com/cloud/"
+		sec = "http://example.com/security/"
+	)
+
+	var (
+		vm = &ontology.Resource{Iri: ex + "VirtualMachine", Name: "VirtualMachine", Parents: []string{ex + "Resource"},
+			Relationship: []*ontology.Relationship{
+				{IRI: ex + "name", Typ: "string", Name: "name", From: ex + "Resource"},
+				{IRI: ex + "retired", Typ: "bool", Name: "retired", From: ex + "Resource", Cardinality: &ontology.Cardinality{Max: 0}},
+			},
+			ObjectRelationship: []*ontology.ObjectRelationship{
+				{ObjectProperty: ex + "hasMultiple", From: ex + "VirtualMachine", To: ex + "VirtualMachine", Name: "VirtualMachine", Cardinality: &ontology.Cardinality{Max: ontology.Unbounded}},
+				{ObjectProperty: ex + "has", From: ex + "VirtualMachine", To: ex + "GeoLocation", Name: "GeoLocation"},
+				{ObjectProperty: ex + "owner", From: ex + "VirtualMachine", To: ex + "Person", Name: "Person"},
+				{ObjectProperty: ex + "level", From: ex + "VirtualMachine", To: ex + "Level", Name: "Level"},
+			},
+		}
+		encryption = &ontology.Resource{Iri: sec + "Encryption", Name: "Encryption", Relationship: []*ontology.Relationship{
+			{IRI: sec + "name", Typ: "string", Name: "name", From: sec + "Encryption"},
+		}}
+		resource = &ontology.Resource{Iri: ex + "Resource", Name: "Resource", SubResources: []*ontology.Resource{vm}}
+		po       = &ontology.OntologyPrepared{
+			Prefixes: map[string]*owl.Prefix{
+				"ex": {Name: "ex", IRI: ex},
+				"":   {Name: "", IRI: sec},
+			},
+			RootResourceName: ex + "Resource",
+			Resources: map[string]*ontology.Resource{
+				ex + "VirtualMachine": vm,
+				ex + "Resource":       resource,
+				ex + "GeoLocation":    {Iri: ex + "GeoLocation", Name: "GeoLocation"},
+				ex + "Person":         {Iri: ex + "Person", Name: "Person"},
+				ex + "Level":          {Iri: ex + "Level", Name: "Level"},
+				sec + "Encryption":    encryption,
+			},
+			Enums: map[string]*ontology.Enum{
+				ex + "Level": {IRI: ex + "Level", Name: "Level", Values: []*ontology.EnumValue{
+					{IRI: ex + "High", Name: "LEVEL_HIGH"},
+					{IRI: ex + "Low", Name: "LEVEL_LOW"},
+				}},
+				ex + "Size": {IRI: ex + "Size", Name: "Size", Values: []*ontology.EnumValue{{Literal: "small", Name: "SIZE_SMALL"}}},
+			},
+			References: map[string]ontology.Reference{ex + "Person": ontology.ReferenceIRI},
+		}
+	)
+
+	cmd := &GenerateJSONLDContextCmd{Base: "http://example.com/resources/"}
+	cmd.preparedOntology = po
+
+	want := map[string]any{
+		"@version":            1.1,
+		"@base":               "http://example.com/resources/",
+		"ex":                  ex,
+		"Encryption":          sec + "Encryption",
+		"GeoLocation":         "ex:GeoLocation",
+		"Person":              "ex:Person",
+		"Resource":            "ex:Resource",
+		"VirtualMachine":      "ex:VirtualMachine",
+		"virtualMachine":      "@nest",
+		"virtual_machine":     "@nest",
+		"name":                "ex:name",
+		"virtualMachineIds":   map[string]string{"@id": "ex:hasMultiple", "@type": "@id"},
+		"virtual_machine_ids": map[string]string{"@id": "ex:hasMultiple", "@type": "@id"},
+		"geoLocation":         "ex:has",
+		"geo_location":        "ex:has",
+		"personIri":           map[string]string{"@id": "ex:owner", "@type": "@id"},
+		"person_iri":          map[string]string{"@id": "ex:owner", "@type": "@id"},
+		"level":               map[string]string{"@id": "ex:level", "@type": "@vocab"},
+		"LEVEL_HIGH":          "ex:High",
+		"LEVEL_LOW":           "ex:Low",
+	}
+
+	if got := cmd.createContext(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateJSONLDContextCmd.createContext() = %v, want %v", got, want)
+	}
+
+	// The name of the encryption has a different property than the name of the resource
+	if cmd.warnings != 1 {
+		t.Errorf("GenerateJSONLDContextCmd.warnings = %v, want %v", cmd.warnings, 1)
+	}
+}
+
+func Test_jsonName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "name", want: "name"},
+		{name: "block_storage_ids", want: "blockStorageIds"},
+		{name: "tls_1_2", want: "tls12"},
+		{name: "_private", want: "Private"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jsonName(tt.name); got != tt.want {
+				t.Errorf("jsonName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,488 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/oxisto/owl2proto/internal/util"
+	"github.com/oxisto/owl2proto/ontology"
+	"github.com/oxisto/owl2proto/rdf"
+)
+
+const (
+	// schemaDialect is the JSON schema dialect of the generated schema
+	schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+	// classKeyword, propertyKeyword and enumKeyword are the custom keywords that contain the IRIs of classes,
+	// properties and enumerations
+	classKeyword    = "x-owl-class"
+	propertyKeyword = "x-owl-property"
+	enumKeyword     = "x-owl-enum"
+
+	// inheritedDef is the name of the definition that contains the properties of an abstract class, which are
+	// inherited by its sub-classes
+	inheritedDef = "properties"
+)
+
+// datatypeFormats contains the JSON schema formats of the datatypes that are represented as string.
+var datatypeFormats = map[string]string{
+	rdf.NamespaceXSD + "anyURI":            "uri",
+	rdf.NamespaceXSD + "date":              "date",
+	rdf.NamespaceXSD + "time":              "time",
+	rdf.NamespaceXSD + "dateTime":          "date-time",
+	rdf.NamespaceXSD + "dateTimeStamp":     "date-time",
+	rdf.NamespaceXSD + "duration":          "duration",
+	rdf.NamespaceXSD + "dayTimeDuration":   "duration",
+	rdf.NamespaceXSD + "yearMonthDuration": "duration",
+}
+
+type GenerateJSONSchemaCmd struct {
+	GenerateCmd
+
+	// UseProtoNames uses the names of the proto fields as property names instead of their lowerCamelCase JSON names,
+	// like the option of protojson with the same name.
+	UseProtoNames bool `optional:""`
+
+	OutputPath string `optional:"" default:"api/ontology.schema.json"`
+
+	// defs contains the key of the definition in "$defs" of each class and enumeration, indexed by its IRI
+	defs map[string]string
+}
+
+func (cmd *GenerateJSONSchemaCmd) Run() (err error) {
+	err = cmd.prepare()
+	if err != nil {
+		return err
+	}
+
+	// Generate the schema
+	schema := cmd.createSchema()
+
+	err = cmd.checkWarnings()
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error while creating JSON schema: %w", err)
+	}
+
+	// Write schema
+	err = util.WriteFile(cmd.OutputPath, string(b)+"\n")
+	if err != nil {
+		return fmt.Errorf("error writing JSON schema to storage: %w", err)
+	}
+
+	slog.Info("JSON schema written to storage", slog.String("output folder", cmd.OutputPath))
+	return
+}
+
+// createSchema creates a JSON schema of the JSON representation of the generated messages, as produced by protojson.
+// The schema contains a definition in "$defs" for each class and enumeration and refers to the root resource.
+func (cmd *GenerateJSONSchemaCmd) createSchema() map[string]any {
+	var (
+		ont  = cmd.preparedOntology
+		defs = make(map[string]any)
+	)
+
+	cmd.prepareDefs()
+
+	for _, iri := range util.SortMapKeys(ont.Resources) {
+		if _, ok := ont.Enums[iri]; !ok {
+			defs[cmd.defs[iri]] = cmd.classSchema(ont.Resources[iri])
+		}
+	}
+
+	for _, iri := range util.SortMapKeys(ont.Enums) {
+		defs[cmd.defs[iri]] = cmd.enumSchema(ont.Enums[iri])
+	}
+
+	schema := map[string]any{
+		"$schema": schemaDialect,
+		"$defs":   defs,
+	}
+
+	if root, ok := ont.Resources[ont.RootResourceName]; ok {
+		schema["$ref"] = defRef(cmd.defs[root.Iri])
+	}
+
+	return schema
+}
+
+// prepareDefs assigns the key of its definition in "$defs" to each class and enumeration, which is its name. If
+// several classes or enumerations have the same name, a warning is logged and their keys are qualified with the name
+// of their prefix, e.g., "sec.Policy". Keys that are still not unique get a number as suffix.
+func (cmd *GenerateJSONSchemaCmd) prepareDefs() {
+	var (
+		ont   = cmd.preparedOntology
+		names = make(map[string][]string)
+	)
+
+	for _, iri := range util.SortMapKeys(ont.Resources) {
+		if _, ok := ont.Enums[iri]; !ok {
+			names[ont.Resources[iri].Name] = append(names[ont.Resources[iri].Name], iri)
+		}
+	}
+
+	for _, iri := range util.SortMapKeys(ont.Enums) {
+		names[ont.Enums[iri].Name] = append(names[ont.Enums[iri].Name], iri)
+	}
+
+	cmd.defs = make(map[string]string)
+
+	for _, name := range util.SortMapKeys(names) {
+		iris := names[name]
+		if len(iris) == 1 {
+			cmd.defs[iris[0]] = name
+			continue
+		}
+
+		cmd.warn("classes or enumerations have the same name, qualifying their definitions with their prefix",
+			"name", name, "iris", iris)
+
+		keys := make(map[string]bool)
+		for _, iri := range iris {
+			key := name
+			if prefix := ont.PrefixOf(iri); prefix != "" {
+				key = prefix + "." + name
+			}
+
+			unique := key
+			for i := 2; keys[unique]; i++ {
+				unique = fmt.Sprintf("%s_%d", key, i)
+			}
+
+			keys[unique] = true
+			cmd.defs[iri] = unique
+		}
+	}
+}
+
+// classSchema returns the schema of a class. Like the message of an entity class, its schema contains its own
+// properties as well as the properties of its super-classes, which are included using "allOf". Like the message of
+// an abstract class, its schema is a "oneOf" of the entity classes that derive from it, wrapped in the field of the
+// "oneof type". The properties of an abstract class are contained in a separate definition, so that they can be
+// inherited.
+func (cmd *GenerateJSONSchemaCmd) classSchema(class *ontology.Resource) map[string]any {
+	schema := map[string]any{
+		"title":                 class.Name,
+		"type":                  "object",
+		classKeyword:            class.Iri,
+		"unevaluatedProperties": false,
+	}
+
+	if len(class.Comment) > 0 {
+		schema["description"] = strings.Join(class.Comment, "\n")
+	}
+	if class.Deprecated {
+		schema["deprecated"] = true
+	}
+
+	if len(class.SubResources) == 0 {
+		for k, v := range cmd.propertiesSchema(class) {
+			schema[k] = v
+		}
+
+		return schema
+	}
+
+	var oneOf []any
+	for _, leaf := range findAllLeafs(class.Iri, cmd.preparedOntology) {
+		name := cmd.fieldName(util.ToSnakeCase(leaf.Name))
+
+		oneOf = append(oneOf, map[string]any{
+			"properties": map[string]any{name: map[string]any{"$ref": defRef(cmd.defs[leaf.Iri])}},
+			"required":   []string{name},
+		})
+	}
+
+	schema["oneOf"] = oneOf
+	schema["$defs"] = map[string]any{inheritedDef: cmd.propertiesSchema(class)}
+
+	return schema
+}
+
+// propertiesSchema returns the schema of the properties of the class, which includes the properties of the
+// super-classes using "allOf".
+func (cmd *GenerateJSONSchemaCmd) propertiesSchema(class *ontology.Resource) map[string]any {
+	var (
+		ont        = cmd.preparedOntology
+		schema     = make(map[string]any)
+		properties = make(map[string]any)
+		required   []string
+		allOf      []any
+	)
+
+	// Like the lineage, the super-classes of the root resource are not included
+	if class.Iri != ont.RootResourceName {
+		for _, parent := range class.Parents {
+			if _, ok := ont.Resources[parent]; ok {
+				allOf = append(allOf, map[string]any{"$ref": defRef(cmd.defs[parent]) + "/$defs/" + inheritedDef})
+			}
+		}
+	}
+
+	for _, r := range class.Relationship {
+		// Properties with a maximum cardinality of 0 must not have any value
+		if r.Typ == "" || r.Name == "" || r.Cardinality.Prohibited() {
+			continue
+		}
+
+		name := cmd.fieldName(util.ToSnakeCase(r.Name))
+		properties[name] = cmd.dataPropertySchema(r)
+
+		if r.Cardinality.Required() {
+			required = append(required, name)
+		}
+	}
+
+	for _, o := range class.ObjectRelationship {
+		if o.Name == "" || o.ObjectProperty == "" || o.Cardinality.Prohibited() {
+			continue
+		}
+
+		value, typ, name := ont.GetObjectDetail(o)
+		if typ == "" || (value == "" && name == "") {
+			continue
+		}
+
+		name = cmd.fieldName(util.ToSnakeCase(name))
+		properties[name] = cmd.objectPropertySchema(o, value != "")
+
+		if o.Cardinality.Required() {
+			required = append(required, name)
+		}
+	}
+
+	// Cardinality restrictions on inherited properties complement their schema in the super-class
+	for _, r := range ont.FindAllDataProperties(class.Iri) {
+		if r.Typ == "" || r.Name == "" || r.From == class.Iri || !overrides(class, r.IRI, "") {
+			continue
+		}
+
+		name := cmd.fieldName(util.ToSnakeCase(r.Name))
+		properties[name] = overrideSchema(r.Cardinality, strings.HasPrefix(fieldType(r), util.Repeated))
+
+		if r.Cardinality.Required() {
+			required = append(required, name)
+		}
+	}
+
+	for _, o := range ont.FindAllObjectProperties(class.Iri) {
+		if o.Name == "" || o.ObjectProperty == "" || o.From == class.Iri || !overrides(class, o.ObjectProperty, o.To) {
+			continue
+		}
+
+		value, typ, name := ont.GetObjectDetail(o)
+		if typ == "" || (value == "" && name == "") {
+			continue
+		}
+
+		name = cmd.fieldName(util.ToSnakeCase(name))
+		properties[name] = overrideSchema(o.Cardinality, value != "")
+
+		if o.Cardinality.Required() {
+			required = append(required, name)
+		}
+	}
+
+	if len(allOf) > 0 {
+		schema["allOf"] = allOf
+	}
+	if len(properties) > 0 {
+		schema["properties"] = properties
+	}
+	if len(required) > 0 {
+		slices.Sort(required)
+		schema["required"] = slices.Compact(required)
+	}
+
+	return schema
+}
+
+// dataPropertySchema returns the schema of the field of a data property.
+func (cmd *GenerateJSONSchemaCmd) dataPropertySchema(r *ontology.Relationship) map[string]any {
+	schema := cmd.typeSchema(strings.TrimPrefix(r.Typ, util.Repeated), r.Datatype)
+	if strings.HasPrefix(fieldType(r), util.Repeated) {
+		schema = map[string]any{"type": "array", "items": schema}
+		itemCounts(schema, r.Cardinality)
+	}
+
+	schema[propertyKeyword] = r.IRI
+	if r.Comment != "" {
+		schema["description"] = strings.ReplaceAll(r.Comment, "\n\t ", "\n")
+	}
+	if r.Deprecated {
+		schema["deprecated"] = true
+	}
+
+	return schema
+}
+
+// objectPropertySchema returns the schema of the field of an object property, which either embeds the target,
+// references it by its ID or IRI or contains an enum value.
+func (cmd *GenerateJSONSchemaCmd) objectPropertySchema(o *ontology.ObjectRelationship, repeated bool) map[string]any {
+	var (
+		ont    = cmd.preparedOntology
+		schema map[string]any
+	)
+
+	if _, ok := ont.Enums[o.To]; ok {
+		schema = map[string]any{"$ref": defRef(cmd.defs[o.To])}
+	} else {
+		switch ont.ReferenceOf(o) {
+		case ontology.ReferenceID:
+			schema = map[string]any{"type": "string"}
+		case ontology.ReferenceIRI:
+			schema = map[string]any{"type": "string", "format": "iri"}
+		default:
+			schema = map[string]any{"$ref": defRef(cmd.defs[o.To])}
+		}
+	}
+
+	if repeated {
+		schema = map[string]any{"type": "array", "items": schema}
+		itemCounts(schema, o.Cardinality)
+	}
+
+	schema[propertyKeyword] = o.ObjectProperty
+	if o.Comment != "" {
+		schema["description"] = o.Comment
+	}
+	if o.Deprecated {
+		schema["deprecated"] = true
+	}
+
+	return schema
+}
+
+// overrides returns true if the class overrides the cardinality of the inherited property, which points to the class
+// to in case of object properties.
+func overrides(class *ontology.Resource, property, to string) bool {
+	return slices.ContainsFunc(class.Overrides, func(o *ontology.CardinalityOverride) bool {
+		return o.Property == property && (o.To == "" || o.To == to)
+	})
+}
+
+// overrideSchema returns the schema of an inherited property whose cardinality is overridden by a class. The schema of
+// the property itself is defined by the super-class, so it only restricts the number of items or, if the property
+// must not have a value, does not allow the property at all.
+func overrideSchema(c *ontology.Cardinality, repeated bool) any {
+	if c.Prohibited() {
+		return false
+	}
+
+	schema := make(map[string]any)
+	if repeated {
+		itemCounts(schema, c)
+	}
+
+	return schema
+}
+
+// itemCounts adds the minimum and maximum number of items of the cardinality to the schema of a repeated field.
+func itemCounts(schema map[string]any, c *ontology.Cardinality) {
+	if c == nil {
+		return
+	}
+
+	if c.Min > 0 {
+		schema["minItems"] = c.Min
+	}
+	if c.Max != ontology.Unbounded {
+		schema["maxItems"] = c.Max
+	}
+}
+
+// typeSchema returns the schema of the protobuf type according to the JSON mapping of protobuf. Strings get the
+// format of their datatype, if any. 64-bit integers are represented as string by protojson, but numbers are accepted
+// as well.
+func (cmd *GenerateJSONSchemaCmd) typeSchema(typ string, datatype string) map[string]any {
+	// Maps are represented as object, whose keys are always strings
+	if inner, ok := strings.CutPrefix(typ, "map<"); ok {
+		_, value, _ := strings.Cut(strings.TrimSuffix(inner, ">"), ",")
+		return map[string]any{"type": "object", "additionalProperties": cmd.typeSchema(strings.TrimSpace(value), "")}
+	}
+
+	switch typ {
+	case "string":
+		if format, ok := datatypeFormats[datatype]; ok {
+			return map[string]any{"type": "string", "format": format}
+		}
+
+		return map[string]any{"type": "string"}
+	case "bool":
+		return map[string]any{"type": "boolean"}
+	case "int32", "sint32", "sfixed32":
+		return map[string]any{"type": "integer"}
+	case "uint32", "fixed32":
+		return map[string]any{"type": "integer", "minimum": 0}
+	case "int64", "sint64", "sfixed64":
+		return map[string]any{"type": []string{"integer", "string"}, "pattern": "^-?[0-9]+$"}
+	case "uint64", "fixed64":
+		return map[string]any{"type": []string{"integer", "string"}, "pattern": "^[0-9]+$", "minimum": 0}
+	case "float", "double":
+		return map[string]any{"type": "number"}
+	case "bytes":
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	case "google.protobuf.Timestamp":
+		return map[string]any{"type": "string", "format": "date-time"}
+	case "google.protobuf.Duration":
+		// protojson represents durations as seconds with the suffix "s", e.g., "1.5s", not as ISO 8601 duration
+		return map[string]any{"type": "string", "pattern": `^-?[0-9]+(\.[0-9]{1,9})?s$`}
+	case "google.protobuf.Struct":
+		return map[string]any{"type": "object"}
+	}
+
+	// Enumerations of literals are datatypes
+	if enum, ok := cmd.preparedOntology.Enums[datatype]; ok && enum.Name == typ {
+		return map[string]any{"$ref": defRef(cmd.defs[datatype])}
+	}
+
+	cmd.warn("protobuf type has no JSON schema, allowing any value", "type", typ)
+
+	return map[string]any{}
+}
+
+// enumSchema returns the schema of an enumeration, whose values are represented by their name.
+func (cmd *GenerateJSONSchemaCmd) enumSchema(enum *ontology.Enum) map[string]any {
+	var values = []string{enum.UnspecifiedName()}
+
+	for _, v := range enum.Values {
+		values = append(values, v.Name)
+	}
+
+	schema := map[string]any{
+		"title":     enum.Name,
+		"type":      "string",
+		"enum":      values,
+		enumKeyword: enum.IRI,
+	}
+
+	if len(enum.Comment) > 0 {
+		schema["description"] = strings.Join(enum.Comment, "\n")
+	}
+	if enum.Deprecated {
+		schema["deprecated"] = true
+	}
+
+	return schema
+}
+
+// fieldName returns the name of the field in JSON.
+func (cmd *GenerateJSONSchemaCmd) fieldName(name string) string {
+	if cmd.UseProtoNames {
+		return name
+	}
+
+	return jsonName(name)
+}
+
+// defRef returns the reference to the definition with the key.
+func defRef(key string) string {
+	return "#/$defs/" + key
+}
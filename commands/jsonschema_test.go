@@ -0,0 +1,267 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oxisto/owl2proto/ontology"
+	"github.com/oxisto/owl2proto/owl"
+	"github.com/oxisto/owl2proto/rdf"
+)
+
+func TestGenerateJSONSchemaCmd_createSchema(t *testing.T) {
+	const ex = "http://example.com/cloud/"
+
+	var (
+		vm = &ontology.Resource{Iri: ex + "VirtualMachine", Name: "VirtualMachine", Parents: []string{ex + "Resource"},
+			Relationship: []*ontology.Relationship{
+				{IRI: ex + "createdAt", Typ: "google.protobuf.Timestamp", Datatype: rdf.NamespaceXSD + "dateTime", Name: "createdAt", From: ex + "VirtualMachine"},
+				{IRI: ex + "retired", Typ: "bool", Name: "retired", From: ex + "VirtualMachine", Cardinality: &ontology.Cardinality{Max: 0}},
+			},
+			ObjectRelationship: []*ontology.ObjectRelationship{
+				{ObjectProperty: ex + "hasMultiple", From: ex + "VirtualMachine", To: ex + "VirtualMachine", Name: "VirtualMachine", Cardinality: &ontology.Cardinality{Min: 2, Max: ontology.Unbounded}},
+				{ObjectProperty: ex + "level", From: ex + "VirtualMachine", To: ex + "Level", Name: "Level", Cardinality: &ontology.Cardinality{Min: 1, Max: 1}},
+			},
+			Overrides: []*ontology.CardinalityOverride{
+				{Property: ex + "tag", Cardinality: &ontology.Cardinality{Min: 1, Max: 3}},
+			},
+		}
+		resource = &ontology.Resource{Iri: ex + "Resource", Name: "Resource", Comment: []string{"A resource."}, SubResources: []*ontology.Resource{vm},
+			Relationship: []*ontology.Relationship{
+				{IRI: ex + "name", Typ: "string", Datatype: rdf.NamespaceXSD + "string", Name: "name", From: ex + "Resource", Comment: "The name.", Cardinality: &ontology.Cardinality{Min: 1, Max: 1}},
+				{IRI: ex + "tag", Typ: "string", Datatype: rdf.NamespaceXSD + "string", Name: "tag", From: ex + "Resource", Cardinality: &ontology.Cardinality{Max: ontology.Unbounded}},
+			},
+		}
+		level = &ontology.Enum{IRI: ex + "Level", Name: "Level", Values: []*ontology.EnumValue{{IRI: ex + "High", Name: "LEVEL_HIGH"}}}
+		po    = &ontology.OntologyPrepared{
+			Prefixes:         map[string]*owl.Prefix{"ex": {Name: "ex", IRI: ex}},
+			RootResourceName: ex + "Resource",
+			Resources: map[string]*ontology.Resource{
+				ex + "VirtualMachine": vm,
+				ex + "Resource":       resource,
+				ex + "Level":          {Iri: ex + "Level", Name: "Level"},
+			},
+			Enums: map[string]*ontology.Enum{ex + "Level": level},
+		}
+	)
+
+	tests := []struct {
+		name string
+		want map[string]any
+	}{
+		{
+			name: "JSON names",
+			want: map[string]any{
+				"$schema": schemaDialect,
+				"$ref":    "#/$defs/Resource",
+				"$defs": map[string]any{
+					"Resource": map[string]any{
+						"title":                 "Resource",
+						"description":           "A resource.",
+						"type":                  "object",
+						"unevaluatedProperties": false,
+						classKeyword:            ex + "Resource",
+						"oneOf": []any{
+							map[string]any{
+								"properties": map[string]any{"virtualMachine": map[string]any{"$ref": "#/$defs/VirtualMachine"}},
+								"required":   []string{"virtualMachine"},
+							},
+						},
+						"$defs": map[string]any{
+							"properties": map[string]any{
+								"properties": map[string]any{
+									"name": map[string]any{"type": "string", "description": "The name.", propertyKeyword: ex + "name"},
+									"tag": map[string]any{
+										"type":          "array",
+										"items":         map[string]any{"type": "string"},
+										propertyKeyword: ex + "tag",
+									},
+								},
+								"required": []string{"name"},
+							},
+						},
+					},
+					"VirtualMachine": map[string]any{
+						"title":                 "VirtualMachine",
+						"type":                  "object",
+						"unevaluatedProperties": false,
+						classKeyword:            ex + "VirtualMachine",
+						"allOf":                 []any{map[string]any{"$ref": "#/$defs/Resource/$defs/properties"}},
+						"properties": map[string]any{
+							"createdAt": map[string]any{"type": "string", "format": "date-time", propertyKeyword: ex + "createdAt"},
+							"virtualMachineIds": map[string]any{
+								"type":          "array",
+								"items":         map[string]any{"type": "string"},
+								"minItems":      2,
+								propertyKeyword: ex + "hasMultiple",
+							},
+							"level": map[string]any{"$ref": "#/$defs/Level", propertyKeyword: ex + "level"},
+							"tag":   map[string]any{"minItems": 1, "maxItems": 3},
+						},
+						"required": []string{"level", "tag", "virtualMachineIds"},
+					},
+					"Level": map[string]any{
+						"title":     "Level",
+						"type":      "string",
+						"enum":      []string{"LEVEL_UNSPECIFIED", "LEVEL_HIGH"},
+						enumKeyword: ex + "Level",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &GenerateJSONSchemaCmd{}
+			cmd.preparedOntology = po
+
+			if got := cmd.createSchema(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GenerateJSONSchemaCmd.createSchema() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateJSONSchemaCmd_prepareDefs(t *testing.T) {
+	const (
+		ex  = "http://example.com/cloud/"
+		sec = "http://example.com/security/"
+	)
+
+	tests := []struct {
+		name         string
+		resources    map[string]*ontology.Resource
+		enums        map[string]*ontology.Enum
+		want         map[string]string
+		wantWarnings int
+	}{
+		{
+			name: "Unique names",
+			resources: map[string]*ontology.Resource{
+				ex + "Storage": {Iri: ex + "Storage", Name: "Storage"},
+				ex + "Level":   {Iri: ex + "Level", Name: "Level"},
+			},
+			enums: map[string]*ontology.Enum{ex + "Level": {IRI: ex + "Level", Name: "Level"}},
+			want:  map[string]string{ex + "Storage": "Storage", ex + "Level": "Level"},
+		},
+		{
+			name: "Same name in different prefixes",
+			resources: map[string]*ontology.Resource{
+				ex + "Policy":  {Iri: ex + "Policy", Name: "Policy"},
+				sec + "Policy": {Iri: sec + "Policy", Name: "Policy"},
+				ex + "Storage": {Iri: ex + "Storage", Name: "Storage"},
+			},
+			want:         map[string]string{ex + "Policy": "ex.Policy", sec + "Policy": "sec.Policy", ex + "Storage": "Storage"},
+			wantWarnings: 1,
+		},
+		{
+			name: "Same name in the same prefix",
+			resources: map[string]*ontology.Resource{
+				ex + "Policy": {Iri: ex + "Policy", Name: "Policy"},
+				ex + "Rule":   {Iri: ex + "Rule", Name: "Policy"},
+			},
+			enums:        map[string]*ontology.Enum{sec + "Policy": {IRI: sec + "Policy", Name: "Policy"}},
+			want:         map[string]string{ex + "Policy": "ex.Policy", ex + "Rule": "ex.Policy_2", sec + "Policy": "sec.Policy"},
+			wantWarnings: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &GenerateJSONSchemaCmd{}
+			cmd.preparedOntology = &ontology.OntologyPrepared{
+				Prefixes: map[string]*owl.Prefix{
+					"ex":  {Name: "ex", IRI: ex},
+					"sec": {Name: "sec", IRI: sec},
+				},
+				Resources: tt.resources,
+				Enums:     tt.enums,
+			}
+
+			cmd.prepareDefs()
+			if !reflect.DeepEqual(cmd.defs, tt.want) {
+				t.Errorf("GenerateJSONSchemaCmd.defs = %v, want %v", cmd.defs, tt.want)
+			}
+			if cmd.warnings != tt.wantWarnings {
+				t.Errorf("GenerateJSONSchemaCmd.warnings = %v, want %v", cmd.warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestGenerateJSONSchemaCmd_typeSchema(t *testing.T) {
+	type args struct {
+		typ      string
+		datatype string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		want         map[string]any
+		wantWarnings int
+	}{
+		{
+			name: "String with format",
+			args: args{typ: "string", datatype: rdf.NamespaceXSD + "anyURI"},
+			want: map[string]any{"type": "string", "format": "uri"},
+		},
+		{
+			name: "String duration",
+			args: args{typ: "string", datatype: rdf.NamespaceXSD + "yearMonthDuration"},
+			want: map[string]any{"type": "string", "format": "duration"},
+		},
+		{
+			name: "64-bit integer",
+			args: args{typ: "int64", datatype: rdf.NamespaceXSD + "long"},
+			want: map[string]any{"type": []string{"integer", "string"}, "pattern": "^-?[0-9]+$"},
+		},
+		{
+			name: "Duration",
+			args: args{typ: "google.protobuf.Duration", datatype: rdf.NamespaceXSD + "duration"},
+			want: map[string]any{"type": "string", "pattern": `^-?[0-9]+(\.[0-9]{1,9})?s$`},
+		},
+		{
+			name: "Map",
+			args: args{typ: "map<string, uint32>"},
+			want: map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "integer", "minimum": 0}},
+		},
+		{
+			name:         "Unknown type",
+			args:         args{typ: "example.Unknown"},
+			want:         map[string]any{},
+			wantWarnings: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &GenerateJSONSchemaCmd{}
+			cmd.preparedOntology = &ontology.OntologyPrepared{}
+
+			if got := cmd.typeSchema(tt.args.typ, tt.args.datatype); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GenerateJSONSchemaCmd.typeSchema() = %v, want %v", got, tt.want)
+			}
+			if cmd.warnings != tt.wantWarnings {
+				t.Errorf("GenerateJSONSchemaCmd.warnings = %v, want %v", cmd.warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestGenerateJSONSchemaCmd_fieldName(t *testing.T) {
+	tests := []struct {
+		name          string
+		useProtoNames bool
+		want          string
+	}{
+		{name: "JSON name", want: "blockStorageIds"},
+		{name: "Proto name", useProtoNames: true, want: "block_storage_ids"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &GenerateJSONSchemaCmd{UseProtoNames: tt.useProtoNames}
+
+			if got := cmd.fieldName("block_storage_ids"); got != tt.want {
+				t.Errorf("GenerateJSONSchemaCmd.fieldName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
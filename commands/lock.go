@@ -0,0 +1,227 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/oxisto/owl2proto/internal/util"
+	"github.com/oxisto/owl2proto/ontology"
+)
+
+// FieldNumberLock contains the field numbers that have been assigned to the fields of each generated message. It is
+// persisted as a lock file (e.g., "owl2proto.lock.json") so that field numbers stay stable when classes are moved in
+// the class hierarchy or new properties are added to the ontology.
+type FieldNumberLock struct {
+	// Messages contains the locked fields of each message, indexed by the IRI of its class
+	Messages map[string]*MessageLock `json:"messages"`
+
+	// Enums contains the locked values of each enum, indexed by the IRI of its class or datatype
+	Enums map[string]*EnumLock `json:"enums,omitempty"`
+}
+
+// MessageLock contains the locked fields of one message.
+type MessageLock struct {
+	Name string `json:"name"`
+
+	// Fields contains the locked fields, indexed by the key returned by [fieldKey]
+	Fields map[string]*FieldLock `json:"fields"`
+}
+
+// EnumLock contains the locked values of one enum.
+type EnumLock struct {
+	Name string `json:"name"`
+
+	// Values contains the locked values, indexed by the key returned by [enumValueKey]
+	Values map[string]*FieldLock `json:"values"`
+}
+
+// FieldLock contains the field number that is assigned to one field.
+type FieldLock struct {
+	Name   string `json:"name"`
+	Number int    `json:"number"`
+}
+
+// fieldKey returns the key of a field in the lock file. It is built out of the IRIs that identify the field, e.g., the
+// IRI of a data property or the IRI of an object property together with the IRI of the target class.
+func fieldKey(iris ...string) string {
+	return strings.Join(iris, " ")
+}
+
+// enumValueKey returns the key of an enum value in the lock file, which is the IRI of the individual or, for
+// enumerations of literals, the literal.
+func enumValueKey(v *ontology.EnumValue) string {
+	if v.IRI != "" {
+		return v.IRI
+	}
+
+	return v.Literal
+}
+
+// readLockFile reads the lock file at the given location. If the file does not exist yet, an empty lock is returned.
+func readLockFile(path string) (lock *FieldNumberLock, err error) {
+	var b []byte
+
+	lock = &FieldNumberLock{Messages: map[string]*MessageLock{}}
+
+	b, err = os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return lock, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading lock file: %w", err)
+	}
+
+	err = json.Unmarshal(b, lock)
+	if err != nil {
+		return nil, fmt.Errorf("error while un-marshalling lock file: %w", err)
+	}
+
+	if lock.Messages == nil {
+		lock.Messages = map[string]*MessageLock{}
+	}
+
+	err = lock.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid lock file: %w", err)
+	}
+
+	return lock, nil
+}
+
+// validate checks that the numbers of all messages and enums are valid and unique, so that a broken lock file fails
+// loudly instead of producing an invalid proto file.
+func (lock *FieldNumberLock) validate() (err error) {
+	for _, iri := range util.SortMapKeys(lock.Messages) {
+		if _, err = lock.Messages[iri].numbers(); err != nil {
+			return err
+		}
+	}
+
+	for _, iri := range util.SortMapKeys(lock.Enums) {
+		if _, err = lock.Enums[iri].numbers(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeLockFile writes the lock to the given location.
+func writeLockFile(path string, lock *FieldNumberLock) (err error) {
+	var b []byte
+
+	b, err = json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error while marshalling lock file: %w", err)
+	}
+
+	return util.WriteFile(path, string(b)+"\n")
+}
+
+// message returns the lock of the message with the given class IRI and creates it, if it does not exist yet.
+func (lock *FieldNumberLock) message(iri, name string) *MessageLock {
+	m, ok := lock.Messages[iri]
+	if !ok {
+		m = &MessageLock{Fields: map[string]*FieldLock{}}
+		lock.Messages[iri] = m
+	} else if m.Fields == nil {
+		m.Fields = map[string]*FieldLock{}
+	}
+
+	m.Name = name
+
+	return m
+}
+
+// enum returns the lock of the enum with the given IRI and creates it, if it does not exist yet.
+func (lock *FieldNumberLock) enum(iri, name string) *EnumLock {
+	if lock.Enums == nil {
+		lock.Enums = map[string]*EnumLock{}
+	}
+
+	e, ok := lock.Enums[iri]
+	if !ok {
+		e = &EnumLock{Values: map[string]*FieldLock{}}
+		lock.Enums[iri] = e
+	} else if e.Values == nil {
+		e.Values = map[string]*FieldLock{}
+	}
+
+	e.Name = name
+
+	return e
+}
+
+// number returns the number of the enum value identified by key. Values that are not locked yet get the number
+// after the highest locked number, so that the numbers of existing values never change, even if values are removed
+// or inserted before them. Numbers of removed values stay in the lock and are thus never re-used.
+func (e *EnumLock) number(key, name string) int {
+	if v, ok := e.Values[key]; ok {
+		v.Name = name
+		return v.Number
+	}
+
+	var number int
+	for _, v := range e.Values {
+		number = max(number, v.Number)
+	}
+	number++
+
+	e.Values[key] = &FieldLock{Name: name, Number: number}
+
+	return number
+}
+
+// numbers returns all locked field numbers of the message and the key of the field they belong to. An error is
+// returned if the lock assigns an invalid number or the same number to more than one field.
+func (m *MessageLock) numbers() (numbers map[int]string, err error) {
+	numbers = make(map[int]string)
+
+	for _, key := range util.SortMapKeys(m.Fields) {
+		f := m.Fields[key]
+
+		if !validNumber(f.Number) {
+			return nil, fmt.Errorf("field number %d of %q in message %s is invalid", f.Number, key, m.Name)
+		}
+
+		if other, ok := numbers[f.Number]; ok {
+			return nil, fmt.Errorf("field number %d of message %s is locked for both %q and %q", f.Number, m.Name, other, key)
+		}
+
+		numbers[f.Number] = key
+	}
+
+	return numbers, nil
+}
+
+// numbers returns all locked numbers of the enum and the key of the value they belong to. An error is returned if the
+// lock assigns an invalid number or the same number to more than one value.
+func (e *EnumLock) numbers() (numbers map[int]string, err error) {
+	numbers = make(map[int]string)
+
+	for _, key := range util.SortMapKeys(e.Values) {
+		v := e.Values[key]
+
+		if !validNumber(v.Number) {
+			return nil, fmt.Errorf("number %d of %q in enum %s is invalid", v.Number, key, e.Name)
+		}
+
+		if other, ok := numbers[v.Number]; ok {
+			return nil, fmt.Errorf("number %d of enum %s is locked for both %q and %q", v.Number, e.Name, other, key)
+		}
+
+		numbers[v.Number] = key
+	}
+
+	return numbers, nil
+}
+
+// validNumber returns whether a locked number can be used. The number 0 is not allowed, since it is taken by the
+// "UNSPECIFIED" value of enums, and neither are the numbers reserved for the Protocol Buffers implementation.
+func validNumber(number int) bool {
+	return number >= 1 && number <= util.MaxProtoFieldNumber &&
+		(number < util.FirstReservedFieldNumber || number > util.LastReservedFieldNumber)
+}
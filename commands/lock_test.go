@@ -0,0 +1,261 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/oxisto/owl2proto/internal/util"
+)
+
+func TestGenerateProtoCmd_fieldNumber(t *testing.T) {
+	type fields struct {
+		DeterministicFieldNumbers bool
+		message                   *MessageLock
+		i                         int
+	}
+	type args struct {
+		key   string
+		name  string
+		input []string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Happy path: locked field",
+			fields: fields{
+				DeterministicFieldNumbers: true,
+				message: &MessageLock{
+					Name: "VirtualMachine",
+					Fields: map[string]*FieldLock{
+						"http://example.com/cloud/name": {Name: "name", Number: 1},
+					},
+				},
+			},
+			args: args{
+				key:   "http://example.com/cloud/name",
+				name:  "name",
+				input: []string{"Resource", "Compute", "VirtualMachine", "name"},
+			},
+			want: 1,
+		},
+		{
+			name: "Happy path: new field",
+			fields: fields{
+				DeterministicFieldNumbers: true,
+				message: &MessageLock{
+					Name:   "VirtualMachine",
+					Fields: map[string]*FieldLock{},
+				},
+			},
+			args: args{
+				key:   "http://example.com/cloud/name",
+				name:  "name",
+				input: []string{"Resource", "Compute", "VirtualMachine", "name"},
+			},
+			want: 4044,
+		},
+		{
+			name: "Happy path: ascending order skips locked numbers",
+			fields: fields{
+				DeterministicFieldNumbers: false,
+				message: &MessageLock{
+					Name: "VirtualMachine",
+					Fields: map[string]*FieldLock{
+						"http://example.com/cloud/id": {Name: "id", Number: 1},
+					},
+				},
+			},
+			args: args{
+				key:  "http://example.com/cloud/name",
+				name: "name",
+			},
+			want: 2,
+		},
+		{
+			name: "Happy path: collision with locked number",
+			fields: fields{
+				DeterministicFieldNumbers: true,
+				message: &MessageLock{
+					Name: "VirtualMachine",
+					Fields: map[string]*FieldLock{
+						"http://example.com/cloud/id": {Name: "id", Number: 4044},
+					},
+				},
+			},
+			args: args{
+				key:   "http://example.com/cloud/name",
+				name:  "name",
+				input: []string{"Resource", "Compute", "VirtualMachine", "name"},
+			},
+			want: 4045,
+		},
+		{
+			name: "Happy path: collision with locked numbers",
+			fields: fields{
+				DeterministicFieldNumbers: true,
+				message: &MessageLock{
+					Name: "VirtualMachine",
+					Fields: map[string]*FieldLock{
+						"http://example.com/cloud/id":          {Name: "id", Number: 4044},
+						"http://example.com/cloud/description": {Name: "description", Number: 4045},
+					},
+				},
+			},
+			args: args{
+				key:   "http://example.com/cloud/name",
+				name:  "name",
+				input: []string{"Resource", "Compute", "VirtualMachine", "name"},
+			},
+			want: 4046,
+		},
+		{
+			name: "Ascending field numbers exhausted",
+			fields: fields{
+				DeterministicFieldNumbers: false,
+				message: &MessageLock{
+					Name:   "VirtualMachine",
+					Fields: map[string]*FieldLock{},
+				},
+				i: util.MaxFieldNumber,
+			},
+			args: args{
+				key:  "http://example.com/cloud/name",
+				name: "name",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+
+			cmd := &GenerateProtoCmd{
+				DeterministicFieldNumbers: tt.fields.DeterministicFieldNumbers,
+				message:                   tt.fields.message,
+				previousMessage:           &previousMessage{},
+				emitted:                   map[int]string{},
+				i:                         tt.fields.i,
+			}
+			cmd.numbers, err = cmd.message.numbers()
+			if err != nil {
+				t.Fatalf("MessageLock.numbers() error = %v", err)
+			}
+
+			got, err := cmd.fieldNumber(tt.args.key, tt.args.name, tt.args.input...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateProtoCmd.fieldNumber() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GenerateProtoCmd.fieldNumber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageLock_numbers(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       *MessageLock
+		want    map[int]string
+		wantErr bool
+	}{
+		{
+			name: "Happy path",
+			m: &MessageLock{
+				Fields: map[string]*FieldLock{
+					"a": {Name: "a", Number: 1},
+					"b": {Name: "b", Number: 2},
+				},
+			},
+			want: map[int]string{1: "a", 2: "b"},
+		},
+		{
+			name: "Duplicate number",
+			m: &MessageLock{
+				Fields: map[string]*FieldLock{
+					"a": {Name: "a", Number: 1},
+					"b": {Name: "b", Number: 1},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Number zero",
+			m:       &MessageLock{Fields: map[string]*FieldLock{"a": {Name: "a", Number: 0}}},
+			wantErr: true,
+		},
+		{
+			name:    "Reserved number",
+			m:       &MessageLock{Fields: map[string]*FieldLock{"a": {Name: "a", Number: 19500}}},
+			wantErr: true,
+		},
+		{
+			name:    "Number too high",
+			m:       &MessageLock{Fields: map[string]*FieldLock{"a": {Name: "a", Number: 536870912}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.numbers()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MessageLock.numbers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MessageLock.numbers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_readLockFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		lock    string
+		wantErr bool
+	}{
+		{
+			name: "Happy path",
+			lock: `{"messages": {"ex:Storage": {"name": "Storage", "fields": {"ex:size": {"name": "size", "number": 18999}}}},
+				"enums": {"ex:Level": {"name": "Level", "values": {"ex:High": {"name": "LEVEL_HIGH", "number": 1}}}}}`,
+		},
+		{
+			name:    "Invalid field number",
+			lock:    `{"messages": {"ex:Storage": {"name": "Storage", "fields": {"ex:size": {"name": "size", "number": 19000}}}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid enum value number",
+			lock:    `{"messages": {}, "enums": {"ex:Level": {"name": "Level", "values": {"ex:High": {"name": "LEVEL_HIGH", "number": 0}}}}}`,
+			wantErr: true,
+		},
+		{
+			name: "Duplicate enum value number",
+			lock: `{"messages": {}, "enums": {"ex:Level": {"name": "Level", "values": {
+				"ex:High": {"name": "LEVEL_HIGH", "number": 1}, "ex:Low": {"name": "LEVEL_LOW", "number": 1}}}}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "owl2proto.lock.json")
+			if err := os.WriteFile(path, []byte(tt.lock), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := readLockFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("readLockFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
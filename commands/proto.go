@@ -3,20 +3,45 @@ package commands
 import (
 	"fmt"
 	"log/slog"
-	"os"
+	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
-	"github.com/lmittmann/tint"
 	"github.com/oxisto/owl2proto/internal/util"
 	"github.com/oxisto/owl2proto/ontology"
 )
 
 type GenerateProtoCmd struct {
 	GenerateCmd
-	HeaderFile string
+
+	// HeaderFile contains the syntax, package and file options of the proto file. If not specified, the header is
+	// generated based on the package flags.
+	HeaderFile string `optional:"" type:"path"`
+
+	// ProtoPackage is the package of the generated proto file, e.g., "example.v1".
+	ProtoPackage string `optional:""`
+
+	// PackageFromIRI derives the package from the ontology IRI and the major version of its version IRI, if no
+	// package is specified, e.g., "com.example.cloud.v1" for "http://example.com/cloud".
+	PackageFromIRI bool `optional:"" name:"package-from-iri"`
+
+	// GoPackage, JavaPackage and CsharpNamespace are emitted as the respective file options, if specified.
+	GoPackage       string `optional:""`
+	JavaPackage     string `optional:""`
+	CsharpNamespace string `optional:""`
+
 	OutputPath string `optional:"" default:"api/ontology.proto"`
 
+	// SplitBy groups the messages and enums into one proto file and package per module, which is either the prefix of
+	// their IRI or the value of their o2p:module annotation. The files are written to OutputDir instead of OutputPath.
+	SplitBy string `optional:"" enum:"none,prefix,annotation" default:"none"`
+
+	// OutputDir is the directory of the proto files if the output is split. The files are placed in sub-directories
+	// according to their package.
+	OutputDir string `optional:"" type:"path" default:"api"`
+
 	// DeterministicFieldNumbers is an option to enable deterministic field numbers based on a cryptographic hash. If
 	// disabled, ascending field numbers are used sorted by parent class and then name
 	DeterministicFieldNumbers bool `optional:"" default:"true"`
@@ -26,32 +51,134 @@ type GenerateProtoCmd struct {
 	// as protobuf options.
 	FullSemanticMode bool `optional:"" default:"true"`
 
+	// LockFile is the location of a lock file that contains the field numbers that were assigned in previous runs. If
+	// specified, fields that are contained in the lock file keep their field number, new fields are added to it.
+	LockFile string `optional:""`
+
+	// PreviousProto is the location of a previously generated proto file or of a directory of proto files, if the output
+	// is split. If specified, fields keep the number they had in the previous file and numbers and names of fields that
+	// were removed from the ontology are emitted as reserved.
+	PreviousProto string `optional:""`
+
 	// counter for generating the field number if ascending order is chosen
 	i int
+
+	// lock contains the locked field numbers of all messages
+	lock *FieldNumberLock
+
+	// message is the lock of the message that is currently generated
+	message *MessageLock
+
+	// numbers contains the field numbers that are already in use by the current message
+	numbers map[int]string
+
+	// emitted contains the names of the fields that were emitted for the current message, indexed by their number
+	emitted map[int]string
+
+	// previous contains the messages of the previously generated proto file, indexed by their fully qualified name
+	previous map[string]*previousMessage
+
+	// previousMessage is the current message in the previously generated proto file
+	previousMessage *previousMessage
+
+	// imports contains the files that are needed by the generated messages and options
+	imports map[string]bool
+
+	// baseHeader is the header of the proto file, before it is adjusted to the module
+	baseHeader string
+
+	// module is the module that is currently generated, if the output is split
+	module *module
+
+	// modules contains the modules of all classes and enumerations, indexed by their IRI
+	modules map[string]*module
+
+	// dependencies contains the files of other modules that are imported by each file, indexed by the file
+	dependencies map[string]map[string]bool
 }
 
+const (
+	// owlImport contains the options for the semantic meta-data, see owl/owl.proto
+	owlImport = "owl/owl.proto"
+
+	// validateImport contains the options for the validation rules
+	validateImport = "buf/validate/validate.proto"
+
+	// descriptorImport contains the option types that are extended in condensed mode
+	descriptorImport = "google/protobuf/descriptor.proto"
+)
+
+var (
+	// headerImportPattern matches the import statements of the header.
+	headerImportPattern = regexp.MustCompile(`(?m)^[ \t]*import\s+(?:public\s+|weak\s+)?"([^"]+)"\s*;`)
+
+	// headerPackagePattern matches the package statement of the header.
+	headerPackagePattern = regexp.MustCompile(`(?m)^[ \t]*package\s+([\w.]+)\s*;`)
+
+	// headerSyntaxPattern matches the syntax statement of the header.
+	headerSyntaxPattern = regexp.MustCompile(`(?m)^[ \t]*syntax\s*=\s*"[^"]*"\s*;`)
+)
+
 // createProto creates the proto file
-func (cmd *GenerateProtoCmd) createProto(header string) string {
-	output := ""
+func (cmd *GenerateProtoCmd) createProto(header string) (output string, err error) {
+	if cmd.lock == nil {
+		cmd.lock = &FieldNumberLock{Messages: map[string]*MessageLock{}}
+	}
 
-	// Add "auto-generated" header
-	output += "// Auto-generated code by owl2proto (https://github.com/oxisto/owl2proto)"
+	cmd.imports = make(map[string]bool)
 
-	//Add header
-	output += "\n\n" + header
+	// The options header is needed before the messages, but the imports are only known after all messages are
+	// generated
+	options := cmd.emitOptionsHeader()
 
-	output += cmd.emitOptionsHeader()
+	// Messages of the previously generated proto file are looked up within the package of this file
+	pkg := headerPackage(header)
 
 	// Sort preparedOntology.Resources map keys
 	resourceMapKeys := util.SortMapKeys(cmd.preparedOntology.Resources)
 
 	// Create proto messages with comments
 	for _, rmk := range resourceMapKeys {
-		class := cmd.preparedOntology.Resources[rmk]
+		var (
+			class = cmd.preparedOntology.Resources[rmk]
+			ok    bool
+		)
+
+		// Enumerations are emitted as enum instead of a message
+		if _, ok = cmd.preparedOntology.Enums[rmk]; ok || !cmd.inModule(rmk) {
+			continue
+		}
 
 		// is the counter for the message field numbers
 		cmd.i = 0
-		// i := 1
+
+		// Look up the locked field numbers of this message
+		cmd.message = cmd.lock.message(rmk, class.Name)
+		cmd.numbers, err = cmd.message.numbers()
+		if err != nil {
+			return "", err
+		}
+
+		// Block all numbers that were used or reserved in the previously generated proto file
+		cmd.previousMessage, ok = cmd.findPreviousMessage(pkg, class.Name)
+		if !ok {
+			cmd.previousMessage = &previousMessage{}
+		}
+		for name, number := range cmd.previousMessage.Fields {
+			if _, ok := cmd.numbers[number]; !ok {
+				cmd.numbers[number] = name
+			}
+		}
+		for _, r := range cmd.previousMessage.ReservedRanges {
+			// Numbers above the maximum are never assigned
+			for number := r.Start; number <= min(r.End, util.MaxFieldNumber); number++ {
+				if _, ok := cmd.numbers[number]; !ok {
+					cmd.numbers[number] = "reserved"
+				}
+			}
+		}
+
+		cmd.emitted = make(map[int]string)
 
 		// Add message comment
 		if len(class.SubResources) == 0 {
@@ -64,19 +191,30 @@ func (cmd *GenerateProtoCmd) createProto(header string) string {
 		for _, w := range class.Comment {
 			output += "\n// " + w
 		}
+		output += deprecationComment("", class.DeprecationReason)
 
 		// Start message
 		output += fmt.Sprintf("\nmessage %s {\n", class.Name)
 
+		if class.Deprecated {
+			output += "\toption deprecated = true;\n"
+		}
+
 		if len(class.SubResources) == 0 {
 			// Add class hierarchy as message options
 			output = cmd.addClassHierarchy(output, rmk)
 
 			// Add data properties, e.g., "bool enabled", "int64 interval", "int64 retention_period"
-			output = cmd.addDataProperties(output, rmk)
+			output, err = cmd.addDataProperties(output, rmk)
+			if err != nil {
+				return "", err
+			}
 
 			// Add object properties, e.g., "string compute_id", "ApplicationLogging application_logging", "TransportEncryption transport_encrypton"
-			output = cmd.addObjectProperties(output, rmk)
+			output, err = cmd.addObjectProperties(output, rmk)
+			if err != nil {
+				return "", err
+			}
 		} else {
 			// Get all leafs from object property and write it as 'oneOf {}'
 			leafs := findAllLeafs(class.Iri, cmd.preparedOntology)
@@ -84,31 +222,281 @@ func (cmd *GenerateProtoCmd) createProto(header string) string {
 			output += fmt.Sprintf("\n\toneof %s {", "type")
 			for _, v := range leafs {
 				var fieldNumber = 0
-				fieldNumber, cmd.i = util.GetFieldNumber(cmd.DeterministicFieldNumbers, cmd.i, cmd.getResourceTypeList(v)...)
-				output += fmt.Sprintf("\n\t\t%s %s = %d;", v.Name, util.ToSnakeCase(v.Name), fieldNumber)
+				fieldNumber, err = cmd.fieldNumber(fieldKey(v.Iri), util.ToSnakeCase(v.Name), cmd.getResourceTypeList(v)...)
+				if err != nil {
+					return "", err
+				}
+				var opts string
+				if v.Deprecated {
+					opts = " [ deprecated = true ]"
+				}
+
+				output += fmt.Sprintf("\n\t\t%s %s = %d%s;", cmd.qualify(v.Name, v.Iri), util.ToSnakeCase(v.Name), fieldNumber, opts)
 			}
 
 			// close oneOf{}
 			output += "\n\t}"
 		}
 
+		// Reserve numbers and names of fields that do not exist anymore
+		output += cmd.emitReserved()
+
 		// Close message
 		output += "\n}\n"
 	}
 
+	// Create proto enums for the enumerations of individuals and literals
+	for _, iri := range util.SortMapKeys(cmd.preparedOntology.Enums) {
+		if cmd.inModule(iri) {
+			output += cmd.emitEnum(cmd.preparedOntology.Enums[iri])
+		}
+	}
+
+	// Add "auto-generated" header and the header including all imports that are needed but not declared by it
+	output = "// Auto-generated code by owl2proto (https://github.com/oxisto/owl2proto)" +
+		"\n\n" + emitImports(header, util.SortMapKeys(cmd.imports)) +
+		options +
+		output
+
+	return output, nil
+}
+
+// emitEnum returns the proto enum of the enumeration. The first value is always the default value "UNSPECIFIED", the
+// other values keep the number of the lock or are numbered in the order of the enumeration, and carry the IRI of the
+// individual or the literal as option when full semantic mode is enabled. Values that are contained in the lock, but
+// not in the enumeration anymore, are reserved.
+func (cmd *GenerateProtoCmd) emitEnum(enum *ontology.Enum) string {
+	var output string
+
+	output += fmt.Sprintf("\n// %s is an enumeration in our ontology.", enum.Name)
+	for _, w := range enum.Comment {
+		output += "\n// " + w
+	}
+	output += deprecationComment("", enum.DeprecationReason)
+
+	output += fmt.Sprintf("\nenum %s {\n", enum.Name)
+
+	if enum.Deprecated {
+		output += "\toption deprecated = true;\n"
+	}
+
+	if cmd.FullSemanticMode {
+		output += fmt.Sprintf("\toption (owl.enum).iri = \"%s\";\n\n", cmd.preparedOntology.AbbreviateIRI(enum.IRI))
+	}
+
+	output += fmt.Sprintf("\t%s = 0;", enum.UnspecifiedName())
+
+	if cmd.lock == nil {
+		cmd.lock = &FieldNumberLock{Messages: map[string]*MessageLock{}}
+	}
+
+	var (
+		lock = cmd.lock.enum(enum.IRI, enum.Name)
+		keys = make(map[string]bool)
+	)
+
+	for _, v := range enum.Values {
+		var (
+			opts       []string
+			optsOutput string
+		)
+
+		if v.Deprecated {
+			opts = append(opts, "deprecated = true")
+		}
+
+		if cmd.FullSemanticMode && v.IRI != "" {
+			opts = append(opts, fmt.Sprintf("(owl.individual).iri = \"%s\"", cmd.preparedOntology.AbbreviateIRI(v.IRI)))
+		} else if cmd.FullSemanticMode {
+			opts = append(opts, fmt.Sprintf("(owl.literal) = %s", strconv.Quote(v.Literal)))
+		}
+
+		if len(opts) > 0 {
+			optsOutput = fmt.Sprintf(" [ %s ]", strings.Join(opts, ", "))
+		}
+
+		keys[enumValueKey(v)] = true
+		output += fmt.Sprintf("\n\t%s = %d%s;", v.Name, lock.number(enumValueKey(v), v.Name), optsOutput)
+	}
+
+	output += emitEnumReserved(lock, keys)
+	output += "\n}\n"
+
+	return output
+}
+
+// emitImports returns the header together with the import statements of the files that are not already imported by
+// the header, sorted by their name. The imports are added after the last import of the header or, if the header has
+// no imports, after the package or syntax statement.
+func emitImports(header string, imports []string) string {
+	var (
+		output   string
+		declared = make(map[string]bool)
+	)
+
+	for _, m := range headerImportPattern.FindAllStringSubmatch(header, -1) {
+		declared[m[1]] = true
+	}
+
+	imports = slices.Clone(imports)
+	slices.Sort(imports)
+
+	for _, file := range imports {
+		if !declared[file] {
+			output += fmt.Sprintf("\nimport \"%s\";", file)
+		}
+	}
+
+	if output == "" {
+		return header
+	}
+
+	// Add the imports to the existing imports, otherwise in a separate block
+	for _, pattern := range []*regexp.Regexp{headerImportPattern, headerPackagePattern, headerSyntaxPattern} {
+		if loc := pattern.FindAllStringIndex(header, -1); loc != nil {
+			idx := loc[len(loc)-1][1]
+			if pattern != headerImportPattern {
+				output = "\n" + output
+			}
+
+			return header[:idx] + output + header[idx:]
+		}
+	}
+
+	return header + output + "\n"
+}
+
+// use marks the file as needed by the generated proto file.
+func (cmd *GenerateProtoCmd) use(file string) {
+	if file == "" {
+		return
+	}
+
+	if cmd.imports == nil {
+		cmd.imports = make(map[string]bool)
+	}
+
+	cmd.imports[file] = true
+}
+
+// useOptions marks the files as needed that declare the given field options.
+func (cmd *GenerateProtoCmd) useOptions(opts []string) {
+	for _, opt := range opts {
+		if strings.HasPrefix(opt, "(buf.validate.") {
+			cmd.use(validateImport)
+		} else if strings.HasPrefix(opt, "(owl.") {
+			cmd.use(owlImport)
+		}
+	}
+}
+
+// deprecationComment returns the comment lines that explain the deprecation with the given indentation, or an empty
+// string if there is no reason. Each line of a multi-line reason becomes its own comment line.
+func deprecationComment(indent, reason string) (output string) {
+	if reason == "" {
+		return ""
+	}
+
+	for i, line := range strings.Split(strings.TrimSpace(reason), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if i == 0 {
+			line = "Deprecated: " + line
+		}
+
+		if line == "" {
+			output += fmt.Sprintf("\n%s//", indent)
+		} else {
+			output += fmt.Sprintf("\n%s// %s", indent, line)
+		}
+	}
+
 	return output
 }
 
+// fieldNumber returns the field number of the field identified by key in the message that is currently generated.
+// Fields that are contained in the lock (or the previous proto file) keep their number. New fields get a number from
+// [util.GetFieldNumber] and are added to the lock. If deterministic field numbers collide with a number that is already used in the message
+// (or is reserved by Protocol Buffers), the collision is resolved by probing with [util.NextFieldNumber].
+func (cmd *GenerateProtoCmd) fieldNumber(key, name string, input ...string) (number int, err error) {
+	if f, ok := cmd.message.Fields[key]; ok {
+		f.Name = name
+		cmd.emitted[f.Number] = name
+		return f.Number, nil
+	}
+
+	// Re-use the number of a field with the same name in the previous proto file
+	if number, ok := cmd.previousMessage.Fields[name]; ok && cmd.numbers[number] == name {
+		cmd.numbers[number] = key
+		cmd.emitted[number] = name
+		cmd.message.Fields[key] = &FieldLock{Name: name, Number: number}
+		return number, nil
+	}
+
+	number, cmd.i, err = util.GetFieldNumber(cmd.DeterministicFieldNumbers, cmd.i, input...)
+	if err != nil {
+		return 0, fmt.Errorf("could not assign a field number to %q in message %s: %w", key, cmd.message.Name, err)
+	}
+
+	if cmd.DeterministicFieldNumbers {
+		var (
+			original = number
+			probes   = 0
+			reasons  []string
+		)
+
+		for {
+			if number > util.MaxFieldNumber {
+				reasons = append(reasons, fmt.Sprintf("%d is reserved", number))
+			} else if other, ok := cmd.numbers[number]; ok {
+				reasons = append(reasons, fmt.Sprintf("%d is used by %q", number, other))
+			} else {
+				break
+			}
+
+			probes++
+			if probes > util.MaxFieldNumber {
+				return 0, fmt.Errorf("could not find a free field number for %q in message %s", key, cmd.message.Name)
+			}
+
+			number = util.NextFieldNumber(number)
+		}
+
+		if number != original {
+			cmd.warn("Field number collision, moved field to a different number",
+				slog.String("message", cmd.message.Name),
+				slog.String("field", name),
+				slog.Int("original", original),
+				slog.Int("number", number),
+				slog.String("reason", strings.Join(reasons, ", ")))
+		}
+	} else {
+		// In ascending order, we simply skip numbers that are already locked
+		for {
+			if _, ok := cmd.numbers[number]; !ok {
+				break
+			}
+
+			number, cmd.i, err = util.GetFieldNumber(cmd.DeterministicFieldNumbers, cmd.i, input...)
+			if err != nil {
+				return 0, fmt.Errorf("could not assign a field number to %q in message %s: %w", key, cmd.message.Name, err)
+			}
+		}
+	}
+
+	cmd.numbers[number] = key
+	cmd.emitted[number] = name
+	cmd.message.Fields[key] = &FieldLock{Name: name, Number: number}
+
+	return number, nil
+}
+
 // emitOptionsHeader includes all semantic ontology metadata as options when full semantic mode is enabled; otherwise, it adds a streamlined version of the ontology class hierarchy.
 func (cmd *GenerateProtoCmd) emitOptionsHeader() string {
 	var output string
 
 	if cmd.FullSemanticMode {
-		// Add import
-		output += `
-import "owl/owl.proto";
+		cmd.use(owlImport)
 
-`
 		// Prepare prefix output
 		var prefixOutputs []string
 
@@ -125,18 +513,25 @@ option (owl.meta) = {
 	prefixes: [` + strings.Join(prefixOutputs, ",") + `]};
 `
 
-	} else {
-		// Add EnumValueOptions
-		output += `
-extend google.protobuf.MessageOptions {
-	repeated string resource_type_names = 60000;
-}`
+	} else if cmd.module == nil {
+		cmd.use(descriptorImport)
 
+		// Add EnumValueOptions
+		output += cmd.emitResourceTypeNamesExtension()
 	}
 
 	return output
 }
 
+// emitResourceTypeNamesExtension returns the extension of the message options that contains the names of the
+// resource types in condensed mode.
+func (cmd *GenerateProtoCmd) emitResourceTypeNamesExtension() string {
+	return `
+extend google.protobuf.MessageOptions {
+	repeated string resource_type_names = 60000;
+}`
+}
+
 // emitClassOptions adds the class options IRI and parent when full semantic mode is enabled, otherwise it adds only the resource type name.
 func (cmd *GenerateProtoCmd) emitClassOptions(iri string) string {
 	var (
@@ -153,39 +548,72 @@ func (cmd *GenerateProtoCmd) emitClassOptions(iri string) string {
 		}
 	} else {
 		for _, typ := range cmd.getResourceTypeList(class) {
-			output += fmt.Sprintf("\toption (resource_type_names) = \"%s\";\n", typ)
+			output += fmt.Sprintf("\toption (%s) = \"%s\";\n", cmd.resourceTypeNamesOption(), typ)
 		}
 	}
 
 	return output
 }
 
-// emitPropertyOptions adds the property options IRI, parent and class IRI when full semantic mode is enabled.
+// emitPropertyOptions adds the validation rules of the property as well as the property options IRI, parent and class
+// IRI when full semantic mode is enabled.
 func (cmd *GenerateProtoCmd) emitPropertyOptions(r *ontology.Relationship) string {
 	var (
 		opts       []string
 		optsOutput string
+		items      string
 	)
-	// Make name and id mandatory
-	// TODO(oxisto): somehow extract this out of the ontology file itself which fields have constraints
-	if r.Name == "name" || r.Name == "id" {
-		opts = append(opts, "(buf.validate.field).required = true")
+	if r.Deprecated {
+		opts = append(opts, "deprecated = true")
+	}
+
+	// Properties with a minimum cardinality are mandatory, repeated ones can be restricted in their number of items
+	repeated := strings.HasPrefix(fieldType(r), util.Repeated)
+	opts = append(opts, cardinalityOptions(r.Cardinality, repeated)...)
+
+	// Rules of datatype restrictions apply to each item of repeated fields
+	if repeated {
+		items = "repeated.items."
+	}
+	for _, rule := range r.Rules {
+		opts = append(opts, fmt.Sprintf("(buf.validate.field).%s%s", items, rule))
 	}
 
 	if cmd.FullSemanticMode {
 		opts = append(opts, fmt.Sprintf("(owl.property).iri = \"%s\"", cmd.preparedOntology.AbbreviateIRI(r.IRI)))
-		// TODO(oxisto): Emit all the real property parents
-		opts = append(opts, fmt.Sprintf("(owl.property).parent = \"%s\"", "owl:topDataProperty"))
+		opts = append(opts, cmd.emitPropertyParents(r.IRI, "owl:topDataProperty")...)
 		opts = append(opts, fmt.Sprintf("(owl.property).class_iri = \"%s\"", cmd.preparedOntology.AbbreviateIRI(r.From)))
 	}
 
 	if len(opts) > 0 {
 		optsOutput = fmt.Sprintf(" [ %s ]", strings.Join(opts, ",\n\t"))
 	}
+	cmd.useOptions(opts)
 
 	return optsOutput
 }
 
+// cardinalityOptions returns the validation options of the cardinality of a field. Fields with a minimum cardinality
+// are required; repeated fields are instead restricted in their minimum number of items if more than one value is
+// needed, as well as in their maximum number of items.
+func cardinalityOptions(c *ontology.Cardinality, repeated bool) (opts []string) {
+	if c == nil {
+		return nil
+	}
+
+	if repeated && c.Min > 1 {
+		opts = append(opts, fmt.Sprintf("(buf.validate.field).repeated.min_items = %d", c.Min))
+	} else if c.Required() {
+		opts = append(opts, "(buf.validate.field).required = true")
+	}
+
+	if repeated && c.Max != ontology.Unbounded {
+		opts = append(opts, fmt.Sprintf("(buf.validate.field).repeated.max_items = %d", c.Max))
+	}
+
+	return opts
+}
+
 // emitObjectPropertyOptions adds the property options IRI, parent and class IRI when full semantic mode is enabled.
 func (cmd *GenerateProtoCmd) emitObjectPropertyOptions(r *ontology.ObjectRelationship) string {
 	var (
@@ -193,30 +621,48 @@ func (cmd *GenerateProtoCmd) emitObjectPropertyOptions(r *ontology.ObjectRelatio
 		optsOutput string
 	)
 
+	if r.Deprecated {
+		opts = append(opts, "deprecated = true")
+	}
+
+	// Properties with a minimum cardinality are mandatory, repeated ones can be restricted in their number of items
+	opts = append(opts, cardinalityOptions(r.Cardinality, r.Cardinality.Repeated())...)
+
 	if cmd.FullSemanticMode {
 		opts = append(opts, fmt.Sprintf("(owl.property).iri = \"%s\"", cmd.preparedOntology.AbbreviateIRI(r.ObjectProperty)))
-		// TODO(oxisto): Emit all the real property parents
-		opts = append(opts, fmt.Sprintf("(owl.property).parent = \"%s\"", "owl:topObjectProperty"))
+		opts = append(opts, cmd.emitPropertyParents(r.ObjectProperty, "owl:topObjectProperty")...)
 		opts = append(opts, fmt.Sprintf("(owl.property).class_iri = \"%s\"", cmd.preparedOntology.AbbreviateIRI(r.From)))
 	}
 
 	if len(opts) > 0 {
 		optsOutput = fmt.Sprintf(" [ %s ]", strings.Join(opts, ",\n\t"))
 	}
+	cmd.useOptions(opts)
 
 	return optsOutput
 }
 
+// emitPropertyParents returns the parent options of the property, i.e., all of its super-properties ordered from the
+// direct super-properties to the given top property.
+func (cmd *GenerateProtoCmd) emitPropertyParents(iri string, top string) (opts []string) {
+	for _, parent := range cmd.preparedOntology.PropertyAncestors(iri) {
+		opts = append(opts, fmt.Sprintf("(owl.property).parent = \"%s\"", cmd.preparedOntology.AbbreviateIRI(parent)))
+	}
+
+	return append(opts, fmt.Sprintf("(owl.property).parent = \"%s\"", top))
+}
+
 // addObjectProperties adds all object properties for the given resource to the output string
 // Object properties (e.g., "AccessRestriction access_restriction", "HttpEndpoint http_endpoint", "TransportEncryption transport_encryption")
-func (cmd *GenerateProtoCmd) addObjectProperties(output, rmk string) string {
+func (cmd *GenerateProtoCmd) addObjectProperties(output, rmk string) (string, error) {
 	var (
 		fieldNumber = 0
 		optsOutput  string
+		err         error
 	)
 
 	// Get all data properties of the given resource (rmk) and the parent resources
-	objectProperties := cmd.findAllObjectProperties(rmk)
+	objectProperties := cmd.preparedOntology.FindAllObjectProperties(rmk)
 
 	// Sort slice of object properties
 	sort.Slice(objectProperties, func(i, j int) bool {
@@ -229,70 +675,86 @@ func (cmd *GenerateProtoCmd) addObjectProperties(output, rmk string) string {
 	for _, o := range objectProperties {
 		resourceTypeList := cmd.getResourceTypeList(cmd.preparedOntology.Resources[rmk])
 
-		// Get field number
-		resourceTypeList = append(resourceTypeList, o.Name)
-		fieldNumber, cmd.i = util.GetFieldNumber(cmd.DeterministicFieldNumbers, cmd.i, resourceTypeList...)
+		// Properties with a maximum cardinality of 0 must not have any value
+		if o.Name != "" && o.ObjectProperty != "" && !o.Cardinality.Prohibited() {
+			value, typ, name := cmd.preparedOntology.GetObjectDetail(o)
+
+			// Skip properties that do not result in a field
+			if typ == "" || (value == "" && name == "") {
+				cmd.skipFieldNumber()
+				continue
+			}
+
+			optsOutput = cmd.emitObjectPropertyOptions(o)
+
+			// Get field number
+			resourceTypeList = append(resourceTypeList, o.Name)
+			fieldNumber, err = cmd.fieldNumber(fieldKey(o.ObjectProperty, o.To), util.ToSnakeCase(name), resourceTypeList...)
+			if err != nil {
+				return "", err
+			}
+
+			output += deprecationComment("\t", o.DeprecationReason)
 
-		optsOutput = cmd.emitObjectPropertyOptions(o)
+			// Embedded messages and enums of other modules are referenced by their fully qualified name
+			typ = cmd.qualify(typ, o.To)
 
-		if o.Name != "" && o.ObjectProperty != "" {
-			value, typ, name := cmd.preparedOntology.GetObjectDetail(o.ObjectPropertyName, cmd.preparedOntology.Resources[o.To])
 			if value != "" && typ != "" {
 				output += fmt.Sprintf("\n\t%s%s %s  = %d%s;", value, typ, util.ToSnakeCase(name), fieldNumber, optsOutput)
 			} else if typ != "" && name != "" {
 				output += fmt.Sprintf("\n\t%s %s = %d%s;", typ, util.ToSnakeCase(name), fieldNumber, optsOutput)
 			}
+		} else {
+			cmd.skipFieldNumber()
 		}
 	}
 
-	return output
+	return output, nil
 }
 
-// findAllLeafs returns a resource list of all leaf nodes of a given resource/class
-func findAllLeafs(class string, preparedOntology *ontology.OntologyPrepared) []*ontology.Resource {
-	var leafs []*ontology.Resource
-
-	r := preparedOntology.Resources[class]
-
-	if len(r.SubResources) == 0 {
-		leafs = append(leafs, r)
-	} else {
-		for _, s := range r.SubResources {
-			leafs = append(leafs, findAllLeafs(s.Iri, preparedOntology)...)
-		}
+// skipFieldNumber advances the counter of ascending field numbers for a property that does not result in a field,
+// e.g., because it is prohibited by a cardinality restriction. Thus, the numbers of the following fields do not
+// change, which would break the wire compatibility of messages that are generated without a lock file.
+func (cmd *GenerateProtoCmd) skipFieldNumber() {
+	if !cmd.DeterministicFieldNumbers {
+		cmd.i++
 	}
-
-	return leafs
 }
 
-// findAllObjectProperties adds all object properties for the given entity and the parents
-func (cmd *GenerateProtoCmd) findAllObjectProperties(iri string) []*ontology.ObjectRelationship {
+// findAllLeafs returns a resource list of all leaf nodes of a given resource/class. Leaf nodes that are reachable via
+// several sub-classes are only contained once.
+func findAllLeafs(class string, preparedOntology *ontology.OntologyPrepared) []*ontology.Resource {
 	var (
-		objectRelationships []*ontology.ObjectRelationship
-		parent              string
+		leafs   []*ontology.Resource
+		visited = map[string]bool{}
+		visit   func(class string)
 	)
 
-	res, ok := cmd.preparedOntology.Resources[iri]
-	if !ok {
-		slog.Error("Could not find entity", "iri", iri)
-		return nil
-	}
+	visit = func(class string) {
+		if visited[class] {
+			return
+		}
+		visited[class] = true
 
-	objectRelationships = append(objectRelationships, res.ObjectRelationship...)
+		r := preparedOntology.Resources[class]
 
-	parent = cmd.preparedOntology.Resources[iri].Parent
-	if parent == "" || iri == cmd.preparedOntology.RootResourceName {
-		return objectRelationships
-	} else {
-		objectRelationships = append(objectRelationships, cmd.findAllObjectProperties(parent)...)
+		if len(r.SubResources) == 0 {
+			leafs = append(leafs, r)
+		} else {
+			for _, s := range r.SubResources {
+				visit(s.Iri)
+			}
+		}
 	}
 
-	return objectRelationships
+	visit(class)
+
+	return leafs
 }
 
 // addObjectProperties adds all data properties for the given resource to the output string
 // Data properties (e.g., "bool enabled", "int64 interval", "int64 retention_period")
-func (cmd *GenerateProtoCmd) addDataProperties(output, rmk string) string {
+func (cmd *GenerateProtoCmd) addDataProperties(output, rmk string) (string, error) {
 	// Get all data properties of the given resource (rmk) and the parent resources
 	dataProperties := cmd.preparedOntology.FindAllDataProperties(rmk)
 
@@ -305,10 +767,12 @@ func (cmd *GenerateProtoCmd) addDataProperties(output, rmk string) string {
 
 	// Create output for the data properties
 	for _, r := range dataProperties {
-		if r.Typ != "" && r.Name != "" {
+		// Properties with a maximum cardinality of 0 must not have any value
+		if r.Typ != "" && r.Name != "" && !r.Cardinality.Prohibited() {
 			var (
 				optsOutput  string
 				fieldNumber = 0
+				err         error
 			)
 
 			// Get list of resource types for given  object
@@ -316,20 +780,41 @@ func (cmd *GenerateProtoCmd) addDataProperties(output, rmk string) string {
 
 			// Get field number
 			resourceTypeList = append(resourceTypeList, r.Name)
-			fieldNumber, cmd.i = util.GetFieldNumber(cmd.DeterministicFieldNumbers, cmd.i, resourceTypeList...)
+			fieldNumber, err = cmd.fieldNumber(fieldKey(r.IRI), util.ToSnakeCase(r.Name), resourceTypeList...)
+			if err != nil {
+				return "", err
+			}
 
 			optsOutput = cmd.emitPropertyOptions(r)
+			cmd.use(cmd.preparedOntology.ImportOf(r.Typ))
 
 			// Add data property comment if available
 			if r.Comment != "" {
 				output += fmt.Sprintf("\n\t// %s", r.Comment)
 			}
+			output += deprecationComment("\t", r.DeprecationReason)
 
-			output += fmt.Sprintf("\n\t%s %s = %d%s;", r.Typ, util.ToSnakeCase(r.Name), fieldNumber, optsOutput)
+			output += fmt.Sprintf("\n\t%s %s = %d%s;", cmd.qualify(fieldType(r), r.Datatype), util.ToSnakeCase(r.Name), fieldNumber, optsOutput)
+		} else if r.Cardinality.Prohibited() {
+			cmd.skipFieldNumber()
 		}
 	}
 
-	return output
+	return output, nil
+}
+
+// fieldType returns the type of the data property field including its label, i.e., "repeated" or "optional",
+// according to the cardinality of the property. Types that are already repeated or maps are not changed.
+func fieldType(r *ontology.Relationship) string {
+	if strings.HasPrefix(r.Typ, util.Repeated) || strings.HasPrefix(r.Typ, "map<") {
+		return r.Typ
+	} else if r.Cardinality.Repeated() {
+		return util.Repeated + r.Typ
+	} else if r.Cardinality.Optional() {
+		return "optional " + r.Typ
+	}
+
+	return r.Typ
 }
 
 func (cmd *GenerateProtoCmd) addClassHierarchy(output, iri string) string {
@@ -338,7 +823,7 @@ func (cmd *GenerateProtoCmd) addClassHierarchy(output, iri string) string {
 	return output
 }
 
-// getResourceTypeList returns a list of all derived resources
+// getResourceTypeList returns a list of the names of the resource and all of its parents
 func (cmd *GenerateProtoCmd) getResourceTypeList(resource *ontology.Resource) []string {
 	var resource_types []string
 
@@ -346,49 +831,93 @@ func (cmd *GenerateProtoCmd) getResourceTypeList(resource *ontology.Resource) []
 		return nil
 	}
 
-	if resource.Parent == "" {
-		return []string{resource.Name}
-	} else {
-		resource_types = append(resource_types, resource.Name)
-		resource_types = append(resource_types, cmd.getResourceTypeList(cmd.preparedOntology.Resources[resource.Parent])...)
+	resource_types = append(resource_types, resource.Name)
+	for _, iri := range cmd.preparedOntology.Ancestors(resource.Iri) {
+		if parent, ok := cmd.preparedOntology.Resources[iri]; ok {
+			resource_types = append(resource_types, parent.Name)
+		}
 	}
 
 	return resource_types
 }
 
-// getParents returns a list of all parent IRIs
+// getParents returns a list of all parent IRIs, linearized from the direct parents up to "owl:Thing"
 func (cmd *GenerateProtoCmd) getParents(resource *ontology.Resource) []string {
-	var iris []string
-
-	if resource.Parent == "" {
-		return []string{"owl:Thing"}
-	} else {
-		iris = append(iris, resource.Parent)
-		iris = append(iris, cmd.getParents(cmd.preparedOntology.Resources[resource.Parent])...)
-	}
-
-	return iris
+	return append(cmd.preparedOntology.Ancestors(resource.Iri), "owl:Thing")
 }
 
 func (cmd *GenerateProtoCmd) Run() (err error) {
-	cmd.prepare()
+	err = cmd.prepare()
+	if err != nil {
+		return err
+	}
 
-	// Read header content from file
-	b, err := os.ReadFile(cmd.HeaderFile)
+	// Read the header from the file or generate it
+	header, err := cmd.header()
 	if err != nil {
-		slog.Error("error reading header file", "location", cmd.HeaderFile, tint.Err(err))
-		return nil
+		return err
+	}
+
+	// Read locked field numbers
+	if cmd.LockFile != "" {
+		cmd.lock, err = readLockFile(cmd.LockFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Read previously generated proto file
+	if cmd.PreviousProto != "" {
+		cmd.previous, err = readPreviousProtos(cmd.PreviousProto)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Generate one proto file per module
+	if cmd.SplitBy != "none" {
+		err = cmd.runSplit(header)
+		if err != nil {
+			return err
+		}
+
+		return cmd.writeLock()
 	}
 
 	// Generate proto content
-	output := cmd.createProto(string(b))
+	output, err := cmd.createProto(header)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.checkWarnings()
+	if err != nil {
+		return err
+	}
 
 	// Write proto content to file
 	err = util.WriteFile(cmd.OutputPath, output)
 	if err != nil {
-		slog.Error("error writing proto file to storage", tint.Err(err))
+		return fmt.Errorf("error writing proto file to storage: %w", err)
 	}
 
 	slog.Info("proto file written to storage", slog.String("output folder", cmd.OutputPath))
-	return
+
+	return cmd.writeLock()
+}
+
+// writeLock writes the (updated) field numbers back to the lock file, if specified.
+func (cmd *GenerateProtoCmd) writeLock() (err error) {
+	if cmd.LockFile == "" {
+		return nil
+	}
+
+	err = writeLockFile(cmd.LockFile, cmd.lock)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("lock file written to storage", slog.String("location", cmd.LockFile))
+
+	return nil
 }
@@ -0,0 +1,380 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oxisto/owl2proto/ontology"
+	"github.com/oxisto/owl2proto/owl"
+)
+
+func Test_fieldType(t *testing.T) {
+	type args struct {
+		r *ontology.Relationship
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Unrestricted",
+			args: args{r: &ontology.Relationship{Typ: "string"}},
+			want: "string",
+		},
+		{
+			name: "Repeated",
+			args: args{r: &ontology.Relationship{Typ: "string", Cardinality: &ontology.Cardinality{Min: 0, Max: ontology.Unbounded}}},
+			want: "repeated string",
+		},
+		{
+			name: "Optional",
+			args: args{r: &ontology.Relationship{Typ: "int32", Cardinality: &ontology.Cardinality{Min: 0, Max: 1}}},
+			want: "optional int32",
+		},
+		{
+			name: "Already repeated",
+			args: args{r: &ontology.Relationship{Typ: "repeated string", Cardinality: &ontology.Cardinality{Min: 0, Max: 1}}},
+			want: "repeated string",
+		},
+		{
+			name: "Map",
+			args: args{r: &ontology.Relationship{Typ: "map<string, string>", Cardinality: &ontology.Cardinality{Min: 0, Max: 5}}},
+			want: "map<string, string>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fieldType(tt.args.r); got != tt.want {
+				t.Errorf("fieldType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_findAllLeafs(t *testing.T) {
+	var (
+		objectStorage = &ontology.Resource{Iri: "ex:ObjectStorage", Name: "ObjectStorage", Parents: []string{"ex:Storage", "ex:Service"}}
+		blockStorage  = &ontology.Resource{Iri: "ex:BlockStorage", Name: "BlockStorage", Parents: []string{"ex:Storage"}}
+		storage       = &ontology.Resource{Iri: "ex:Storage", Name: "Storage", Parents: []string{"ex:Resource"}, SubResources: []*ontology.Resource{objectStorage, blockStorage}}
+		service       = &ontology.Resource{Iri: "ex:Service", Name: "Service", Parents: []string{"ex:Resource"}, SubResources: []*ontology.Resource{objectStorage}}
+		resource      = &ontology.Resource{Iri: "ex:Resource", Name: "Resource", SubResources: []*ontology.Resource{storage, service}}
+		po            = &ontology.OntologyPrepared{Resources: map[string]*ontology.Resource{
+			"ex:ObjectStorage": objectStorage,
+			"ex:BlockStorage":  blockStorage,
+			"ex:Storage":       storage,
+			"ex:Service":       service,
+			"ex:Resource":      resource,
+		}}
+	)
+
+	tests := []struct {
+		name  string
+		class string
+		want  []*ontology.Resource
+	}{
+		{
+			name:  "Leaf with several parents is contained once",
+			class: "ex:Resource",
+			want:  []*ontology.Resource{objectStorage, blockStorage},
+		},
+		{
+			name:  "Leaf is contained in every parent",
+			class: "ex:Service",
+			want:  []*ontology.Resource{objectStorage},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findAllLeafs(tt.class, po); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findAllLeafs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateProtoCmd_emitEnum(t *testing.T) {
+	enum := &ontology.Enum{
+		IRI:     "http://example.com/cloud/Severity",
+		Name:    "Severity",
+		Comment: []string{"The severity of a finding."},
+		Values: []*ontology.EnumValue{
+			{IRI: "http://example.com/cloud/High", Name: "SEVERITY_HIGH"},
+			{Literal: "\"low\"", Name: "SEVERITY_LOW", Deprecated: true},
+		},
+	}
+	po := &ontology.OntologyPrepared{Prefixes: map[string]*owl.Prefix{
+		"ex": {Name: "ex", IRI: "http://example.com/cloud/"},
+	}}
+
+	tests := []struct {
+		name             string
+		fullSemanticMode bool
+		lock             *FieldNumberLock
+		want             string
+	}{
+		{
+			name: "Without options",
+			want: `
+// Severity is an enumeration in our ontology.
+// The severity of a finding.
+enum Severity {
+	SEVERITY_UNSPECIFIED = 0;
+	SEVERITY_HIGH = 1;
+	SEVERITY_LOW = 2 [ deprecated = true ];
+}
+`,
+		},
+		{
+			name:             "Full semantic mode",
+			fullSemanticMode: true,
+			want: `
+// Severity is an enumeration in our ontology.
+// The severity of a finding.
+enum Severity {
+	option (owl.enum).iri = "ex:Severity";
+
+	SEVERITY_UNSPECIFIED = 0;
+	SEVERITY_HIGH = 1 [ (owl.individual).iri = "ex:High" ];
+	SEVERITY_LOW = 2 [ deprecated = true, (owl.literal) = "\"low\"" ];
+}
+`,
+		},
+		{
+			name: "Locked and removed values",
+			lock: &FieldNumberLock{Enums: map[string]*EnumLock{
+				"http://example.com/cloud/Severity": {Name: "Severity", Values: map[string]*FieldLock{
+					"http://example.com/cloud/Medium": {Name: "SEVERITY_MEDIUM", Number: 2},
+					"http://example.com/cloud/High":   {Name: "SEVERITY_HIGH", Number: 3},
+				}},
+			}},
+			want: `
+// Severity is an enumeration in our ontology.
+// The severity of a finding.
+enum Severity {
+	SEVERITY_UNSPECIFIED = 0;
+	SEVERITY_HIGH = 3;
+	SEVERITY_LOW = 4 [ deprecated = true ];
+	reserved 2;
+	reserved "SEVERITY_MEDIUM";
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &GenerateProtoCmd{FullSemanticMode: tt.fullSemanticMode, lock: tt.lock}
+			cmd.preparedOntology = po
+
+			if got := cmd.emitEnum(enum); got != tt.want {
+				t.Errorf("GenerateProtoCmd.emitEnum() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateProtoCmd_emitPropertyOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		r           *ontology.Relationship
+		want        string
+		wantImports map[string]bool
+	}{
+		{
+			name: "No options",
+			r:    &ontology.Relationship{Typ: "string"},
+			want: "",
+		},
+		{
+			name: "Rules",
+			r: &ontology.Relationship{
+				Typ:         "string",
+				Cardinality: &ontology.Cardinality{Min: 1, Max: 1},
+				Rules:       []string{"string.max_len = 255"},
+			},
+			want:        " [ (buf.validate.field).required = true,\n\t(buf.validate.field).string.max_len = 255 ]",
+			wantImports: map[string]bool{validateImport: true},
+		},
+		{
+			name: "Rules of repeated fields apply to the items",
+			r: &ontology.Relationship{
+				Typ:         "int32",
+				Cardinality: &ontology.Cardinality{Min: 0, Max: ontology.Unbounded},
+				Rules:       []string{"int32.gte = 0"},
+			},
+			want:        " [ (buf.validate.field).repeated.items.int32.gte = 0 ]",
+			wantImports: map[string]bool{validateImport: true},
+		},
+		{
+			name: "Number of items of repeated fields",
+			r: &ontology.Relationship{
+				Typ:         "string",
+				Cardinality: &ontology.Cardinality{Min: 2, Max: 5},
+			},
+			want:        " [ (buf.validate.field).repeated.min_items = 2,\n\t(buf.validate.field).repeated.max_items = 5 ]",
+			wantImports: map[string]bool{validateImport: true},
+		},
+		{
+			name: "Required repeated field with a maximum",
+			r: &ontology.Relationship{
+				Typ:         "string",
+				Cardinality: &ontology.Cardinality{Min: 1, Max: 3},
+			},
+			want:        " [ (buf.validate.field).required = true,\n\t(buf.validate.field).repeated.max_items = 3 ]",
+			wantImports: map[string]bool{validateImport: true},
+		},
+		{
+			name: "Deprecated",
+			r:    &ontology.Relationship{Typ: "string", Deprecated: true, DeprecationReason: "Use display_name instead."},
+			want: " [ deprecated = true ]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &GenerateProtoCmd{}
+
+			if got := cmd.emitPropertyOptions(tt.r); got != tt.want {
+				t.Errorf("GenerateProtoCmd.emitPropertyOptions() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(cmd.imports, tt.wantImports) {
+				t.Errorf("GenerateProtoCmd.emitPropertyOptions() imports = %v, want %v", cmd.imports, tt.wantImports)
+			}
+		})
+	}
+}
+
+func TestGenerateProtoCmd_addDataProperties(t *testing.T) {
+	po := &ontology.OntologyPrepared{Resources: map[string]*ontology.Resource{
+		"ex:Storage": {Iri: "ex:Storage", Name: "Storage", Relationship: []*ontology.Relationship{
+			{IRI: "ex:enabled", Name: "enabled", Typ: "bool", Cardinality: &ontology.Cardinality{Min: 0, Max: 0}},
+			{IRI: "ex:name", Name: "name", Typ: "string"},
+		}},
+	}}
+
+	tests := []struct {
+		name                      string
+		deterministicFieldNumbers bool
+		want                      string
+	}{
+		{
+			name: "Prohibited properties keep their ascending field number",
+			want: "\n\tstring name = 2;",
+		},
+		{
+			name:                      "Deterministic field numbers",
+			deterministicFieldNumbers: true,
+			want:                      "\n\tstring name = 7675;",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &GenerateProtoCmd{
+				DeterministicFieldNumbers: tt.deterministicFieldNumbers,
+				message:                   &MessageLock{Name: "Storage", Fields: map[string]*FieldLock{}},
+				previousMessage:           &previousMessage{},
+				numbers:                   map[int]string{},
+				emitted:                   map[int]string{},
+			}
+			cmd.preparedOntology = po
+
+			got, err := cmd.addDataProperties("", "ex:Storage")
+			if err != nil {
+				t.Fatalf("GenerateProtoCmd.addDataProperties() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GenerateProtoCmd.addDataProperties() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_deprecationComment(t *testing.T) {
+	type args struct {
+		indent string
+		reason string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "No reason",
+			args: args{indent: "\t"},
+			want: "",
+		},
+		{
+			name: "Reason",
+			args: args{indent: "\t", reason: "Use display_name instead."},
+			want: "\n\t// Deprecated: Use display_name instead.",
+		},
+		{
+			name: "Multi-line reason",
+			args: args{indent: "\t", reason: "Use display_name instead.\r\n\nIt will be removed in v2.\n"},
+			want: "\n\t// Deprecated: Use display_name instead.\n\t//\n\t// It will be removed in v2.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deprecationComment(tt.args.indent, tt.args.reason); got != tt.want {
+				t.Errorf("deprecationComment() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_emitImports(t *testing.T) {
+	type args struct {
+		header  string
+		imports []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "No imports",
+			args: args{header: "syntax = \"proto3\";\n"},
+			want: "syntax = \"proto3\";\n",
+		},
+		{
+			name: "Imports are sorted and not repeated",
+			args: args{
+				header:  "syntax = \"proto3\";\n\nimport \"google/protobuf/timestamp.proto\";\n\noption go_package = \"example\";\n",
+				imports: []string{"google/protobuf/timestamp.proto", "google/type/money.proto", "google/protobuf/duration.proto"},
+			},
+			want: "syntax = \"proto3\";\n\nimport \"google/protobuf/timestamp.proto\";\nimport \"google/protobuf/duration.proto\";\nimport \"google/type/money.proto\";\n\noption go_package = \"example\";\n",
+		},
+		{
+			name: "Public and weak imports of the header",
+			args: args{
+				header:  "import public \"owl/owl.proto\";\nimport weak  \"buf/validate/validate.proto\" ;",
+				imports: []string{"owl/owl.proto", "buf/validate/validate.proto"},
+			},
+			want: "import public \"owl/owl.proto\";\nimport weak  \"buf/validate/validate.proto\" ;",
+		},
+		{
+			name: "Imports after package",
+			args: args{
+				header:  "syntax = \"proto3\";\n\npackage example.v1;\n\noption go_package = \"example\";\n",
+				imports: []string{"owl/owl.proto"},
+			},
+			want: "syntax = \"proto3\";\n\npackage example.v1;\n\nimport \"owl/owl.proto\";\n\noption go_package = \"example\";\n",
+		},
+		{
+			name: "Imports after empty header",
+			args: args{
+				imports: []string{"owl/owl.proto"},
+			},
+			want: "\nimport \"owl/owl.proto\";\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := emitImports(tt.args.header, tt.args.imports); got != tt.want {
+				t.Errorf("emitImports() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package commands
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/oxisto/owl2proto/internal/util"
+)
+
+var (
+	messageRegexp  = regexp.MustCompile(`^\s*message\s+(\w+)\s*{`)
+	fieldRegexp    = regexp.MustCompile(`^\s*(?:repeated\s+|optional\s+)?[\w.]+(?:<[^>]*>)?\s+(\w+)\s*=\s*(\d+)`)
+	reservedRegexp = regexp.MustCompile(`^\s*reserved\s+([^;]*);`)
+)
+
+// previousMessage contains the fields and reserved statements of a message in a previously generated proto file.
+type previousMessage struct {
+	// Fields contains the field numbers, indexed by the field name
+	Fields         map[string]int
+	ReservedRanges []reservedRange
+	ReservedNames  []string
+}
+
+// reservedRange is a range of reserved field numbers, e.g., "5 to 7". The end of a range up to "max" is
+// [util.MaxProtoFieldNumber].
+type reservedRange struct {
+	Start int
+	End   int
+}
+
+// String returns the range as used in reserved statements, e.g., "5", "5 to 7" or "100 to max".
+func (r reservedRange) String() string {
+	switch r.End {
+	case r.Start:
+		return strconv.Itoa(r.Start)
+	case util.MaxProtoFieldNumber:
+		return fmt.Sprintf("%d to max", r.Start)
+	default:
+		return fmt.Sprintf("%d to %d", r.Start, r.End)
+	}
+}
+
+// readPreviousProtos reads the messages of a previously generated proto file or, if path is a directory, of all proto
+// files within the directory, e.g., the output of a previous run that was split into modules. The messages are indexed
+// by their fully qualified name, because messages of different packages can have the same name.
+func readPreviousProtos(path string) (messages map[string]*previousMessage, err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading previous proto file: %w", err)
+	} else if !info.IsDir() {
+		return readPreviousProto(path)
+	}
+
+	messages = make(map[string]*previousMessage)
+
+	err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(file) != ".proto" {
+			return err
+		}
+
+		m, err := readPreviousProto(file)
+		if err != nil {
+			return err
+		}
+
+		maps.Copy(messages, m)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// readPreviousProto reads the messages of a previously generated proto file, indexed by their fully qualified name.
+// This is not a full proto parser, it only understands the package and the (top-level) messages that are generated by
+// owl2proto.
+func readPreviousProto(path string) (messages map[string]*previousMessage, err error) {
+	var (
+		f     *os.File
+		m     *previousMessage
+		pkg   string
+		depth int
+		block bool
+	)
+
+	f, err = os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading previous proto file: %w", err)
+	}
+	defer f.Close()
+
+	messages = make(map[string]*previousMessage)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, braces := scanLine(scanner.Text(), &block)
+
+		if depth == 0 {
+			if match := headerPackagePattern.FindStringSubmatch(line); match != nil {
+				pkg = match[1]
+			} else if match := messageRegexp.FindStringSubmatch(line); match != nil {
+				m = &previousMessage{Fields: map[string]int{}}
+				messages[qualifiedName(pkg, match[1])] = m
+			}
+		} else if m != nil {
+			if match := reservedRegexp.FindStringSubmatch(line); match != nil {
+				err = m.parseReserved(match[1])
+				if err != nil {
+					return nil, err
+				}
+			} else if match := fieldRegexp.FindStringSubmatch(line); match != nil {
+				number, _ := strconv.Atoi(match[2])
+				m.Fields[match[1]] = number
+			}
+		}
+
+		depth += braces
+		if depth == 0 {
+			m = nil
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error reading previous proto file: %w", err)
+	}
+
+	return messages, nil
+}
+
+// scanLine removes the comments of a line of a proto file and returns the change of the nesting depth, i.e., the number
+// of opening minus closing braces. Braces and comment markers within string literals are ignored. Since block comments
+// can span multiple lines, block keeps track whether the line starts within a block comment.
+func scanLine(line string, block *bool) (code string, braces int) {
+	var (
+		b     strings.Builder
+		quote byte
+	)
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+
+		switch {
+		case *block:
+			if strings.HasPrefix(line[i:], "*/") {
+				*block = false
+				i++
+			}
+			continue
+		case quote != 0:
+			if c == '\\' && i+1 < len(line) {
+				b.WriteByte(c)
+				i++
+				c = line[i]
+			} else if c == quote {
+				quote = 0
+			}
+		case strings.HasPrefix(line[i:], "//"):
+			return b.String(), braces
+		case strings.HasPrefix(line[i:], "/*"):
+			*block = true
+			i++
+			continue
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '{':
+			braces++
+		case c == '}':
+			braces--
+		}
+
+		b.WriteByte(c)
+	}
+
+	return b.String(), braces
+}
+
+// findPreviousMessage returns the message with the given name of the package in the previously generated proto file.
+// If the package does not contain the message, e.g., because the output was not split before, a message with the same
+// name is used, as long as only one package contains it.
+func (cmd *GenerateProtoCmd) findPreviousMessage(pkg string, name string) (m *previousMessage, ok bool) {
+	m, ok = cmd.previous[qualifiedName(pkg, name)]
+	if ok {
+		return m, true
+	}
+
+	for key, other := range cmd.previous {
+		if key[strings.LastIndex(key, ".")+1:] != name {
+			continue
+		} else if m != nil {
+			return nil, false
+		}
+
+		m = other
+	}
+
+	return m, m != nil
+}
+
+// parseReserved parses the content of a reserved statement, e.g., `1, 5 to 7` or `"name", "id"`.
+func (m *previousMessage) parseReserved(s string) error {
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+
+		if strings.HasPrefix(part, `"`) || strings.HasPrefix(part, `'`) {
+			m.ReservedNames = append(m.ReservedNames, strings.Trim(part, `"'`))
+			continue
+		}
+
+		from, to, isRange := strings.Cut(part, " to ")
+		if !isRange {
+			to = from
+		}
+
+		start, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil {
+			return fmt.Errorf("invalid reserved statement %q: %w", s, err)
+		}
+
+		end := util.MaxProtoFieldNumber
+		if strings.TrimSpace(to) != "max" {
+			end, err = strconv.Atoi(strings.TrimSpace(to))
+			if err != nil {
+				return fmt.Errorf("invalid reserved statement %q: %w", s, err)
+			}
+		}
+
+		m.ReservedRanges = append(m.ReservedRanges, reservedRange{Start: start, End: end})
+	}
+
+	return nil
+}
+
+// emitReserved emits reserved statements for all field numbers and names of the current message that are contained in
+// the lock or the previous proto file, but are not used anymore, e.g., because the property was removed from the
+// ontology.
+func (cmd *GenerateProtoCmd) emitReserved() string {
+	var (
+		numbers = make(map[int]bool)
+		names   = make(map[string]bool)
+		used    = make(map[string]bool)
+	)
+
+	for _, name := range cmd.emitted {
+		used[name] = true
+	}
+
+	reserve := func(number int, name string) {
+		if _, ok := cmd.emitted[number]; !ok && number > 0 {
+			numbers[number] = true
+		}
+		if name != "" && !used[name] {
+			names[name] = true
+		}
+	}
+
+	for _, f := range cmd.message.Fields {
+		reserve(f.Number, f.Name)
+	}
+
+	for name, number := range cmd.previousMessage.Fields {
+		reserve(number, name)
+	}
+
+	for _, name := range cmd.previousMessage.ReservedNames {
+		reserve(0, name)
+	}
+
+	// Reserved ranges stay reserved, except for the numbers that are used by a field again
+	ranges := numberRanges(numbers)
+	for _, r := range cmd.previousMessage.ReservedRanges {
+		start := r.Start
+		for _, number := range util.SortMapKeys(cmd.emitted) {
+			if number >= start && number <= r.End {
+				if number > start {
+					ranges = append(ranges, reservedRange{Start: start, End: number - 1})
+				}
+				start = number + 1
+			}
+		}
+
+		if start <= r.End {
+			ranges = append(ranges, reservedRange{Start: start, End: r.End})
+		}
+	}
+
+	return reservedStatements(ranges, names)
+}
+
+// emitEnumReserved emits reserved statements for all numbers and names of enum values that are contained in the lock,
+// but are not part of the enumeration anymore. The keys contain the values of the enumeration.
+func emitEnumReserved(lock *EnumLock, keys map[string]bool) string {
+	var (
+		numbers = make(map[int]bool)
+		names   = make(map[string]bool)
+		used    = make(map[string]bool)
+	)
+
+	for key := range keys {
+		if v, ok := lock.Values[key]; ok {
+			used[v.Name] = true
+		}
+	}
+
+	for key, v := range lock.Values {
+		if keys[key] {
+			continue
+		}
+
+		numbers[v.Number] = true
+		if !used[v.Name] {
+			names[v.Name] = true
+		}
+	}
+
+	return reservedStatements(numberRanges(numbers), names)
+}
+
+// reservedStatements returns the reserved statements of the ranges of numbers and the names, sorted in ascending
+// order. Overlapping and adjacent ranges are merged, e.g., "1, 2, 3" into "1 to 3".
+func reservedStatements(ranges []reservedRange, names map[string]bool) (output string) {
+	if len(ranges) > 0 {
+		var (
+			merged []reservedRange
+			list   []string
+		)
+
+		slices.SortFunc(ranges, func(a, b reservedRange) int {
+			return cmp.Compare(a.Start, b.Start)
+		})
+
+		for _, r := range ranges {
+			if last := len(merged) - 1; last >= 0 && r.Start <= merged[last].End+1 {
+				merged[last].End = max(merged[last].End, r.End)
+			} else {
+				merged = append(merged, r)
+			}
+		}
+
+		for _, r := range merged {
+			list = append(list, r.String())
+		}
+
+		output += fmt.Sprintf("\n\treserved %s;", strings.Join(list, ", "))
+	}
+
+	if len(names) > 0 {
+		var list []string
+		for _, name := range util.SortMapKeys(names) {
+			list = append(list, strconv.Quote(name))
+		}
+
+		output += fmt.Sprintf("\n\treserved %s;", strings.Join(list, ", "))
+	}
+
+	return output
+}
+
+// numberRanges returns a range for each of the numbers.
+func numberRanges(numbers map[int]bool) (ranges []reservedRange) {
+	for number := range numbers {
+		ranges = append(ranges, reservedRange{Start: number, End: number})
+	}
+
+	return ranges
+}
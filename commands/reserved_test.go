@@ -0,0 +1,314 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/oxisto/owl2proto/internal/util"
+)
+
+func Test_previousMessage_parseReserved(t *testing.T) {
+	type args struct {
+		s string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *previousMessage
+		wantErr bool
+	}{
+		{
+			name: "Happy path: numbers",
+			args: args{
+				s: "1, 5 to 7",
+			},
+			want: &previousMessage{
+				ReservedRanges: []reservedRange{{Start: 1, End: 1}, {Start: 5, End: 7}},
+			},
+		},
+		{
+			name: "Happy path: max",
+			args: args{
+				s: "100 to max",
+			},
+			want: &previousMessage{
+				ReservedRanges: []reservedRange{{Start: 100, End: util.MaxProtoFieldNumber}},
+			},
+		},
+		{
+			name: "Happy path: names",
+			args: args{
+				s: `"name", "id"`,
+			},
+			want: &previousMessage{
+				ReservedNames: []string{"name", "id"},
+			},
+		},
+		{
+			name: "Invalid number",
+			args: args{
+				s: "one",
+			},
+			want:    &previousMessage{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &previousMessage{}
+			if err := m.parseReserved(tt.args.s); (err != nil) != tt.wantErr {
+				t.Errorf("previousMessage.parseReserved() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(m, tt.want) {
+				t.Errorf("previousMessage.parseReserved() = %v, want %v", m, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateProtoCmd_emitReserved(t *testing.T) {
+	type fields struct {
+		message         *MessageLock
+		emitted         map[int]string
+		previousMessage *previousMessage
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name: "Nothing removed",
+			fields: fields{
+				message: &MessageLock{
+					Fields: map[string]*FieldLock{
+						"http://example.com/cloud/name": {Name: "name", Number: 8027},
+					},
+				},
+				emitted:         map[int]string{8027: "name"},
+				previousMessage: &previousMessage{},
+			},
+			want: "",
+		},
+		{
+			name: "Removed fields",
+			fields: fields{
+				message: &MessageLock{
+					Fields: map[string]*FieldLock{
+						"http://example.com/cloud/name": {Name: "name", Number: 8027},
+					},
+				},
+				emitted: map[int]string{},
+				previousMessage: &previousMessage{
+					Fields:         map[string]int{"id": 12},
+					ReservedRanges: []reservedRange{{Start: 3, End: 3}},
+					ReservedNames:  []string{"description"},
+				},
+			},
+			want: "\n\treserved 3, 12, 8027;\n\treserved \"description\", \"id\", \"name\";",
+		},
+		{
+			name: "Ranges are merged and kept",
+			fields: fields{
+				message: &MessageLock{
+					Fields: map[string]*FieldLock{
+						"http://example.com/cloud/id":   {Name: "id", Number: 4},
+						"http://example.com/cloud/name": {Name: "name", Number: 8},
+					},
+				},
+				emitted: map[int]string{8: "name"},
+				previousMessage: &previousMessage{
+					Fields:         map[string]int{"id": 4},
+					ReservedRanges: []reservedRange{{Start: 1, End: 3}, {Start: 5, End: 6}, {Start: 100, End: util.MaxProtoFieldNumber}},
+				},
+			},
+			want: "\n\treserved 1 to 6, 100 to max;\n\treserved \"id\";",
+		},
+		{
+			name: "Numbers of ranges that are used again",
+			fields: fields{
+				message: &MessageLock{
+					Fields: map[string]*FieldLock{
+						"http://example.com/cloud/name": {Name: "name", Number: 8},
+					},
+				},
+				emitted: map[int]string{8: "name"},
+				previousMessage: &previousMessage{
+					ReservedRanges: []reservedRange{{Start: 5, End: util.MaxProtoFieldNumber}},
+				},
+			},
+			want: "\n\treserved 5 to 7, 9 to max;",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &GenerateProtoCmd{
+				message:         tt.fields.message,
+				emitted:         tt.fields.emitted,
+				previousMessage: tt.fields.previousMessage,
+			}
+			if got := cmd.emitReserved(); got != tt.want {
+				t.Errorf("GenerateProtoCmd.emitReserved() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_readPreviousProtos(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"example/ex/v1/ex.proto": `syntax = "proto3";
+
+package example.ex.v1;
+
+message Resource {
+	string name = 1;
+}
+`,
+		"example/sec/v1/sec.proto": `syntax = "proto3";
+
+package example.sec.v1;
+
+// Resource is a resource {of the ontology.
+message Resource {
+	/* A block comment } with a brace
+	   that spans several lines */
+	string id = 2 [ (owl.property).iri = "sec:id}" ];
+	reserved 3;
+}
+`,
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := readPreviousProtos(dir)
+	if err != nil {
+		t.Fatalf("readPreviousProtos() error = %v", err)
+	}
+
+	want := map[string]*previousMessage{
+		".example.ex.v1.Resource":  {Fields: map[string]int{"name": 1}},
+		".example.sec.v1.Resource": {Fields: map[string]int{"id": 2}, ReservedRanges: []reservedRange{{Start: 3, End: 3}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readPreviousProtos() = %v, want %v", got, want)
+	}
+}
+
+func TestGenerateProtoCmd_findPreviousMessage(t *testing.T) {
+	var (
+		resource = &previousMessage{Fields: map[string]int{"name": 1}}
+		other    = &previousMessage{Fields: map[string]int{"id": 2}}
+		storage  = &previousMessage{Fields: map[string]int{"size": 3}}
+		previous = map[string]*previousMessage{
+			".example.ex.v1.Resource":  resource,
+			".example.sec.v1.Resource": other,
+			".example.v1.Storage":      storage,
+		}
+	)
+
+	type args struct {
+		pkg  string
+		name string
+	}
+	tests := []struct {
+		name   string
+		args   args
+		want   *previousMessage
+		wantOk bool
+	}{
+		{
+			name:   "Same package",
+			args:   args{pkg: "example.sec.v1", name: "Resource"},
+			want:   other,
+			wantOk: true,
+		},
+		{
+			name:   "Message of another package",
+			args:   args{pkg: "example.ex.v1", name: "Storage"},
+			want:   storage,
+			wantOk: true,
+		},
+		{
+			name: "Ambiguous message of other packages",
+			args: args{pkg: "example.v1", name: "Resource"},
+		},
+		{
+			name: "Unknown message",
+			args: args{pkg: "example.v1", name: "Firewall"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &GenerateProtoCmd{previous: previous}
+
+			got, ok := cmd.findPreviousMessage(tt.args.pkg, tt.args.name)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("GenerateProtoCmd.findPreviousMessage() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_scanLine(t *testing.T) {
+	type args struct {
+		line  string
+		block bool
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantCode   string
+		wantBraces int
+		wantBlock  bool
+	}{
+		{
+			name:       "Message",
+			args:       args{line: "message Storage {"},
+			wantCode:   "message Storage {",
+			wantBraces: 1,
+		},
+		{
+			name:     "Line comment",
+			args:     args{line: "\tstring name = 1; // the name {"},
+			wantCode: "\tstring name = 1; ",
+		},
+		{
+			name:     "String literal",
+			args:     args{line: `option (owl.meta).iri = "}\"//";`},
+			wantCode: `option (owl.meta).iri = "}\"//";`,
+		},
+		{
+			name:       "Start of block comment",
+			args:       args{line: "} /* closed {"},
+			wantCode:   "} ",
+			wantBraces: -1,
+			wantBlock:  true,
+		},
+		{
+			name:       "End of block comment",
+			args:       args{line: "still a comment } */ }", block: true},
+			wantCode:   " }",
+			wantBraces: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := tt.args.block
+
+			code, braces := scanLine(tt.args.line, &block)
+			if code != tt.wantCode || braces != tt.wantBraces || block != tt.wantBlock {
+				t.Errorf("scanLine() = (%q, %v, %v), want (%q, %v, %v)", code, braces, block, tt.wantCode, tt.wantBraces, tt.wantBlock)
+			}
+		})
+	}
+}
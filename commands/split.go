@@ -0,0 +1,358 @@
+package commands
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/oxisto/owl2proto/internal/util"
+)
+
+// defaultModule is the module of classes and enumerations whose IRI does not match any named prefix.
+const defaultModule = "ontology"
+
+// optionsFile is the file that declares the extension of the message options in condensed mode, if the output is
+// split.
+const optionsFile = "options.proto"
+
+// abstractSuffix is the suffix of the file of a module that contains its abstract classes, e.g., "ex_abstract.proto".
+const abstractSuffix = "_abstract"
+
+// module contains the messages and enumerations of one module of the ontology, which are written to a separate proto
+// file and package.
+type module struct {
+	// Name is the name of the module, e.g., the name of the prefix
+	Name string
+
+	// Package is the proto package of the module, e.g., "example.sec.v1"
+	Package string
+
+	// File is the path of the proto file relative to the output directory, e.g., "example/sec/v1/sec.proto"
+	File string
+}
+
+// newModule returns the module with the given name within the base package. The file of the module is named after
+// the module and the suffix, if any.
+func newModule(base string, name string, suffix string) *module {
+	m := &module{Name: name, Package: modulePackage(base, name)}
+	m.File = filepath.ToSlash(filepath.Join(strings.ReplaceAll(m.Package, ".", "/"), name+suffix+".proto"))
+
+	return m
+}
+
+// goPackagePattern, javaPackagePattern and csharpNamespacePattern match the respective file options of the header.
+var (
+	goPackagePattern       = regexp.MustCompile(`(?m)^(\s*option\s+go_package\s*=\s*)"([^"]*)"`)
+	javaPackagePattern     = regexp.MustCompile(`(?m)^(\s*option\s+java_package\s*=\s*)"([^"]*)"`)
+	csharpNamespacePattern = regexp.MustCompile(`(?m)^(\s*option\s+csharp_namespace\s*=\s*)"([^"]*)"`)
+)
+
+// prepareModules assigns all classes and enumerations to modules, either according to the prefix of their IRI or to
+// their o2p:module annotation. The packages of the modules are derived from the package of the header. The abstract
+// classes of a module are placed in a separate file of the same package, because their oneof lists the leaf classes
+// of all modules. Otherwise, a module that contains the root class would import all other modules and none of them
+// could embed a message of that module without creating an import cycle. An error is returned if two classes or
+// enumerations of the same package result in the same type name.
+func (cmd *GenerateProtoCmd) prepareModules(header string) (modules []*module, err error) {
+	var (
+		base     = headerPackage(header)
+		byName   = make(map[string]*module)
+		abstract = make(map[string]*module)
+		types    = make(map[string]string)
+		iris     = append(util.SortMapKeys(cmd.preparedOntology.Resources), util.SortMapKeys(cmd.preparedOntology.Enums)...)
+	)
+
+	cmd.modules = make(map[string]*module)
+
+	for _, iri := range iris {
+		// Enumerations are contained in the resources as well, if they are classes
+		if _, ok := cmd.modules[iri]; ok {
+			continue
+		}
+
+		name := packageComponent(cmd.preparedOntology.ModuleOf(iri, cmd.SplitBy == "annotation"))
+		if name == "" {
+			name = defaultModule
+		}
+
+		files, suffix := byName, ""
+		if cmd.isAbstract(iri) {
+			files, suffix = abstract, abstractSuffix
+		}
+
+		m, ok := files[name]
+		if !ok {
+			m = newModule(base, name, suffix)
+			files[name] = m
+			modules = append(modules, m)
+		}
+
+		cmd.modules[iri] = m
+
+		typ := qualifiedName(m.Package, cmd.typeName(iri))
+		if other, ok := types[typ]; ok {
+			return nil, fmt.Errorf("classes or enumerations %s and %s both result in the type %s", other, iri, typ)
+		}
+		types[typ] = iri
+	}
+
+	return modules, nil
+}
+
+// typeName returns the name of the message or enum of the class or enumeration with the given IRI.
+func (cmd *GenerateProtoCmd) typeName(iri string) string {
+	if enum, ok := cmd.preparedOntology.Enums[iri]; ok {
+		return enum.Name
+	}
+
+	return cmd.preparedOntology.Resources[iri].Name
+}
+
+// isAbstract returns true if the IRI belongs to an abstract class, i.e., a class with sub-classes that is emitted as
+// message with a oneof of its leaf classes.
+func (cmd *GenerateProtoCmd) isAbstract(iri string) bool {
+	res, ok := cmd.preparedOntology.Resources[iri]
+	if !ok {
+		return false
+	}
+
+	_, enum := cmd.preparedOntology.Enums[iri]
+
+	return !enum && len(res.SubResources) > 0
+}
+
+// inModule returns true if the class or enumeration belongs to the module that is currently generated. This is always
+// the case if the output is not split.
+func (cmd *GenerateProtoCmd) inModule(iri string) bool {
+	return cmd.module == nil || cmd.modules[iri] == cmd.module
+}
+
+// qualify returns the fully qualified name of the message or enum type of the class or enumeration with the given IRI
+// (including the label of typ, e.g., "repeated"), if it belongs to a different module than the one that is currently
+// generated. The file of the other module (or the other file of the same module) is imported. Types that are not the
+// type of the class or enumeration, e.g., the string of a reference, are returned unchanged.
+func (cmd *GenerateProtoCmd) qualify(typ string, iri string) string {
+	var (
+		label string
+		name  = typ
+	)
+
+	if cmd.module == nil {
+		return typ
+	}
+
+	if idx := strings.LastIndex(typ, " "); idx != -1 {
+		label, name = typ[:idx+1], typ[idx+1:]
+	}
+
+	m, ok := cmd.modules[iri]
+	if !ok || m == cmd.module || name != cmd.typeName(iri) {
+		return typ
+	}
+
+	cmd.use(m.File)
+
+	if cmd.dependencies[cmd.module.File] == nil {
+		cmd.dependencies[cmd.module.File] = make(map[string]bool)
+	}
+	cmd.dependencies[cmd.module.File][m.File] = true
+
+	// The abstract classes of a module are in a separate file of the same package
+	if m.Package == cmd.module.Package {
+		return typ
+	}
+
+	return label + qualifiedName(m.Package, name)
+}
+
+// resourceTypeNamesOption returns the name of the option that contains the resource type names in condensed mode.
+// If the output is split, the option is declared in a separate file.
+func (cmd *GenerateProtoCmd) resourceTypeNamesOption() string {
+	if cmd.module == nil {
+		return "resource_type_names"
+	}
+
+	cmd.use(cmd.optionsFile())
+
+	return qualifiedName(headerPackage(cmd.baseHeader), "resource_type_names")
+}
+
+// optionsFile returns the path of the file that declares the options in condensed mode, if the output is split.
+func (cmd *GenerateProtoCmd) optionsFile() string {
+	return filepath.ToSlash(filepath.Join(strings.ReplaceAll(headerPackage(cmd.baseHeader), ".", "/"), optionsFile))
+}
+
+// runSplit generates one proto file per module and writes them to the output directory.
+func (cmd *GenerateProtoCmd) runSplit(header string) (err error) {
+	var files = make(map[string]string)
+
+	cmd.baseHeader = header
+	cmd.dependencies = make(map[string]map[string]bool)
+
+	modules, err := cmd.prepareModules(header)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range modules {
+		cmd.module = m
+
+		files[m.File], err = cmd.createProto(moduleHeader(header, m))
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.module = nil
+
+	if cycle := findCycle(cmd.dependencies); cycle != nil {
+		return fmt.Errorf("files %s import each other, which is not supported by protobuf", strings.Join(cycle, " -> "))
+	}
+
+	// In condensed mode, the extension of the message options can only be declared once
+	if !cmd.FullSemanticMode {
+		files[cmd.optionsFile()] = "// Auto-generated code by owl2proto (https://github.com/oxisto/owl2proto)\n\n" +
+			emitImports(header, []string{descriptorImport}) + cmd.emitResourceTypeNamesExtension() + "\n"
+	}
+
+	err = cmd.checkWarnings()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range util.SortMapKeys(files) {
+		err = util.WriteFile(filepath.Join(cmd.OutputDir, file), files[file])
+		if err != nil {
+			return fmt.Errorf("error writing proto file to storage: %w", err)
+		}
+
+		slog.Info("proto file written to storage", slog.String("file", filepath.Join(cmd.OutputDir, file)))
+	}
+
+	return nil
+}
+
+// findCycle returns the files of an import cycle, starting and ending with the same file, or nil if the files do not
+// import each other.
+func findCycle(dependencies map[string]map[string]bool) []string {
+	var (
+		state = make(map[string]int) // 1 = on the current path, 2 = done
+		path  []string
+		visit func(name string) []string
+	)
+
+	visit = func(name string) []string {
+		state[name] = 1
+		path = append(path, name)
+
+		for _, dep := range util.SortMapKeys(dependencies[name]) {
+			if state[dep] == 1 {
+				for i, n := range path {
+					if n == dep {
+						return append(append([]string{}, path[i:]...), dep)
+					}
+				}
+			} else if state[dep] == 0 {
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		state[name] = 2
+		path = path[:len(path)-1]
+
+		return nil
+	}
+
+	for _, name := range util.SortMapKeys(dependencies) {
+		if state[name] == 0 {
+			if cycle := visit(name); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
+
+// headerPackage returns the package of the header or an empty string, if it has no package statement.
+func headerPackage(header string) string {
+	if m := headerPackagePattern.FindStringSubmatch(header); m != nil {
+		return m[1]
+	}
+
+	return ""
+}
+
+// modulePackage returns the package of the module within the base package. If the base package ends with a version,
+// the module is inserted before it, e.g., "example.sec.v1" for the module "sec" and the base package "example.v1".
+func modulePackage(base string, name string) string {
+	if base == "" {
+		return name
+	}
+
+	components := strings.Split(base, ".")
+	if last := components[len(components)-1]; len(components) > 1 && versionPattern.MatchString(last) {
+		return strings.Join(append(components[:len(components)-1], name, last), ".")
+	}
+
+	return base + "." + name
+}
+
+// moduleHeader returns the header of the proto file of a module. The package and the package options of the header
+// are replaced by the ones of the module, e.g., the go_package "example.com/api" becomes "example.com/api/sec".
+func moduleHeader(header string, m *module) string {
+	if headerPackagePattern.MatchString(header) {
+		header = headerPackagePattern.ReplaceAllLiteralString(header, fmt.Sprintf("package %s;", m.Package))
+	} else if loc := headerSyntaxPattern.FindStringIndex(header); loc != nil {
+		header = header[:loc[1]] + fmt.Sprintf("\n\npackage %s;", m.Package) + header[loc[1]:]
+	} else {
+		header = fmt.Sprintf("package %s;\n", m.Package) + header
+	}
+
+	header = replaceOption(header, goPackagePattern, func(s string) string {
+		path, _, _ := strings.Cut(s, ";")
+		return path + "/" + m.Name + ";" + m.Name
+	})
+	header = replaceOption(header, javaPackagePattern, func(s string) string {
+		return s + "." + m.Name
+	})
+	header = replaceOption(header, csharpNamespacePattern, func(s string) string {
+		return s + "." + toPascalCase(m.Name)
+	})
+
+	return header
+}
+
+// replaceOption replaces the value of the file option matched by the pattern.
+func replaceOption(header string, pattern *regexp.Regexp, replace func(s string) string) string {
+	return pattern.ReplaceAllStringFunc(header, func(s string) string {
+		m := pattern.FindStringSubmatch(s)
+		return fmt.Sprintf("%s%q", m[1], replace(m[2]))
+	})
+}
+
+// qualifiedName returns the fully qualified name of the type in the package, e.g., ".example.sec.v1.Firewall".
+func qualifiedName(pkg string, name string) string {
+	if pkg == "" {
+		return "." + name
+	}
+
+	return "." + pkg + "." + name
+}
+
+// toPascalCase converts a snake case string to pascal case, e.g., "cloud_security" to "CloudSecurity".
+func toPascalCase(s string) string {
+	var output string
+
+	for _, part := range strings.Split(s, "_") {
+		if part != "" {
+			output += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+
+	return output
+}
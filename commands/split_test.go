@@ -0,0 +1,299 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/oxisto/owl2proto/ontology"
+)
+
+func Test_modulePackage(t *testing.T) {
+	type args struct {
+		base string
+		name string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{name: "Versioned package", args: args{base: "example.v1", name: "sec"}, want: "example.sec.v1"},
+		{name: "Package without version", args: args{base: "example.cloud", name: "sec"}, want: "example.cloud.sec"},
+		{name: "Only version", args: args{base: "v1", name: "sec"}, want: "v1.sec"},
+		{name: "No package", args: args{name: "sec"}, want: "sec"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := modulePackage(tt.args.base, tt.args.name); got != tt.want {
+				t.Errorf("modulePackage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_moduleHeader(t *testing.T) {
+	var sec = &module{Name: "cloud_security", Package: "example.cloud_security.v1"}
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{
+			name: "Package and options",
+			header: "syntax = \"proto3\";\n\npackage example.v1;\n\n" +
+				"option csharp_namespace = \"Example\";\noption go_package = \"example.com/api;api\";\noption java_package = \"com.example\";\n",
+			want: "syntax = \"proto3\";\n\npackage example.cloud_security.v1;\n\n" +
+				"option csharp_namespace = \"Example.CloudSecurity\";\noption go_package = \"example.com/api/cloud_security;cloud_security\";\noption java_package = \"com.example.cloud_security\";\n",
+		},
+		{
+			name:   "No package",
+			header: "syntax = \"proto3\";\n",
+			want:   "syntax = \"proto3\";\n\npackage example.cloud_security.v1;\n",
+		},
+		{
+			name:   "Empty header",
+			header: "",
+			want:   "package example.cloud_security.v1;\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := moduleHeader(tt.header, sec); got != tt.want {
+				t.Errorf("moduleHeader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_findCycle(t *testing.T) {
+	tests := []struct {
+		name         string
+		dependencies map[string]map[string]bool
+		want         []string
+	}{
+		{
+			name: "No cycle",
+			dependencies: map[string]map[string]bool{
+				"ex.proto":  {"geo.proto": true, "sec.proto": true},
+				"sec.proto": {"geo.proto": true},
+			},
+		},
+		{
+			name: "Cycle",
+			dependencies: map[string]map[string]bool{
+				"ex.proto":  {"sec.proto": true},
+				"geo.proto": {"sec.proto": true},
+				"sec.proto": {"geo.proto": true},
+			},
+			want: []string{"sec.proto", "geo.proto", "sec.proto"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findCycle(tt.dependencies); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findCycle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateProtoCmd_qualify(t *testing.T) {
+	var (
+		ex  = &module{Name: "ex", Package: "example.ex.v1", File: "example/ex/v1/ex.proto"}
+		sec = &module{Name: "sec", Package: "example.sec.v1", File: "example/sec/v1/sec.proto"}
+		abs = &module{Name: "ex", Package: "example.ex.v1", File: "example/ex/v1/ex_abstract.proto"}
+	)
+
+	tests := []struct {
+		name        string
+		module      *module
+		typ         string
+		iri         string
+		want        string
+		wantImports map[string]bool
+	}{
+		{name: "Not split", typ: "Firewall", iri: "sec:Firewall", want: "Firewall"},
+		{name: "Same module", module: sec, typ: "Firewall", iri: "sec:Firewall", want: "Firewall"},
+		{name: "Reference", module: ex, typ: "optional string", iri: "sec:Firewall", want: "optional string"},
+		{
+			name:        "Other module",
+			module:      ex,
+			typ:         "repeated Firewall",
+			iri:         "sec:Firewall",
+			want:        "repeated .example.sec.v1.Firewall",
+			wantImports: map[string]bool{"example/sec/v1/sec.proto": true},
+		},
+		{
+			name:        "Same name in other module",
+			module:      ex,
+			typ:         "Storage",
+			iri:         "sec:Storage",
+			want:        ".example.sec.v1.Storage",
+			wantImports: map[string]bool{"example/sec/v1/sec.proto": true},
+		},
+		{
+			name:        "Other file of the same module",
+			module:      abs,
+			typ:         "Storage",
+			iri:         "ex:Storage",
+			want:        "Storage",
+			wantImports: map[string]bool{"example/ex/v1/ex.proto": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &GenerateProtoCmd{
+				module:       tt.module,
+				modules:      map[string]*module{"sec:Firewall": sec, "sec:Storage": sec, "ex:Storage": ex},
+				dependencies: map[string]map[string]bool{},
+			}
+			cmd.preparedOntology = &ontology.OntologyPrepared{Resources: map[string]*ontology.Resource{
+				"sec:Firewall": {Iri: "sec:Firewall", Name: "Firewall"},
+				"sec:Storage":  {Iri: "sec:Storage", Name: "Storage"},
+				"ex:Storage":   {Iri: "ex:Storage", Name: "Storage"},
+			}}
+
+			if got := cmd.qualify(tt.typ, tt.iri); got != tt.want {
+				t.Errorf("GenerateProtoCmd.qualify() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(cmd.imports, tt.wantImports) {
+				t.Errorf("GenerateProtoCmd.qualify() imports = %v, want %v", cmd.imports, tt.wantImports)
+			}
+		})
+	}
+}
+
+func TestGenerateProtoCmd_runSplit(t *testing.T) {
+	dir := t.TempDir()
+
+	// The abstract class ex:Resource has a leaf in the module sec, which embeds a message of the module ex
+	err := os.WriteFile(filepath.Join(dir, "cloud.ofn"), []byte(`Prefix(ex:=<http://example.com/cloud/>)
+Prefix(sec:=<http://example.com/security/>)
+Ontology(<http://example.com/cloud>
+	Declaration(Class(ex:Resource))
+	Declaration(Class(ex:Storage))
+	Declaration(Class(ex:Config))
+	Declaration(Class(sec:Firewall))
+	Declaration(ObjectProperty(sec:config))
+	SubClassOf(ex:Storage ex:Resource)
+	SubClassOf(sec:Firewall ex:Resource)
+	SubClassOf(sec:Firewall ObjectSomeValuesFrom(sec:config ex:Config))
+)`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &GenerateProtoCmd{
+		GenerateCmd:      GenerateCmd{OwlFile: filepath.Join(dir, "cloud.ofn"), RootResourceName: "ex:Resource", InputFormat: "auto"},
+		SplitBy:          "prefix",
+		OutputDir:        filepath.Join(dir, "api"),
+		FullSemanticMode: true,
+	}
+	if err = cmd.prepare(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = cmd.runSplit("syntax = \"proto3\";\n\npackage example.v1;\n"); err != nil {
+		t.Fatalf("GenerateProtoCmd.runSplit() error = %v", err)
+	}
+
+	importPattern := regexp.MustCompile(`import "(example/[^"]*)";`)
+	want := map[string][]string{
+		"example/ex/v1/ex.proto":          nil,
+		"example/ex/v1/ex_abstract.proto": {"example/ex/v1/ex.proto", "example/sec/v1/sec.proto"},
+		"example/sec/v1/sec.proto":        {"example/ex/v1/ex.proto"},
+	}
+	for file, wantImports := range want {
+		b, err := os.ReadFile(filepath.Join(cmd.OutputDir, file))
+		if err != nil {
+			t.Fatalf("GenerateProtoCmd.runSplit() did not write %s: %v", file, err)
+		}
+
+		var imports []string
+		for _, m := range importPattern.FindAllStringSubmatch(string(b), -1) {
+			imports = append(imports, m[1])
+		}
+		if !reflect.DeepEqual(imports, wantImports) {
+			t.Errorf("GenerateProtoCmd.runSplit() imports of %s = %v, want %v", file, imports, wantImports)
+		}
+	}
+}
+
+func TestGenerateProtoCmd_runSplit_sameName(t *testing.T) {
+	tests := []struct {
+		name    string
+		axioms  string
+		want    map[string][]string
+		wantErr bool
+	}{
+		{
+			name: "Classes of different modules",
+			axioms: `SubClassOf(ex:Holder ObjectSomeValuesFrom(ex:has sec:Policy))
+	SubClassOf(ex:Other ObjectSomeValuesFrom(ex:has ex:Policy))`,
+			want: map[string][]string{
+				"example/ex/v1/ex.proto": {
+					"\"Holder\";\n\n\t.example.sec.v1.Policy policy",
+					"\"Other\";\n\n\tPolicy policy",
+				},
+			},
+		},
+		{
+			name:    "Classes of the same module",
+			axioms:  `AnnotationAssertion(rdfs:label ex:Rule "Policy")`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			err := os.WriteFile(filepath.Join(dir, "cloud.ofn"), []byte(`Prefix(ex:=<http://example.com/cloud/>)
+Prefix(sec:=<http://example.com/security/>)
+Ontology(<http://example.com/cloud>
+	Declaration(Class(ex:Resource))
+	Declaration(Class(ex:Holder))
+	Declaration(Class(ex:Other))
+	Declaration(Class(ex:Policy))
+	Declaration(Class(ex:Rule))
+	Declaration(Class(sec:Policy))
+	Declaration(ObjectProperty(ex:has))
+	`+tt.axioms+`
+)`), 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cmd := &GenerateProtoCmd{
+				GenerateCmd: GenerateCmd{OwlFile: filepath.Join(dir, "cloud.ofn"), RootResourceName: "ex:Resource", InputFormat: "auto"},
+				SplitBy:     "prefix",
+				OutputDir:   filepath.Join(dir, "api"),
+			}
+			if err = cmd.prepare(); err != nil {
+				t.Fatal(err)
+			}
+
+			err = cmd.runSplit("syntax = \"proto3\";\n\npackage example.v1;\n")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GenerateProtoCmd.runSplit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			for file, messages := range tt.want {
+				b, err := os.ReadFile(filepath.Join(cmd.OutputDir, file))
+				if err != nil {
+					t.Fatalf("GenerateProtoCmd.runSplit() did not write %s: %v", file, err)
+				}
+
+				for _, message := range messages {
+					if !strings.Contains(string(b), message) {
+						t.Errorf("GenerateProtoCmd.runSplit() %s does not contain %q:\n%s", file, message, b)
+					}
+				}
+			}
+		})
+	}
+}
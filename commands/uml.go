@@ -1,9 +1,9 @@
 package commands
 
 import (
+	"fmt"
 	"log/slog"
 
-	"github.com/lmittmann/tint"
 	"github.com/oxisto/owl2proto"
 	"github.com/oxisto/owl2proto/internal/util"
 )
@@ -14,7 +14,15 @@ type GenerateUMLCmd struct {
 }
 
 func (cmd *GenerateUMLCmd) Run() (err error) {
-	cmd.prepare()
+	err = cmd.prepare()
+	if err != nil {
+		return err
+	}
+
+	err = cmd.checkWarnings()
+	if err != nil {
+		return err
+	}
 
 	// Generate UML
 	output := owl2proto.CreatePlantUMLFile(cmd.preparedOntology)
@@ -22,7 +30,7 @@ func (cmd *GenerateUMLCmd) Run() (err error) {
 	// Write UML
 	err = util.WriteFile(cmd.OutputPath, output)
 	if err != nil {
-		slog.Error("error writing UML file to storage", tint.Err(err))
+		return fmt.Errorf("error writing UML file to storage: %w", err)
 	}
 
 	slog.Info("UML file written to storage", slog.String("output folder", cmd.OutputPath))
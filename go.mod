@@ -8,4 +8,5 @@ require (
 	github.com/cespare/xxhash/v2 v2.3.0
 	github.com/lmittmann/tint v1.0.5
 	google.golang.org/protobuf v1.34.2
+	gopkg.in/yaml.v3 v3.0.1
 )
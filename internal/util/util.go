@@ -1,11 +1,13 @@
 package util
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
-	"log/slog"
 	"os"
+	"path/filepath"
 	"regexp"
-	"sort"
+	"slices"
 	"strings"
 
 	"github.com/cespare/xxhash/v2"
@@ -13,6 +15,19 @@ import (
 
 const (
 	Repeated = "repeated "
+
+	// MaxFieldNumber is the highest field number we assign. The numbers 19000 to 19999 are reserved for the Protocol
+	// Buffers implementation.
+	MaxFieldNumber = 18999
+
+	// FirstReservedFieldNumber and LastReservedFieldNumber are the bounds of the field numbers that are reserved for
+	// the Protocol Buffers implementation.
+	FirstReservedFieldNumber = 19000
+	LastReservedFieldNumber  = 19999
+
+	// MaxProtoFieldNumber is the highest field number that is allowed by Protocol Buffers, which is called "max" in
+	// reserved statements.
+	MaxProtoFieldNumber = 536870911
 )
 
 // ToPlural returns the plural of a string
@@ -26,36 +41,6 @@ func ToPlural(s string) string {
 
 }
 
-// GetProtoType converts Ontology type to golang type
-func GetProtoType(s string) string {
-	switch s {
-	case "xsd:boolean":
-		return "bool"
-	case "xsd:String", "xsd:string", "string":
-		return "string"
-	case "xsd:listString", "xsd:java.util.ArrayList<String>":
-		return "repeated string"
-	case "xsd:integer", "xsd:int":
-		return "int32"
-	case "xsd:Short":
-		return "uint32"
-	case "xsd:float":
-		return "float"
-	case "xsd:java.time.Duration":
-		return "google.protobuf.Duration"
-	case "xsd:dateTime", "xsd:java.time.ZonedDateTime":
-		return "google.protobuf.Timestamp"
-	case "xsd:java.util.ArrayList<Short>":
-		// Note, there is no uint16 in protobuf, therefore we need to resort to uint32.
-		return "repeated uint32"
-	case "xsd:java.util.Map<String, String>":
-		return "map<string, string>"
-	default:
-		return s
-	}
-
-}
-
 // CleanString deletes spaces, '-' and '/'.
 func CleanString(s string) string {
 	s = strings.ReplaceAll(s, " ", "")
@@ -78,67 +63,86 @@ func ToSnakeCase(s string) string {
 	return strings.ToLower(snake)
 }
 
-// SortMapKeys returns the keys of the map sorted ba [sort.Strings].
-func SortMapKeys[V any](m map[string]V) []string {
-	resources := make([]string, 0, len(m))
+// ToUpperSnakeCase converts camel case to upper snake case, as used for enum values. All characters that are neither
+// letters nor digits are replaced by an underscore, e.g., "tls1.2" returns "TLS1_2".
+func ToUpperSnakeCase(s string) string {
+	var nonAlphanumeric = regexp.MustCompile("[^a-z0-9]+")
+
+	s = nonAlphanumeric.ReplaceAllString(ToSnakeCase(s), "_")
+	return strings.ToUpper(strings.Trim(s, "_"))
+}
+
+// SortMapKeys returns the keys of the map sorted by [slices.Sort].
+func SortMapKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	resources := make([]K, 0, len(m))
 
 	for k := range m {
 		resources = append(resources, k)
 	}
 
 	// Sort slice by key
-	sort.Strings(resources)
+	slices.Sort(resources)
 
 	return resources
 }
 
+// ErrFieldNumberTooHigh is returned by [GetFieldNumber] if the ascending field numbers exceed [MaxFieldNumber].
+var ErrFieldNumberTooHigh = errors.New("field number is too high")
+
 // GetFieldNumber returns a "consistent" field number for the proto field based on the input strings if
 // deterministicFieldNumbers is true, otherwise it returns the incremented counter input (ascending field numbers). The
-// maximum field number is 18999. The first return value is the field number and the second is the counter i
-func GetFieldNumber(deterministicFieldNumbers bool, counter int, input ...string) (int, int) {
+// maximum field number is 18999. The first return value is the field number and the second is the counter i. An
+// error wrapping [ErrFieldNumberTooHigh] is returned if the incremented counter exceeds the maximum field number.
+func GetFieldNumber(deterministicFieldNumbers bool, counter int, input ...string) (int, int, error) {
 	if deterministicFieldNumbers {
 		hash := xxhash.Sum64([]byte(strings.Join(input, "")))
 
 		// the maximum field number is 18999, because the numbers 19000 to 19999 are reserved for the Protocol Buffers implementation
 		number := int(hash%19000) + 1
 
-		return number, counter
+		return number, counter, nil
 	} else {
 		counter++
-		if counter >= 19000 {
-			slog.Error("field number '%s' is to high", slog.Int("counter", counter))
-			os.Exit(1)
+		if counter > MaxFieldNumber {
+			return 0, counter, fmt.Errorf("%w: %d exceeds the maximum of %d", ErrFieldNumberTooHigh, counter, MaxFieldNumber)
 		}
-		return counter, counter
+		return counter, counter, nil
 	}
 }
 
+// NextFieldNumber returns the field number that should be probed next if the given number is already taken or not
+// allowed. It uses linear probing that wraps around at [MaxFieldNumber], so that the result is always a valid field
+// number and the same sequence of numbers is probed in every run.
+func NextFieldNumber(number int) int {
+	return number%MaxFieldNumber + 1
+}
+
 func WriteFile(outputFile, s string) error {
 	var err error
 
-	// TODO(all):Create folder if not exists
+	// Create folder if not exists
+	err = os.MkdirAll(filepath.Dir(outputFile), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating folder: %w", err)
+	}
+
 	// Create storage file
 	f, err := os.Create(outputFile)
 	if err != nil {
-		err = fmt.Errorf("error creating file: %v", err)
-		slog.Error(err.Error())
+		return fmt.Errorf("error creating file: %w", err)
 	}
 
 	// Write output string to file
 	_, err = f.WriteString(s)
 	if err != nil {
-		err = fmt.Errorf("error writing output to file: %v", err)
-		slog.Error(err.Error())
 		f.Close()
-		return err
+		return fmt.Errorf("error writing output to file: %w", err)
 	}
 
 	// Close storage file
 	err = f.Close()
 	if err != nil {
-		err = fmt.Errorf("error closing file: %v", err)
-		slog.Error(err.Error())
-		return err
+		return fmt.Errorf("error closing file: %w", err)
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -63,6 +64,7 @@ func TestGetFieldNumber(t *testing.T) {
 		args        args
 		fieldNumber int
 		wantI       int
+		wantErr     error
 	}{
 		{
 			name: "Happy path: incremented",
@@ -84,10 +86,24 @@ func TestGetFieldNumber(t *testing.T) {
 			fieldNumber: 4044,
 			wantI:       0,
 		},
+		{
+			name: "Counter exceeds the maximum field number",
+			args: args{
+				deterministicFieldNumbers: false,
+				counter:                   MaxFieldNumber,
+				input:                     []string{"Resource", "Compute", "VirtualMachine", "name"},
+			},
+			fieldNumber: 0,
+			wantI:       MaxFieldNumber + 1,
+			wantErr:     ErrFieldNumberTooHigh,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, got1 := GetFieldNumber(tt.args.deterministicFieldNumbers, tt.args.counter, tt.args.input...)
+			got, got1, err := GetFieldNumber(tt.args.deterministicFieldNumbers, tt.args.counter, tt.args.input...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetFieldNumber() error = %v, wantErr %v", err, tt.wantErr)
+			}
 			if got != tt.fieldNumber {
 				t.Errorf("GetFieldNumber() got = %v, want %v", got, tt.fieldNumber)
 			}
@@ -98,6 +114,86 @@ func TestGetFieldNumber(t *testing.T) {
 	}
 }
 
+func TestNextFieldNumber(t *testing.T) {
+	type args struct {
+		number int
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "Happy path",
+			args: args{
+				number: 4044,
+			},
+			want: 4045,
+		},
+		{
+			name: "Happy path: wrap around",
+			args: args{
+				number: 18999,
+			},
+			want: 1,
+		},
+		{
+			name: "Happy path: reserved number",
+			args: args{
+				number: 19000,
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextFieldNumber(tt.args.number); got != tt.want {
+				t.Errorf("NextFieldNumber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToUpperSnakeCase(t *testing.T) {
+	type args struct {
+		s string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Camel case",
+			args: args{
+				s: "TLSVersion",
+			},
+			want: "TLS_VERSION",
+		},
+		{
+			name: "Special characters",
+			args: args{
+				s: "tls1.2 (legacy)",
+			},
+			want: "TLS1_2_LEGACY",
+		},
+		{
+			name: "Only special characters",
+			args: args{
+				s: "*",
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToUpperSnakeCase(tt.args.s); got != tt.want {
+				t.Errorf("ToUpperSnakeCase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestToPlural(t *testing.T) {
 	type args struct {
 		s string
@@ -200,99 +296,3 @@ func TestSortMapKeys(t *testing.T) {
 		})
 	}
 }
-
-func TestGetProtoType(t *testing.T) {
-	type args struct {
-		s string
-	}
-	tests := []struct {
-		name string
-		args args
-		want string
-	}{
-		{
-			name: "Happy path: unknown type",
-			args: args{
-				s: "unknown_type",
-			},
-			want: "unknown_type",
-		},
-		{
-			name: "Happy path: bool",
-			args: args{
-				s: "xsd:boolean",
-			},
-			want: "bool",
-		},
-		{
-			name: "Happy path: string",
-			args: args{
-				s: "xsd:String",
-			},
-			want: "string",
-		},
-		{
-			name: "Happy path: listString",
-			args: args{
-				s: "xsd:listString",
-			},
-			want: "repeated string",
-		},
-		{
-			name: "Happy path: int",
-			args: args{
-				s: "xsd:int",
-			},
-			want: "int32",
-		},
-		{
-			name: "Happy path: Short",
-			args: args{
-				s: "xsd:Short",
-			},
-			want: "uint32",
-		},
-		{
-			name: "Happy path: float",
-			args: args{
-				s: "xsd:float",
-			},
-			want: "float",
-		},
-		{
-			name: "Happy path: xsd:java.time.Duration",
-			args: args{
-				s: "xsd:java.time.Duration",
-			},
-			want: "google.protobuf.Duration",
-		},
-		{
-			name: "Happy path: xsd:dateTime",
-			args: args{
-				s: "xsd:dateTime",
-			},
-			want: "google.protobuf.Timestamp",
-		},
-		{
-			name: "Happy path: xsd:java.util.ArrayList<Short>",
-			args: args{
-				s: "xsd:java.util.ArrayList<Short>",
-			},
-			want: "repeated uint32",
-		},
-		{
-			name: "Happy path: xsd:java.util.Map<String, String>",
-			args: args{
-				s: "xsd:java.util.Map<String, String>",
-			},
-			want: "map<string, string>",
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := GetProtoType(tt.args.s); got != tt.want {
-				t.Errorf("GetProtoType() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
@@ -0,0 +1,326 @@
+package ontology
+
+import (
+	"strings"
+
+	"github.com/oxisto/owl2proto/owl"
+)
+
+// Unbounded is the maximum cardinality of a property that has no upper bound.
+const Unbounded = -1
+
+// Cardinality contains the minimum and maximum number of values of a property, as defined by the cardinality
+// restrictions of a class. A nil cardinality means that the property is not restricted at all.
+type Cardinality struct {
+	Min int
+	Max int
+}
+
+// Repeated returns true if the property can have more than one value.
+func (c *Cardinality) Repeated() bool {
+	return c != nil && (c.Max == Unbounded || c.Max > 1)
+}
+
+// Optional returns true if the property has at most one value, which is not required.
+func (c *Cardinality) Optional() bool {
+	return c != nil && c.Min == 0 && c.Max == 1
+}
+
+// Required returns true if the property needs to have at least one value.
+func (c *Cardinality) Required() bool {
+	return c != nil && c.Min > 0
+}
+
+// Prohibited returns true if the property must not have a value.
+func (c *Cardinality) Prohibited() bool {
+	return c != nil && c.Max == 0
+}
+
+// restrict narrows down the cardinality by a restriction with the given minimum and maximum. If c is nil, the
+// property was not restricted before.
+func (c *Cardinality) restrict(min, max int) *Cardinality {
+	if c == nil {
+		c = &Cardinality{Min: 0, Max: Unbounded}
+	}
+
+	if min > c.Min {
+		c.Min = min
+	}
+	if max != Unbounded && (c.Max == Unbounded || max < c.Max) {
+		c.Max = max
+	}
+
+	return c
+}
+
+// check reports a diagnostic if the cardinality cannot be satisfied, because of contradicting restrictions.
+func (c *Cardinality) check(r cardinalityRestriction, pos owl.Position, diags *Diagnostics) {
+	if c.Max != Unbounded && c.Min > c.Max {
+		diags.report(r.axiom, r.property, pos, "cardinality restrictions cannot be satisfied (min %d, max %d)", c.Min, c.Max)
+	}
+}
+
+// CardinalityOverride is a cardinality restriction of a class on a property that it inherits from one of its parents.
+// It applies to the class and its sub-classes, but not to the parent that has the property.
+type CardinalityOverride struct {
+	Property    string // IRI of the property
+	To          string // IRI of the class of a qualified object property restriction, empty otherwise
+	Cardinality *Cardinality
+}
+
+// overriddenCardinality applies the cardinality overrides of the classes of the lineage to the cardinality c of the
+// property, which points to the class to in case of object properties. It returns false if no override applies, c
+// itself is never modified.
+func (ont *OntologyPrepared) overriddenCardinality(lineage []string, property, to string, c *Cardinality) (*Cardinality, bool) {
+	var overridden bool
+
+	if c != nil {
+		c = &Cardinality{Min: c.Min, Max: c.Max}
+	}
+
+	for _, class := range lineage {
+		res, ok := ont.Resources[class]
+		if !ok {
+			continue
+		}
+
+		for _, o := range res.Overrides {
+			if o.Property == property && (o.To == "" || o.To == to) {
+				c = c.restrict(o.Cardinality.Min, o.Cardinality.Max)
+				overridden = true
+			}
+		}
+	}
+
+	return c, overridden
+}
+
+// cardinalityRestriction is a single cardinality restriction of a SubClassOf axiom.
+type cardinalityRestriction struct {
+	axiom     string
+	property  string
+	qualifier string
+	min       int
+	max       int
+}
+
+// prepareCardinalities applies the cardinality restrictions of all SubClassOf axioms to the relationships of the
+// classes. This needs to happen after all relationships are prepared, because the restrictions can be stated in any
+// order. Restrictions on inherited properties are stored as [CardinalityOverride] of the class, so that the parent
+// keeps its cardinality and no second field is created. Qualified restrictions on properties that the class does not
+// have at all add a new relationship, just like ObjectSomeValuesFrom and DataSomeValuesFrom do.
+func (ont *OntologyPrepared) prepareCardinalities(src *owl.Ontology, diags *Diagnostics) {
+	for _, sc := range src.SubClasses {
+		if len(sc.Class) == 0 {
+			continue
+		}
+
+		// Undeclared classes are already reported
+		res, ok := ont.Resources[NormalizedIRI(ont, &sc.Class[0].Entity)]
+		if !ok {
+			continue
+		}
+
+		for _, r := range ont.objectCardinalities(sc) {
+			ont.restrictObjectRelationship(res, r, sc.Position, diags)
+		}
+
+		for _, r := range ont.dataCardinalities(sc) {
+			ont.restrictRelationship(res, r, sc.Position, diags)
+		}
+	}
+}
+
+// objectCardinalities returns the cardinality restrictions on object properties of the SubClassOf axiom.
+func (ont *OntologyPrepared) objectCardinalities(sc owl.SubClassOf) (restrictions []cardinalityRestriction) {
+	add := func(axiom string, list []owl.ObjectCardinality) {
+		for _, c := range list {
+			r := cardinalityRestriction{
+				axiom:    axiom,
+				property: NormalizedIRI(ont, &c.ObjectProperty.Entity),
+			}
+			r.min, r.max = bounds(axiom, c.Cardinality)
+
+			if c.Class.IRI != "" || c.Class.AbbreviatedIRI != "" {
+				r.qualifier = NormalizedIRI(ont, &c.Class.Entity)
+			}
+
+			restrictions = append(restrictions, r)
+		}
+	}
+
+	add("ObjectMinCardinality", sc.ObjectMinCardinality)
+	add("ObjectMaxCardinality", sc.ObjectMaxCardinality)
+	add("ObjectExactCardinality", sc.ObjectExactCardinality)
+
+	return
+}
+
+// dataCardinalities returns the cardinality restrictions on data properties of the SubClassOf axiom.
+func (ont *OntologyPrepared) dataCardinalities(sc owl.SubClassOf) (restrictions []cardinalityRestriction) {
+	add := func(axiom string, list []owl.DataCardinality) {
+		for _, c := range list {
+			r := cardinalityRestriction{
+				axiom:     axiom,
+				property:  NormalizedIRI(ont, &c.DataProperty.Entity),
+				qualifier: ont.datatype(c.Datatype),
+			}
+			r.min, r.max = bounds(axiom, c.Cardinality)
+
+			restrictions = append(restrictions, r)
+		}
+	}
+
+	add("DataMinCardinality", sc.DataMinCardinality)
+	add("DataMaxCardinality", sc.DataMaxCardinality)
+	add("DataExactCardinality", sc.DataExactCardinality)
+
+	return
+}
+
+// bounds returns the minimum and maximum number of values that are allowed by a min, max or exact cardinality
+// restriction with the cardinality n.
+func bounds(axiom string, n int) (min, max int) {
+	switch {
+	case strings.HasSuffix(axiom, "MinCardinality"):
+		return n, Unbounded
+	case strings.HasSuffix(axiom, "MaxCardinality"):
+		return 0, n
+	default:
+		return n, n
+	}
+}
+
+// override stores the restriction as cardinality override of the resource, which only applies to relationships to
+// the class to, unless it is empty. Several restrictions on the same property narrow down the same override.
+func (res *Resource) override(r cardinalityRestriction, to string) {
+	for _, o := range res.Overrides {
+		if o.Property == r.property && o.To == to {
+			o.Cardinality = o.Cardinality.restrict(r.min, r.max)
+			return
+		}
+	}
+
+	res.Overrides = append(res.Overrides, &CardinalityOverride{
+		Property:    r.property,
+		To:          to,
+		Cardinality: (*Cardinality)(nil).restrict(r.min, r.max),
+	})
+}
+
+// restrictObjectRelationship applies the restriction to the matching object relationships of the resource or, if the
+// resource inherits the property, stores it as override.
+func (ont *OntologyPrepared) restrictObjectRelationship(res *Resource, r cardinalityRestriction, pos owl.Position, diags *Diagnostics) {
+	var found bool
+
+	if _, ok := ont.AnnotationAssertion[r.property]; !ok {
+		diags.report(r.axiom, r.property, pos, "object property is not declared")
+		return
+	}
+
+	for _, o := range res.ObjectRelationship {
+		if o.ObjectProperty == r.property && (r.qualifier == "" || o.To == r.qualifier) {
+			o.Cardinality = o.Cardinality.restrict(r.min, r.max)
+			o.Cardinality.check(r, pos, diags)
+			found = true
+		}
+	}
+
+	if found {
+		return
+	}
+
+	// Restrictions on inherited properties only apply to this class and its sub-classes
+	inherited := func() (matches []*ObjectRelationship) {
+		for _, o := range ont.FindAllObjectProperties(res.Iri) {
+			if o.ObjectProperty == r.property && (r.qualifier == "" || o.To == r.qualifier) {
+				matches = append(matches, o)
+			}
+		}
+		return
+	}
+
+	if len(inherited()) > 0 {
+		res.override(r, r.qualifier)
+		for _, o := range inherited() {
+			o.Cardinality.check(r, pos, diags)
+		}
+		return
+	} else if r.qualifier == "" {
+		ont.Warnings.report(r.axiom, r.property, pos, "unqualified cardinality restriction on a property that the class does not have")
+		return
+	}
+
+	to, ok := ont.Resources[r.qualifier]
+	if !ok {
+		diags.report(r.axiom, r.qualifier, pos, "class is not declared")
+		return
+	}
+
+	res.ObjectRelationship = append(res.ObjectRelationship, &ObjectRelationship{
+		From:               res.Iri,
+		ObjectProperty:     r.property,
+		ObjectPropertyName: ont.AnnotationAssertion[r.property].Name,
+		To:                 r.qualifier,
+		Name:               to.Name,
+		Cardinality:        (*Cardinality)(nil).restrict(r.min, r.max),
+	})
+}
+
+// restrictRelationship applies the restriction to the matching data relationships of the resource or, if the resource
+// inherits the property, stores it as override.
+func (ont *OntologyPrepared) restrictRelationship(res *Resource, r cardinalityRestriction, pos owl.Position, diags *Diagnostics) {
+	var found bool
+
+	prop, ok := ont.AnnotationAssertion[r.property]
+	if !ok {
+		diags.report(r.axiom, r.property, pos, "data property is not declared")
+		return
+	}
+
+	for _, rel := range res.Relationship {
+		if rel.IRI == r.property {
+			rel.Cardinality = rel.Cardinality.restrict(r.min, r.max)
+			rel.Cardinality.check(r, pos, diags)
+			found = true
+		}
+	}
+
+	if found {
+		return
+	}
+
+	// Restrictions on inherited properties only apply to this class and its sub-classes
+	inherited := func() (matches []*Relationship) {
+		for _, rel := range ont.FindAllDataProperties(res.Iri) {
+			if rel.IRI == r.property {
+				matches = append(matches, rel)
+			}
+		}
+		return
+	}
+
+	if len(inherited()) > 0 {
+		res.override(r, "")
+		for _, rel := range inherited() {
+			rel.Cardinality.check(r, pos, diags)
+		}
+		return
+	} else if r.qualifier == "" {
+		ont.Warnings.report(r.axiom, r.property, pos, "unqualified cardinality restriction on a property that the class does not have")
+		return
+	}
+
+	typ, datatype, rules := ont.dataRange(owl.Datatype{AbbreviatedIRI: r.qualifier}, nil, r.axiom, pos)
+
+	res.Relationship = append(res.Relationship, &Relationship{
+		IRI:         r.property,
+		Typ:         typ,
+		Datatype:    datatype,
+		Name:        prop.Name,
+		From:        res.Iri,
+		Comment:     strings.Join(prop.Comment, "\n\t "),
+		Cardinality: (*Cardinality)(nil).restrict(r.min, r.max),
+		Rules:       rules,
+	})
+}
@@ -0,0 +1,247 @@
+package ontology
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oxisto/owl2proto/owl"
+)
+
+func TestCardinality_restrict(t *testing.T) {
+	type args struct {
+		min int
+		max int
+	}
+	tests := []struct {
+		name string
+		c    *Cardinality
+		args args
+		want *Cardinality
+	}{
+		{
+			name: "Unrestricted",
+			args: args{min: 1, max: Unbounded},
+			want: &Cardinality{Min: 1, Max: Unbounded},
+		},
+		{
+			name: "Narrow maximum",
+			c:    &Cardinality{Min: 1, Max: Unbounded},
+			args: args{min: 0, max: 1},
+			want: &Cardinality{Min: 1, Max: 1},
+		},
+		{
+			name: "Keep narrower bounds",
+			c:    &Cardinality{Min: 2, Max: 3},
+			args: args{min: 1, max: 5},
+			want: &Cardinality{Min: 2, Max: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.restrict(tt.args.min, tt.args.max); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Cardinality.restrict() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardinality_labels(t *testing.T) {
+	tests := []struct {
+		name         string
+		c            *Cardinality
+		wantRepeated bool
+		wantOptional bool
+		wantRequired bool
+	}{
+		{
+			name: "Unrestricted",
+		},
+		{
+			name:         "At most one",
+			c:            &Cardinality{Min: 0, Max: 1},
+			wantOptional: true,
+		},
+		{
+			name:         "Exactly one",
+			c:            &Cardinality{Min: 1, Max: 1},
+			wantRequired: true,
+		},
+		{
+			name:         "At least one",
+			c:            &Cardinality{Min: 1, Max: Unbounded},
+			wantRepeated: true,
+			wantRequired: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Repeated(); got != tt.wantRepeated {
+				t.Errorf("Cardinality.Repeated() = %v, want %v", got, tt.wantRepeated)
+			}
+			if got := tt.c.Optional(); got != tt.wantOptional {
+				t.Errorf("Cardinality.Optional() = %v, want %v", got, tt.wantOptional)
+			}
+			if got := tt.c.Required(); got != tt.wantRequired {
+				t.Errorf("Cardinality.Required() = %v, want %v", got, tt.wantRequired)
+			}
+		})
+	}
+}
+
+func TestPrepare_cardinalities(t *testing.T) {
+	var (
+		vm       = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:VirtualMachine"}}
+		storage  = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:BlockStorage"}}
+		has      = owl.ObjectProperty{Entity: owl.Entity{AbbreviatedIRI: "ex:has"}}
+		name     = owl.DataProperty{Entity: owl.Entity{AbbreviatedIRI: "ex:name"}}
+		declared = []owl.Declaration{{Class: vm}, {Class: storage}, {ObjectProperty: has}, {DataProperty: name}}
+	)
+
+	type args struct {
+		subClasses []owl.SubClassOf
+	}
+	tests := []struct {
+		name                   string
+		args                   args
+		wantRelationship       []*Relationship
+		wantObjectRelationship []*ObjectRelationship
+		wantErr                bool
+	}{
+		{
+			name: "Restrictions on existing and new relationships",
+			args: args{
+				subClasses: []owl.SubClassOf{
+					{Class: []owl.Class{vm}, DataExactCardinality: []owl.DataCardinality{{Cardinality: 1, DataProperty: name}}},
+					{Class: []owl.Class{vm}, DataSomeValuesFrom: []owl.DataSomeValuesFrom{{DataProperty: name, Datatype: owl.Datatype{AbbreviatedIRI: "xsd:string"}}}},
+					{Class: []owl.Class{vm}, ObjectMinCardinality: []owl.ObjectCardinality{{Cardinality: 1, ObjectProperty: has, Class: storage}}},
+				},
+			},
+			wantRelationship: []*Relationship{{
+				IRI:         "http://example.com/cloud/name",
+				Typ:         "string",
+				Datatype:    "http://www.w3.org/2001/XMLSchema#string",
+				Name:        "name",
+				From:        "http://example.com/cloud/VirtualMachine",
+				Cardinality: &Cardinality{Min: 1, Max: 1},
+			}},
+			wantObjectRelationship: []*ObjectRelationship{{
+				ObjectProperty:     "http://example.com/cloud/has",
+				ObjectPropertyName: "has",
+				From:               "http://example.com/cloud/VirtualMachine",
+				To:                 "http://example.com/cloud/BlockStorage",
+				Name:               "BlockStorage",
+				Cardinality:        &Cardinality{Min: 1, Max: Unbounded},
+			}},
+		},
+		{
+			name: "Contradicting restrictions",
+			args: args{
+				subClasses: []owl.SubClassOf{
+					{Class: []owl.Class{vm}, DataSomeValuesFrom: []owl.DataSomeValuesFrom{{DataProperty: name, Datatype: owl.Datatype{AbbreviatedIRI: "xsd:string"}}}},
+					{Class: []owl.Class{vm}, DataMinCardinality: []owl.DataCardinality{{Cardinality: 2, DataProperty: name}}},
+					{Class: []owl.Class{vm}, DataMaxCardinality: []owl.DataCardinality{{Cardinality: 1, DataProperty: name}}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Prepare(&owl.Ontology{
+				Prefixes:     []owl.Prefix{{Name: "ex", IRI: "http://example.com/cloud/"}},
+				Declarations: declared,
+				SubClasses:   tt.args.subClasses,
+			}, "ex:VirtualMachine")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Prepare() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			res := got.Resources["http://example.com/cloud/VirtualMachine"]
+			if !reflect.DeepEqual(res.Relationship, tt.wantRelationship) {
+				t.Errorf("Prepare() relationships = %v, want %v", res.Relationship, tt.wantRelationship)
+			}
+			if !reflect.DeepEqual(res.ObjectRelationship, tt.wantObjectRelationship) {
+				t.Errorf("Prepare() object relationships = %v, want %v", res.ObjectRelationship, tt.wantObjectRelationship)
+			}
+		})
+	}
+}
+
+func TestPrepare_inheritedCardinalities(t *testing.T) {
+	var (
+		resource = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Resource"}}
+		vm       = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:VirtualMachine"}}
+		server   = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Server"}}
+		storage  = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:BlockStorage"}}
+		has      = owl.ObjectProperty{Entity: owl.Entity{AbbreviatedIRI: "ex:has"}}
+		name     = owl.DataProperty{Entity: owl.Entity{AbbreviatedIRI: "ex:name"}}
+	)
+
+	got, err := Prepare(&owl.Ontology{
+		Prefixes: []owl.Prefix{{Name: "ex", IRI: "http://example.com/cloud/"}},
+		Declarations: []owl.Declaration{
+			{Class: resource}, {Class: vm}, {Class: server}, {Class: storage}, {ObjectProperty: has}, {DataProperty: name},
+		},
+		SubClasses: []owl.SubClassOf{
+			{Class: []owl.Class{vm}, DataExactCardinality: []owl.DataCardinality{{Cardinality: 1, DataProperty: name}}},
+			{Class: []owl.Class{vm}, ObjectMinCardinality: []owl.ObjectCardinality{{Cardinality: 2, ObjectProperty: has, Class: storage}}},
+			{Class: []owl.Class{vm, resource}},
+			{Class: []owl.Class{server, vm}},
+			{Class: []owl.Class{server}, ObjectMaxCardinality: []owl.ObjectCardinality{{Cardinality: 4, ObjectProperty: has}}},
+			{Class: []owl.Class{resource}, DataSomeValuesFrom: []owl.DataSomeValuesFrom{{DataProperty: name, Datatype: owl.Datatype{AbbreviatedIRI: "xsd:string"}}}},
+			{Class: []owl.Class{resource}, ObjectSomeValuesFrom: []owl.ObjectSomeValuesFrom{{ObjectProperty: has, Class: storage}}},
+		},
+	}, "ex:Resource")
+	if err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	tests := []struct {
+		name                  string
+		class                 string
+		wantOwnRelationships  int
+		wantDataCardinality   *Cardinality
+		wantObjectCardinality *Cardinality
+	}{
+		{
+			name:                  "Parent keeps its cardinality",
+			class:                 "http://example.com/cloud/Resource",
+			wantOwnRelationships:  2,
+			wantDataCardinality:   nil,
+			wantObjectCardinality: nil,
+		},
+		{
+			name:                  "Override of the class",
+			class:                 "http://example.com/cloud/VirtualMachine",
+			wantDataCardinality:   &Cardinality{Min: 1, Max: 1},
+			wantObjectCardinality: &Cardinality{Min: 2, Max: Unbounded},
+		},
+		{
+			name:                  "Overrides of the class and its parent",
+			class:                 "http://example.com/cloud/Server",
+			wantDataCardinality:   &Cardinality{Min: 1, Max: 1},
+			wantObjectCardinality: &Cardinality{Min: 2, Max: 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := got.Resources[tt.class]
+			if n := len(res.Relationship) + len(res.ObjectRelationship); n != tt.wantOwnRelationships {
+				t.Errorf("Prepare() own relationships = %d, want %d", n, tt.wantOwnRelationships)
+			}
+
+			data := got.FindAllDataProperties(tt.class)
+			if len(data) != 1 || !reflect.DeepEqual(data[0].Cardinality, tt.wantDataCardinality) {
+				t.Errorf("FindAllDataProperties() = %v, want cardinality %v", data, tt.wantDataCardinality)
+			}
+
+			objects := got.FindAllObjectProperties(tt.class)
+			if len(objects) != 1 || !reflect.DeepEqual(objects[0].Cardinality, tt.wantObjectCardinality) {
+				t.Errorf("FindAllObjectProperties() = %v, want cardinality %v", objects, tt.wantObjectCardinality)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package ontology
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/oxisto/owl2proto/owl"
+	"github.com/oxisto/owl2proto/rdf"
+)
+
+// DeprecationReasonAnnotation is the annotation property that explains why an entity that is marked as
+// owl:deprecated is deprecated, e.g., "o2p:deprecationReason" with the prefix
+// o2p = <https://github.com/oxisto/owl2proto#>.
+const DeprecationReasonAnnotation = "https://github.com/oxisto/owl2proto#deprecationReason"
+
+// DeprecatedAnnotation is the built-in annotation property that marks an entity as deprecated.
+const DeprecatedAnnotation = rdf.NamespaceOWL + "deprecated"
+
+// prepareDeprecations collects the entities that are marked as deprecated using "owl:deprecated true" together with
+// the reason of their deprecation. Invalid boolean values are reported as warning.
+func (ont *OntologyPrepared) prepareDeprecations(src *owl.Ontology) {
+	var reasons = map[string][]string{}
+
+	for _, aa := range src.AnnotationAssertion {
+		var (
+			iri      = NormalizedIRI(ont, aa)
+			property = ont.annotationPropertyIRI(aa.AnnotationProperty)
+		)
+
+		if property == DeprecationReasonAnnotation {
+			reasons[iri] = append(reasons[iri], aa.Literal)
+			continue
+		} else if property != DeprecatedAnnotation && aa.AnnotationProperty.AbbreviatedIRI != "owl:deprecated" {
+			continue
+		}
+
+		deprecated, err := strconv.ParseBool(strings.TrimSpace(aa.Literal))
+		if err != nil {
+			ont.Warnings.report("AnnotationAssertion", iri, aa.Position, "invalid value %q of owl:deprecated", aa.Literal)
+			continue
+		}
+
+		if deprecated {
+			ont.Deprecations[iri] = ""
+		} else {
+			delete(ont.Deprecations, iri)
+		}
+	}
+
+	for iri := range ont.Deprecations {
+		ont.Deprecations[iri] = strings.Join(reasons[iri], " ")
+	}
+}
+
+// applyDeprecations marks the resources, relationships and enumerations as deprecated whose class, property or
+// individual is deprecated.
+func (ont *OntologyPrepared) applyDeprecations() {
+	for _, res := range ont.Resources {
+		res.Deprecated, res.DeprecationReason = ont.deprecation(res.Iri)
+
+		for _, r := range res.Relationship {
+			r.Deprecated, r.DeprecationReason = ont.deprecation(r.IRI)
+		}
+
+		for _, r := range res.ObjectRelationship {
+			r.Deprecated, r.DeprecationReason = ont.deprecation(r.ObjectProperty)
+		}
+	}
+
+	for _, enum := range ont.Enums {
+		enum.Deprecated, enum.DeprecationReason = ont.deprecation(enum.IRI)
+
+		for _, v := range enum.Values {
+			if v.IRI != "" {
+				v.Deprecated, _ = ont.deprecation(v.IRI)
+			}
+		}
+	}
+}
+
+// deprecation returns whether the entity is deprecated and the reason of its deprecation.
+func (ont *OntologyPrepared) deprecation(iri string) (deprecated bool, reason string) {
+	reason, deprecated = ont.Deprecations[iri]
+	return
+}
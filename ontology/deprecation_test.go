@@ -0,0 +1,76 @@
+package ontology
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oxisto/owl2proto/owl"
+)
+
+func TestPrepare_deprecations(t *testing.T) {
+	var (
+		annotation = func(property, subject, literal string) owl.AnnotationAssertion {
+			return owl.AnnotationAssertion{
+				AnnotationProperty: owl.AnnotationProperty{AbbreviatedIRI: property},
+				AbbreviatedIRI:     subject,
+				Literal:            literal,
+			}
+		}
+		resource = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Resource"}}
+		storage  = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Storage"}}
+		name     = owl.DataProperty{Entity: owl.Entity{AbbreviatedIRI: "ex:name"}}
+		has      = owl.ObjectProperty{Entity: owl.Entity{AbbreviatedIRI: "ex:has"}}
+		src      = &owl.Ontology{
+			Prefixes: []owl.Prefix{
+				{Name: "ex", IRI: "http://example.com/cloud/"},
+				{Name: "o2p", IRI: "https://github.com/oxisto/owl2proto#"},
+			},
+			Declarations: []owl.Declaration{{Class: resource}, {Class: storage}, {DataProperty: name}, {ObjectProperty: has}},
+			SubClasses: []owl.SubClassOf{
+				{Class: []owl.Class{storage, resource}},
+				{Class: []owl.Class{resource}, DataSomeValuesFrom: []owl.DataSomeValuesFrom{{DataProperty: name, Datatype: owl.Datatype{AbbreviatedIRI: "xsd:string"}}}},
+				{Class: []owl.Class{resource}, ObjectSomeValuesFrom: []owl.ObjectSomeValuesFrom{{ObjectProperty: has, Class: storage}}},
+			},
+			AnnotationAssertion: []owl.AnnotationAssertion{
+				annotation("owl:deprecated", "ex:Storage", "true"),
+				annotation("o2p:deprecationReason", "ex:Storage", "Use ex:Volume instead."),
+				annotation("owl:deprecated", "ex:name", "1"),
+				annotation("owl:deprecated", "ex:has", "true"),
+				annotation("owl:deprecated", "ex:has", "false"),
+				annotation("o2p:deprecationReason", "ex:Resource", "Not deprecated."),
+				annotation("owl:deprecated", "ex:Resource", "yes"),
+			},
+		}
+	)
+
+	prepared, err := Prepare(src, "ex:Resource")
+	if err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	var (
+		storageRes = prepared.Resources["http://example.com/cloud/Storage"]
+		res        = prepared.Resources["http://example.com/cloud/Resource"]
+	)
+	if !storageRes.Deprecated || storageRes.DeprecationReason != "Use ex:Volume instead." {
+		t.Errorf("Prepare() Storage = (%v, %q), want (true, %q)", storageRes.Deprecated, storageRes.DeprecationReason, "Use ex:Volume instead.")
+	}
+	if res.Deprecated {
+		t.Errorf("Prepare() Resource is deprecated")
+	}
+	if r := res.Relationship[0]; !r.Deprecated || r.DeprecationReason != "" {
+		t.Errorf("Prepare() name = (%v, %q), want (true, %q)", r.Deprecated, r.DeprecationReason, "")
+	}
+	if r := res.ObjectRelationship[0]; r.Deprecated {
+		t.Errorf("Prepare() has is deprecated")
+	}
+
+	wantWarnings := Diagnostics{{
+		Axiom:   "AnnotationAssertion",
+		IRI:     "http://example.com/cloud/Resource",
+		Message: `invalid value "yes" of owl:deprecated`,
+	}}
+	if !reflect.DeepEqual(prepared.Warnings, wantWarnings) {
+		t.Errorf("Prepare() warnings = %v, want %v", prepared.Warnings, wantWarnings)
+	}
+}
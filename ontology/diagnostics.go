@@ -0,0 +1,64 @@
+package ontology
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oxisto/owl2proto/owl"
+)
+
+// Diagnostic describes a problem with an axiom of the ontology, e.g., a reference to a class that is not declared.
+type Diagnostic struct {
+	// Axiom is the kind of the axiom, e.g., "SubClassOf"
+	Axiom string
+
+	// IRI is the offending IRI
+	IRI string
+
+	// Message describes the problem
+	Message string
+
+	// Position is the position of the axiom in the source file
+	Position owl.Position
+}
+
+// String returns the diagnostic in the form "file:line:column: Axiom: message <IRI>". The IRI is omitted if the
+// problem does not concern a named entity, e.g., an anonymous sub-class.
+func (d Diagnostic) String() string {
+	var b strings.Builder
+
+	if pos := d.Position.String(); pos != "" {
+		b.WriteString(pos + ": ")
+	}
+
+	fmt.Fprintf(&b, "%s: %s", d.Axiom, d.Message)
+
+	if d.IRI != "" {
+		fmt.Fprintf(&b, " <%s>", d.IRI)
+	}
+
+	return b.String()
+}
+
+// Diagnostics is a list of diagnostics. It is returned as error by [Prepare].
+type Diagnostics []Diagnostic
+
+// Error returns all diagnostics, one per line.
+func (d Diagnostics) Error() string {
+	lines := make([]string, 0, len(d))
+	for _, diag := range d {
+		lines = append(lines, diag.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// report adds a diagnostic.
+func (d *Diagnostics) report(axiom string, iri string, pos owl.Position, format string, a ...any) {
+	*d = append(*d, Diagnostic{
+		Axiom:    axiom,
+		IRI:      iri,
+		Message:  fmt.Sprintf(format, a...),
+		Position: pos,
+	})
+}
@@ -0,0 +1,132 @@
+package ontology
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oxisto/owl2proto/internal/util"
+	"github.com/oxisto/owl2proto/owl"
+)
+
+// Enum is an enumeration of the ontology, i.e., a class that is equivalent to an ObjectOneOf or a datatype that is
+// defined as DataOneOf. It is translated into a proto enum.
+type Enum struct {
+	IRI     string
+	Name    string
+	Comment []string
+	Values  []*EnumValue
+
+	Deprecated        bool   // Deprecated is true if the class or datatype is marked as owl:deprecated
+	DeprecationReason string // Reason of the deprecation, if any
+}
+
+type EnumValue struct {
+	IRI        string // IRI of the named individual, empty for literals
+	Literal    string // Literal of DataOneOf, empty for individuals
+	Name       string // Name of the enum value including the prefix of the enum, e.g., "SEVERITY_HIGH"
+	Deprecated bool   // Deprecated is true if the named individual is marked as owl:deprecated
+}
+
+// UnspecifiedName returns the name of the default enum value, e.g., "SEVERITY_UNSPECIFIED".
+func (e *Enum) UnspecifiedName() string {
+	return util.ToUpperSnakeCase(e.Name) + "_UNSPECIFIED"
+}
+
+// prepareEnums collects the enumerations of the ontology. Classes of ObjectOneOf enumerations must be declared and
+// their members must be declared as named individuals.
+func (ont *OntologyPrepared) prepareEnums(src *owl.Ontology, diags *Diagnostics) {
+	for _, ec := range src.EquivalentClasses {
+		if len(ec.ObjectOneOf) == 0 {
+			continue
+		}
+
+		for _, c := range ec.Class {
+			iri := NormalizedIRI(ont, &c.Entity)
+			res, ok := ont.Resources[iri]
+			if !ok {
+				diags.report("EquivalentClasses", iri, ec.Position, "class is not declared")
+				continue
+			}
+
+			enum := &Enum{IRI: iri, Name: res.Name, Comment: res.Comment}
+
+			for _, v := range ec.ObjectOneOf[0].NamedIndividual {
+				individualIri := NormalizedIRI(ont, &v.Entity)
+				individual, ok := ont.NamedIndividual[individualIri]
+				if !ok {
+					diags.report("ObjectOneOf", individualIri, ec.Position, "named individual is not declared")
+					continue
+				}
+
+				enum.Values = append(enum.Values, &EnumValue{IRI: individualIri, Name: individual.Name})
+			}
+
+			ont.addEnum(enum, "ObjectOneOf", ec.Position)
+		}
+	}
+
+	for _, dd := range src.DatatypeDefinitions {
+		if len(dd.DataOneOf) == 0 {
+			continue
+		}
+
+		iri := ont.datatypeIRI(dd.Datatype)
+		enum := &Enum{IRI: iri, Name: util.CleanString(GetNameFromIri(iri))}
+		if dd.Datatype.IRI == "" && iri == dd.Datatype.AbbreviatedIRI {
+			// The prefix could not be resolved, so the name is the part after the prefix
+			_, name, _ := strings.Cut(iri, ":")
+			enum.Name = util.CleanString(name)
+		}
+
+		for _, literal := range dd.DataOneOf[0].Literal {
+			enum.Values = append(enum.Values, &EnumValue{Literal: literal, Name: literal})
+		}
+
+		ont.addEnum(enum, "DataOneOf", dd.Position)
+	}
+}
+
+// addEnum adds the enum and derives the names of its values. Values whose names collide with another value are made
+// unique and reported as warning.
+func (ont *OntologyPrepared) addEnum(enum *Enum, axiom string, pos owl.Position) {
+	var (
+		prefix = util.ToUpperSnakeCase(enum.Name) + "_"
+		names  = map[string]bool{enum.UnspecifiedName(): true}
+	)
+
+	for i, v := range enum.Values {
+		name := util.ToUpperSnakeCase(v.Name)
+		if name == "" {
+			name = fmt.Sprintf("VALUE_%d", i+1)
+		}
+		name = prefix + name
+
+		if names[name] {
+			ont.Warnings.report(axiom, enum.IRI, pos, "enum value %s is not unique", name)
+			name = fmt.Sprintf("%s_%d", name, i+1)
+		}
+
+		names[name] = true
+		v.Name = name
+	}
+
+	ont.Enums[enum.IRI] = enum
+}
+
+// datatype returns the abbreviated IRI of the datatype, which is used to look up its protobuf type.
+func (ont *OntologyPrepared) datatype(d owl.Datatype) string {
+	if d.IRI != "" {
+		return ont.AbbreviateIRI(d.IRI)
+	}
+
+	return d.AbbreviatedIRI
+}
+
+// datatypeIRI returns the normalized IRI of the datatype.
+func (ont *OntologyPrepared) datatypeIRI(d owl.Datatype) string {
+	if d.IRI != "" {
+		return d.IRI
+	}
+
+	return ont.normalizeAbbreviatedIRI(d.AbbreviatedIRI)
+}
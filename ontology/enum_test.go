@@ -0,0 +1,123 @@
+package ontology
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oxisto/owl2proto/owl"
+)
+
+func TestPrepare_enums(t *testing.T) {
+	var (
+		individual = func(iri string) owl.NamedIndividual {
+			return owl.NamedIndividual{Entity: owl.Entity{AbbreviatedIRI: iri}}
+		}
+		severity = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Severity"}}
+		resource = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Resource"}}
+		src      = &owl.Ontology{
+			Prefixes: []owl.Prefix{{Name: "ex", IRI: "http://example.com/cloud/"}},
+			Declarations: []owl.Declaration{
+				{Class: resource},
+				{Class: severity},
+				{DataProperty: owl.DataProperty{Entity: owl.Entity{AbbreviatedIRI: "ex:tlsVersion"}}},
+				{NamedIndividual: individual("ex:High")},
+				{NamedIndividual: individual("ex:high")},
+			},
+			EquivalentClasses: []owl.EquivalentClasses{{
+				Class:       []owl.Class{severity},
+				ObjectOneOf: []owl.ObjectOneOf{{NamedIndividual: []owl.NamedIndividual{individual("ex:High"), individual("ex:high")}}},
+			}},
+			DatatypeDefinitions: []owl.DatatypeDefinition{{
+				Datatype:  owl.Datatype{AbbreviatedIRI: "ex:TLSVersion"},
+				DataOneOf: []owl.DataOneOf{{Literal: []string{"tls1.2", "tls1.3", "-"}}},
+			}},
+			SubClasses: []owl.SubClassOf{{
+				Class: []owl.Class{resource},
+				DataSomeValuesFrom: []owl.DataSomeValuesFrom{{
+					DataProperty: owl.DataProperty{Entity: owl.Entity{AbbreviatedIRI: "ex:tlsVersion"}},
+					Datatype:     owl.Datatype{AbbreviatedIRI: "ex:TLSVersion"},
+				}},
+			}},
+		}
+	)
+
+	prepared, err := Prepare(src, "ex:Resource")
+	if err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	want := map[string]*Enum{
+		"http://example.com/cloud/Severity": {
+			IRI:  "http://example.com/cloud/Severity",
+			Name: "Severity",
+			Values: []*EnumValue{
+				{IRI: "http://example.com/cloud/High", Name: "SEVERITY_HIGH"},
+				{IRI: "http://example.com/cloud/high", Name: "SEVERITY_HIGH_2"},
+			},
+		},
+		"http://example.com/cloud/TLSVersion": {
+			IRI:  "http://example.com/cloud/TLSVersion",
+			Name: "TLSVersion",
+			Values: []*EnumValue{
+				{Literal: "tls1.2", Name: "TLS_VERSION_TLS1_2"},
+				{Literal: "tls1.3", Name: "TLS_VERSION_TLS1_3"},
+				{Literal: "-", Name: "TLS_VERSION_VALUE_3"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(prepared.Enums, want) {
+		t.Errorf("Prepare() enums = %v, want %v", prepared.Enums, want)
+	}
+
+	wantWarnings := Diagnostics{{
+		Axiom:   "ObjectOneOf",
+		IRI:     "http://example.com/cloud/Severity",
+		Message: "enum value SEVERITY_HIGH is not unique",
+	}}
+	if !reflect.DeepEqual(prepared.Warnings, wantWarnings) {
+		t.Errorf("Prepare() warnings = %v, want %v", prepared.Warnings, wantWarnings)
+	}
+
+	if got := prepared.Resources["http://example.com/cloud/Resource"].Relationship[0].Typ; got != "TLSVersion" {
+		t.Errorf("Prepare() data property type = %v, want %v", got, "TLSVersion")
+	}
+
+	gotRep, gotTyp, gotName := prepared.GetObjectDetail(&ObjectRelationship{
+		From:        "http://example.com/cloud/Resource",
+		To:          "http://example.com/cloud/Severity",
+		Name:        "Severity",
+		Cardinality: &Cardinality{Min: 0, Max: Unbounded},
+	})
+	if gotRep != "repeated " || gotTyp != "Severity" || gotName != "Severities" {
+		t.Errorf("GetObjectDetail() = (%v, %v, %v), want (%v, %v, %v)", gotRep, gotTyp, gotName, "repeated ", "Severity", "Severities")
+	}
+}
+
+func TestPrepare_enumIndividualNotDeclared(t *testing.T) {
+	pos := owl.Position{File: "cloud.owx", Line: 4, Column: 2}
+
+	_, err := Prepare(&owl.Ontology{
+		Prefixes: []owl.Prefix{{Name: "ex", IRI: "http://example.com/cloud/"}},
+		Declarations: []owl.Declaration{
+			{Class: owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Resource"}}},
+			{Class: owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Severity"}}},
+		},
+		EquivalentClasses: []owl.EquivalentClasses{{
+			Class: []owl.Class{{Entity: owl.Entity{AbbreviatedIRI: "ex:Severity"}}},
+			ObjectOneOf: []owl.ObjectOneOf{{NamedIndividual: []owl.NamedIndividual{
+				{Entity: owl.Entity{AbbreviatedIRI: "ex:High"}},
+			}}},
+			Position: pos,
+		}},
+	}, "ex:Resource")
+
+	want := Diagnostics{{
+		Axiom:    "ObjectOneOf",
+		IRI:      "http://example.com/cloud/High",
+		Message:  "named individual is not declared",
+		Position: pos,
+	}}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("Prepare() error = %v, want %v", err, want)
+	}
+}
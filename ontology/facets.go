@@ -0,0 +1,210 @@
+package ontology
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/oxisto/owl2proto/owl"
+	"github.com/oxisto/owl2proto/rdf"
+)
+
+// Datatype is a named datatype that is defined as restriction of another datatype, e.g., a port that is an
+// xsd:integer between 0 and 65535.
+type Datatype struct {
+	IRI    string
+	Base   string // Abbreviated IRI of the restricted datatype
+	Facets []Facet
+}
+
+// Facet is a constraining facet of a datatype restriction.
+type Facet struct {
+	IRI   string // Full IRI of the facet, e.g., "http://www.w3.org/2001/XMLSchema#maxLength"
+	Value string
+}
+
+// numericRules contains the protovalidate rules of numeric types, indexed by the facet.
+var numericRules = map[string]string{
+	rdf.NamespaceXSD + "minInclusive": "gte",
+	rdf.NamespaceXSD + "maxInclusive": "lte",
+	rdf.NamespaceXSD + "minExclusive": "gt",
+	rdf.NamespaceXSD + "maxExclusive": "lt",
+}
+
+// lengthRules contains the protovalidate rules of strings and bytes, indexed by the facet.
+var lengthRules = map[string]string{
+	rdf.NamespaceXSD + "length":    "len",
+	rdf.NamespaceXSD + "minLength": "min_len",
+	rdf.NamespaceXSD + "maxLength": "max_len",
+}
+
+// prepareDatatypes collects the named datatypes that are defined as datatype restriction.
+func (ont *OntologyPrepared) prepareDatatypes(src *owl.Ontology) {
+	for _, dd := range src.DatatypeDefinitions {
+		if dd.DatatypeRestriction == nil {
+			continue
+		}
+
+		iri := ont.datatypeIRI(dd.Datatype)
+		ont.Datatypes[iri] = &Datatype{
+			IRI:    iri,
+			Base:   ont.datatype(dd.DatatypeRestriction.Datatype),
+			Facets: facets(dd.DatatypeRestriction),
+		}
+	}
+}
+
+// dataRange returns the protobuf type and the validation rules of a data range, which is either the datatype d or,
+// if not nil, the datatype restriction r. Named datatypes that are defined as restriction are resolved to the
+// restricted datatype and their facets apply as well.
+func (ont *OntologyPrepared) dataRange(d owl.Datatype, r *owl.DatatypeRestriction, axiom string, pos owl.Position) (typ string, iri string, rules []string) {
+	var (
+		datatype = ont.datatype(d)
+		all      []Facet
+		visited  = map[string]bool{}
+	)
+
+	if r != nil {
+		datatype = ont.datatype(r.Datatype)
+		all = facets(r)
+	}
+
+	for {
+		named, ok := ont.Datatypes[ont.normalizeAbbreviatedIRI(datatype)]
+		if !ok || visited[named.IRI] {
+			break
+		}
+
+		visited[named.IRI] = true
+		datatype = named.Base
+		all = append(all, named.Facets...)
+	}
+
+	typ = ont.protoType(datatype, axiom, pos)
+
+	return typ, ont.expandIRI(datatype), ont.validationRules(typ, all, axiom, pos)
+}
+
+// validationRules translates the facets into protovalidate rules for the protobuf type, e.g., "string.max_len = 255".
+// Facets that cannot be expressed for the type or have an invalid value are reported as warning.
+func (ont *OntologyPrepared) validationRules(typ string, facets []Facet, axiom string, pos owl.Position) (rules []string) {
+	// Repeated types are validated per item
+	typ = strings.TrimPrefix(typ, "repeated ")
+
+	for _, f := range facets {
+		rule, err := validationRule(typ, f)
+		if err != nil {
+			ont.Warnings.report(axiom, f.IRI, pos, "%s", err)
+			continue
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return
+}
+
+// validationRule translates a single facet into a protovalidate rule for the protobuf type.
+func validationRule(typ string, f Facet) (string, error) {
+	var (
+		value = strings.TrimSpace(f.Value)
+		err   error
+	)
+
+	// Values of numeric facets must fit into the field, e.g., into 32 bits for an int32
+	switch typ {
+	case "int32", "int64", "sint32", "sint64", "sfixed32", "sfixed64":
+		if rule, ok := numericRules[f.IRI]; ok {
+			_, err = strconv.ParseInt(value, 10, bitSize(typ))
+			return fmt.Sprintf("%s.%s = %s", typ, rule, value), invalidValue(typ, f, err)
+		}
+	case "uint32", "uint64", "fixed32", "fixed64":
+		if rule, ok := numericRules[f.IRI]; ok {
+			_, err = strconv.ParseUint(value, 10, bitSize(typ))
+			return fmt.Sprintf("%s.%s = %s", typ, rule, value), invalidValue(typ, f, err)
+		}
+	case "float", "double":
+		if rule, ok := numericRules[f.IRI]; ok {
+			_, err = strconv.ParseFloat(value, bitSize(typ))
+			return fmt.Sprintf("%s.%s = %s", typ, rule, value), invalidValue(typ, f, err)
+		}
+	case "string", "bytes":
+		if rule, ok := lengthRules[f.IRI]; ok {
+			_, err = strconv.ParseUint(value, 10, 64)
+			return fmt.Sprintf("%s.%s = %s", typ, rule, value), invalidValue(typ, f, err)
+		} else if f.IRI == rdf.NamespaceXSD+"pattern" {
+			err = checkPattern(f.Value)
+			if err != nil {
+				return "", err
+			}
+
+			// Patterns of XML schema are implicitly anchored, the ones of protovalidate are not
+			return fmt.Sprintf("%s.pattern = %s", typ, strconv.Quote("^(?:"+f.Value+")$")), nil
+		}
+	}
+
+	return "", fmt.Errorf("facet is not supported for type %s", typ)
+}
+
+// bitSize returns the number of bits of a numeric protobuf type.
+func bitSize(typ string) int {
+	if typ == "float" || strings.HasSuffix(typ, "32") {
+		return 32
+	}
+
+	return 64
+}
+
+// invalidValue returns an error describing the invalid value of the facet, if err is not nil.
+func invalidValue(typ string, f Facet, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return fmt.Errorf("value %q of facet is out of range for type %s", f.Value, typ)
+	} else if err != nil {
+		return fmt.Errorf("invalid value %q of facet", f.Value)
+	}
+
+	return nil
+}
+
+// checkPattern returns an error if the pattern of XML schema cannot be used as regular expression of protovalidate,
+// which uses the RE2 syntax. Some constructs of XML schema are valid RE2, but have a different meaning, e.g., the
+// character class subtraction [a-z-[aeiou]], and are therefore rejected explicitly.
+func checkPattern(pattern string) error {
+	var class bool
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\' && i+1 < len(pattern):
+			i++
+
+			// The name characters of XML are not known by RE2
+			if strings.IndexByte("iIcC", pattern[i]) != -1 {
+				return fmt.Errorf("pattern %q uses the escape \\%c of XML schema, which is not supported", pattern, pattern[i])
+			}
+		case c == '[' && !class:
+			class = true
+		case c == '-' && class && i+1 < len(pattern) && pattern[i+1] == '[':
+			return fmt.Errorf("pattern %q uses a character class subtraction of XML schema, which is not supported", pattern)
+		case c == ']' && class:
+			class = false
+		}
+	}
+
+	_, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("pattern %q is not supported: %w", pattern, err)
+	}
+
+	return nil
+}
+
+// facets returns the facets of the datatype restriction.
+func facets(r *owl.DatatypeRestriction) (facets []Facet) {
+	for _, f := range r.FacetRestriction {
+		facets = append(facets, Facet{IRI: f.Facet, Value: f.Literal})
+	}
+
+	return
+}
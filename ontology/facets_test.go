@@ -0,0 +1,194 @@
+package ontology
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oxisto/owl2proto/owl"
+)
+
+func Test_validationRule(t *testing.T) {
+	const xsd = "http://www.w3.org/2001/XMLSchema#"
+
+	type args struct {
+		typ string
+		f   Facet
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Minimum",
+			args: args{typ: "int32", f: Facet{IRI: xsd + "minInclusive", Value: "0"}},
+			want: "int32.gte = 0",
+		},
+		{
+			name: "Exclusive maximum",
+			args: args{typ: "float", f: Facet{IRI: xsd + "maxExclusive", Value: " 1.5 "}},
+			want: "float.lt = 1.5",
+		},
+		{
+			name: "Maximum length",
+			args: args{typ: "string", f: Facet{IRI: xsd + "maxLength", Value: "255"}},
+			want: "string.max_len = 255",
+		},
+		{
+			name: "Pattern is anchored",
+			args: args{typ: "string", f: Facet{IRI: xsd + "pattern", Value: `[a-z]+|\d`}},
+			want: `string.pattern = "^(?:[a-z]+|\\d)$"`,
+		},
+		{
+			name:    "Negative unsigned value",
+			args:    args{typ: "uint32", f: Facet{IRI: xsd + "minInclusive", Value: "-1"}},
+			wantErr: true,
+		},
+		{
+			name:    "Value out of range of int32",
+			args:    args{typ: "sfixed32", f: Facet{IRI: xsd + "maxInclusive", Value: "2147483648"}},
+			wantErr: true,
+		},
+		{
+			name: "Value in range of int64",
+			args: args{typ: "int64", f: Facet{IRI: xsd + "maxInclusive", Value: "2147483648"}},
+			want: "int64.lte = 2147483648",
+		},
+		{
+			name:    "Value out of range of uint32",
+			args:    args{typ: "fixed32", f: Facet{IRI: xsd + "maxInclusive", Value: "4294967296"}},
+			wantErr: true,
+		},
+		{
+			name:    "Value out of range of float",
+			args:    args{typ: "float", f: Facet{IRI: xsd + "maxInclusive", Value: "1e39"}},
+			wantErr: true,
+		},
+		{
+			name: "Escaped name character",
+			args: args{typ: "string", f: Facet{IRI: xsd + "pattern", Value: `\\i\[`}},
+			want: `string.pattern = "^(?:\\\\i\\[)$"`,
+		},
+		{
+			name:    "Pattern with initial name characters",
+			args:    args{typ: "string", f: Facet{IRI: xsd + "pattern", Value: `\i\c*`}},
+			wantErr: true,
+		},
+		{
+			name:    "Pattern with character class subtraction",
+			args:    args{typ: "string", f: Facet{IRI: xsd + "pattern", Value: `[a-z-[aeiou]]+`}},
+			wantErr: true,
+		},
+		{
+			name:    "Pattern with unicode block",
+			args:    args{typ: "string", f: Facet{IRI: xsd + "pattern", Value: `\p{IsBasicLatin}+`}},
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported type",
+			args:    args{typ: "google.protobuf.Timestamp", f: Facet{IRI: xsd + "minInclusive", Value: "2024-01-01T00:00:00Z"}},
+			wantErr: true,
+		},
+		{
+			name:    "Length of a number",
+			args:    args{typ: "int32", f: Facet{IRI: xsd + "maxLength", Value: "3"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validationRule(tt.args.typ, tt.args.f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validationRule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("validationRule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrepare_datatypeRestrictions(t *testing.T) {
+	var (
+		dataProperty = func(iri string) owl.DataProperty {
+			return owl.DataProperty{Entity: owl.Entity{AbbreviatedIRI: iri}}
+		}
+		facet = func(name, value string) owl.FacetRestriction {
+			return owl.FacetRestriction{Facet: "http://www.w3.org/2001/XMLSchema#" + name, Literal: value}
+		}
+		resource = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Resource"}}
+		src      = &owl.Ontology{
+			Prefixes: []owl.Prefix{{Name: "ex", IRI: "http://example.com/cloud/"}},
+			Declarations: []owl.Declaration{
+				{Class: resource},
+				{DataProperty: dataProperty("ex:name")},
+				{DataProperty: dataProperty("ex:port")},
+				{DataProperty: dataProperty("ex:adminPort")},
+			},
+			DatatypeDefinitions: []owl.DatatypeDefinition{
+				{
+					Datatype: owl.Datatype{AbbreviatedIRI: "ex:Port"},
+					DatatypeRestriction: &owl.DatatypeRestriction{
+						Datatype:         owl.Datatype{AbbreviatedIRI: "xsd:integer"},
+						FacetRestriction: []owl.FacetRestriction{facet("minInclusive", "0"), facet("maxExclusive", "65536")},
+					},
+				},
+				{
+					Datatype: owl.Datatype{IRI: "http://example.com/cloud/PrivilegedPort"},
+					DatatypeRestriction: &owl.DatatypeRestriction{
+						Datatype:         owl.Datatype{AbbreviatedIRI: "ex:Port"},
+						FacetRestriction: []owl.FacetRestriction{facet("maxExclusive", "1024")},
+					},
+				},
+			},
+			SubClasses: []owl.SubClassOf{{
+				Class: []owl.Class{resource},
+				DataSomeValuesFrom: []owl.DataSomeValuesFrom{
+					{
+						DataProperty: dataProperty("ex:name"),
+						DatatypeRestriction: &owl.DatatypeRestriction{
+							Datatype:         owl.Datatype{AbbreviatedIRI: "xsd:string"},
+							FacetRestriction: []owl.FacetRestriction{facet("maxLength", "255"), facet("minInclusive", "1")},
+						},
+					},
+					{DataProperty: dataProperty("ex:port"), Datatype: owl.Datatype{AbbreviatedIRI: "ex:Port"}},
+					{DataProperty: dataProperty("ex:adminPort"), Datatype: owl.Datatype{AbbreviatedIRI: "ex:PrivilegedPort"}},
+				},
+			}},
+		}
+	)
+
+	prepared, err := Prepare(src, "ex:Resource")
+	if err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	type rel struct {
+		Typ   string
+		Rules []string
+	}
+	var got []rel
+	for _, r := range prepared.Resources["http://example.com/cloud/Resource"].Relationship {
+		got = append(got, rel{r.Typ, r.Rules})
+	}
+
+	want := []rel{
+		{Typ: "string", Rules: []string{"string.max_len = 255"}},
+		{Typ: "int32", Rules: []string{"int32.gte = 0", "int32.lt = 65536"}},
+		{Typ: "int32", Rules: []string{"int32.lt = 1024", "int32.gte = 0", "int32.lt = 65536"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Prepare() relationships = %v, want %v", got, want)
+	}
+
+	wantWarnings := Diagnostics{{
+		Axiom:   "DataSomeValuesFrom",
+		IRI:     "http://www.w3.org/2001/XMLSchema#minInclusive",
+		Message: "facet is not supported for type string",
+	}}
+	if !reflect.DeepEqual(prepared.Warnings, wantWarnings) {
+		t.Errorf("Prepare() warnings = %v, want %v", prepared.Warnings, wantWarnings)
+	}
+}
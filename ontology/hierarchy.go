@@ -0,0 +1,49 @@
+package ontology
+
+// Ancestors returns the IRIs of all super-classes of the class, excluding "owl:Thing". The super-classes are
+// linearized, i.e., every class is listed before all of its own super-classes and direct super-classes are listed in
+// the order of their SubClassOf axioms. Each class is only contained once, even if it is inherited several times.
+func (po *OntologyPrepared) Ancestors(iri string) []string {
+	return po.linearize(iri, false)[1:]
+}
+
+// Lineage returns the IRI of the class followed by the linearized IRIs of its super-classes up to the root resource.
+// These are all classes whose properties are contained in the message of the class.
+func (po *OntologyPrepared) Lineage(iri string) []string {
+	return po.linearize(iri, true)
+}
+
+// linearize returns the class and its super-classes in reverse post-order of a depth-first search. Visiting the
+// direct super-classes in reverse order keeps them in the order of their axioms. If stopAtRoot is set, the
+// super-classes of the root resource are not visited.
+func (po *OntologyPrepared) linearize(iri string, stopAtRoot bool) []string {
+	var (
+		visited = map[string]bool{}
+		order   []string
+		visit   func(iri string)
+	)
+
+	visit = func(iri string) {
+		visited[iri] = true
+
+		res, ok := po.Resources[iri]
+		if ok && !(stopAtRoot && iri == po.RootResourceName) {
+			for i := len(res.Parents) - 1; i >= 0; i-- {
+				if !visited[res.Parents[i]] {
+					visit(res.Parents[i])
+				}
+			}
+		}
+
+		order = append(order, iri)
+	}
+
+	visit(iri)
+
+	// Reverse the post-order, so that the class itself comes first
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+
+	return order
+}
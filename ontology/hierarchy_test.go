@@ -0,0 +1,141 @@
+package ontology
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oxisto/owl2proto/owl"
+)
+
+// prepareHierarchy prepares an ontology with the given classes and SubClassOf axioms (pairs of sub- and super-class).
+func prepareHierarchy(t *testing.T, classes []string, subClasses [][2]string) *OntologyPrepared {
+	t.Helper()
+
+	src := &owl.Ontology{Prefixes: []owl.Prefix{{Name: "ex", IRI: "http://example.com/cloud/"}}}
+	for _, c := range classes {
+		src.Declarations = append(src.Declarations, owl.Declaration{Class: owl.Class{Entity: owl.Entity{AbbreviatedIRI: c}}})
+	}
+	for _, sc := range subClasses {
+		src.SubClasses = append(src.SubClasses, owl.SubClassOf{Class: []owl.Class{
+			{Entity: owl.Entity{AbbreviatedIRI: sc[0]}},
+			{Entity: owl.Entity{AbbreviatedIRI: sc[1]}},
+		}})
+	}
+
+	prepared, err := Prepare(src, "ex:Resource")
+	if err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	return prepared
+}
+
+func TestOntologyPrepared_Ancestors(t *testing.T) {
+	const ex = "http://example.com/cloud/"
+
+	tests := []struct {
+		name       string
+		subClasses [][2]string
+		iri        string
+		want       []string
+	}{
+		{
+			name:       "Single inheritance",
+			subClasses: [][2]string{{"ex:Storage", "ex:Resource"}, {"ex:ObjectStorage", "ex:Storage"}},
+			iri:        ex + "ObjectStorage",
+			want:       []string{ex + "Storage", ex + "Resource"},
+		},
+		{
+			name: "Diamond",
+			subClasses: [][2]string{
+				{"ex:Storage", "ex:Resource"},
+				{"ex:Service", "ex:Resource"},
+				{"ex:ObjectStorage", "ex:Storage"},
+				{"ex:ObjectStorage", "ex:Service"},
+			},
+			iri:  ex + "ObjectStorage",
+			want: []string{ex + "Storage", ex + "Service", ex + "Resource"},
+		},
+		{
+			name: "Shared ancestor is listed after all of its sub-classes",
+			subClasses: [][2]string{
+				{"ex:ObjectStorage", "ex:Storage"},
+				{"ex:ObjectStorage", "ex:Service"},
+				{"ex:Storage", "ex:Resource"},
+				{"ex:Service", "ex:Networking"},
+				{"ex:Networking", "ex:Resource"},
+			},
+			iri:  ex + "ObjectStorage",
+			want: []string{ex + "Storage", ex + "Service", ex + "Networking", ex + "Resource"},
+		},
+		{
+			name:       "Duplicate axioms",
+			subClasses: [][2]string{{"ex:Storage", "ex:Resource"}, {"ex:Storage", "ex:Resource"}},
+			iri:        ex + "Storage",
+			want:       []string{ex + "Resource"},
+		},
+		{
+			name:       "Cycle",
+			subClasses: [][2]string{{"ex:Storage", "ex:Service"}, {"ex:Service", "ex:Storage"}},
+			iri:        ex + "Storage",
+			want:       []string{ex + "Service"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			po := prepareHierarchy(t, []string{"ex:Resource", "ex:Storage", "ex:Service", "ex:Networking", "ex:ObjectStorage"}, tt.subClasses)
+
+			if got := po.Ancestors(tt.iri); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OntologyPrepared.Ancestors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOntologyPrepared_Lineage(t *testing.T) {
+	const ex = "http://example.com/cloud/"
+
+	po := prepareHierarchy(t,
+		[]string{"ex:Entity", "ex:Resource", "ex:Storage", "ex:Service", "ex:ObjectStorage"},
+		[][2]string{
+			{"ex:Resource", "ex:Entity"},
+			{"ex:Storage", "ex:Resource"},
+			{"ex:Service", "ex:Resource"},
+			{"ex:ObjectStorage", "ex:Storage"},
+			{"ex:ObjectStorage", "ex:Service"},
+		},
+	)
+
+	want := []string{ex + "ObjectStorage", ex + "Storage", ex + "Service", ex + "Resource"}
+	if got := po.Lineage(ex + "ObjectStorage"); !reflect.DeepEqual(got, want) {
+		t.Errorf("OntologyPrepared.Lineage() = %v, want %v", got, want)
+	}
+}
+
+func TestOntologyPrepared_FindAllDataProperties(t *testing.T) {
+	const ex = "http://example.com/cloud/"
+
+	po := prepareHierarchy(t,
+		[]string{"ex:Resource", "ex:Storage", "ex:Service", "ex:ObjectStorage"},
+		[][2]string{
+			{"ex:Storage", "ex:Resource"},
+			{"ex:Service", "ex:Resource"},
+			{"ex:ObjectStorage", "ex:Storage"},
+			{"ex:ObjectStorage", "ex:Service"},
+		},
+	)
+
+	var (
+		name     = &Relationship{IRI: ex + "name", Name: "name", From: ex + "Resource"}
+		capacity = &Relationship{IRI: ex + "capacity", Name: "capacity", From: ex + "Storage"}
+		endpoint = &Relationship{IRI: ex + "endpoint", Name: "endpoint", From: ex + "Service"}
+	)
+	po.Resources[ex+"Resource"].Relationship = []*Relationship{name}
+	po.Resources[ex+"Storage"].Relationship = []*Relationship{capacity}
+	po.Resources[ex+"Service"].Relationship = []*Relationship{endpoint}
+
+	want := []*Relationship{capacity, endpoint, name}
+	if got := po.FindAllDataProperties(ex + "ObjectStorage"); !reflect.DeepEqual(got, want) {
+		t.Errorf("OntologyPrepared.FindAllDataProperties() = %v, want %v", got, want)
+	}
+}
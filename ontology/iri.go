@@ -3,6 +3,7 @@ package ontology
 import (
 	"strings"
 
+	"github.com/oxisto/owl2proto/internal/util"
 	"github.com/oxisto/owl2proto/owl"
 )
 
@@ -57,14 +58,29 @@ func (ont *OntologyPrepared) normalizeAbbreviatedIRI(iri string) string {
 }
 
 // AbbreviateIRI returns an abbreviated IRI, e.g., "ex:Storage" -> "http://example.com/cloud/Storage" if a matching
-// prefix is found. Otherwise, the long version is returned
+// prefix is found. Otherwise, the long version is returned. If several prefixes match, the one with the longest IRI
+// is chosen and named prefixes are preferred over the default (empty) prefix.
 func (ont *OntologyPrepared) AbbreviateIRI(iri string) string {
-	for short, prefix := range ont.Prefixes {
-		_, name, found := strings.Cut(iri, prefix.IRI)
-		if found {
-			return short + ":" + name
-		}
+	if match := ont.prefixOf(iri); match != nil {
+		return match.Name + ":" + strings.TrimPrefix(iri, match.IRI)
 	}
 
 	return iri
 }
+
+// prefixOf returns the prefix whose namespace is the longest match of the IRI, preferring named prefixes over the
+// empty prefix, or nil if no prefix matches.
+func (ont *OntologyPrepared) prefixOf(iri string) (match *owl.Prefix) {
+	for _, short := range util.SortMapKeys(ont.Prefixes) {
+		prefix := ont.Prefixes[short]
+		if prefix.IRI == "" || !strings.HasPrefix(iri, prefix.IRI) {
+			continue
+		}
+
+		if match == nil || len(prefix.IRI) > len(match.IRI) || (len(prefix.IRI) == len(match.IRI) && match.Name == "") {
+			match = prefix
+		}
+	}
+
+	return
+}
@@ -184,6 +184,40 @@ func TestOntologyPrepared_AbbreviateIRI(t *testing.T) {
 			},
 			want: "ex:Resource",
 		},
+		{
+			name: "Happy path: prefer named prefix",
+			fields: fields{
+				Prefixes: map[string]*owl.Prefix{
+					"": {
+						Name: "",
+						IRI:  "http://example.com/",
+					},
+					"ex": {
+						Name: "ex",
+						IRI:  "http://example.com/",
+					},
+				},
+			},
+			args: args{
+				iri: "http://example.com/Resource",
+			},
+			want: "ex:Resource",
+		},
+		{
+			name: "No matching prefix",
+			fields: fields{
+				Prefixes: map[string]*owl.Prefix{
+					"ex": {
+						Name: "ex",
+						IRI:  "http://example.com/",
+					},
+				},
+			},
+			args: args{
+				iri: "http://example.org/Resource",
+			},
+			want: "http://example.org/Resource",
+		},
 	}
 
 	for _, tt := range tests {
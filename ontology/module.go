@@ -0,0 +1,54 @@
+package ontology
+
+import (
+	"strings"
+
+	"github.com/oxisto/owl2proto/owl"
+)
+
+// ModuleAnnotation is the annotation property that assigns a class or an enumeration to a module, e.g.,
+// "o2p:module" with the prefix o2p = <https://github.com/oxisto/owl2proto#>. Modules are used to split the generated
+// proto messages into several files.
+const ModuleAnnotation = "https://github.com/oxisto/owl2proto#module"
+
+// prepareModules collects the modules specified by the [ModuleAnnotation].
+func (ont *OntologyPrepared) prepareModules(src *owl.Ontology) {
+	for _, aa := range src.AnnotationAssertion {
+		if ont.annotationPropertyIRI(aa.AnnotationProperty) != ModuleAnnotation {
+			continue
+		}
+
+		iri := NormalizedIRI(ont, aa)
+
+		if module := strings.TrimSpace(aa.Literal); module == "" {
+			ont.Warnings.report("AnnotationAssertion", iri, aa.Position, "empty module")
+		} else {
+			ont.Modules[iri] = module
+		}
+	}
+}
+
+// ModuleOf returns the module of a class or enumeration. If byAnnotation is set, this is the module of the
+// [ModuleAnnotation] of the class or, if it is not annotated, of its nearest annotated super-class. Otherwise, or if
+// none of them is annotated, the name of the prefix of the IRI is returned.
+func (ont *OntologyPrepared) ModuleOf(iri string, byAnnotation bool) string {
+	if byAnnotation {
+		for _, class := range ont.Lineage(iri) {
+			if module, ok := ont.Modules[class]; ok {
+				return module
+			}
+		}
+	}
+
+	return ont.PrefixOf(iri)
+}
+
+// PrefixOf returns the name of the prefix whose namespace is the longest match of the IRI. Named prefixes are
+// preferred over the empty prefix. If no prefix matches, an empty string is returned.
+func (ont *OntologyPrepared) PrefixOf(iri string) string {
+	if p := ont.prefixOf(iri); p != nil {
+		return p.Name
+	}
+
+	return ""
+}
@@ -0,0 +1,95 @@
+package ontology
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oxisto/owl2proto/owl"
+)
+
+func TestOntologyPrepared_ModuleOf(t *testing.T) {
+	var (
+		resource = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Resource"}}
+		storage  = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Storage"}}
+		block    = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:BlockStorage"}}
+		firewall = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "sec:Firewall"}}
+		src      = &owl.Ontology{
+			Prefixes: []owl.Prefix{
+				{Name: "", IRI: "http://example.com/cloud/"},
+				{Name: "ex", IRI: "http://example.com/cloud/"},
+				{Name: "sec", IRI: "http://example.com/cloud/security/"},
+				{Name: "o2p", IRI: "https://github.com/oxisto/owl2proto#"},
+			},
+			Declarations: []owl.Declaration{{Class: resource}, {Class: storage}, {Class: block}, {Class: firewall}},
+			SubClasses: []owl.SubClassOf{
+				{Class: []owl.Class{storage, resource}},
+				{Class: []owl.Class{block, storage}},
+				{Class: []owl.Class{firewall, resource}},
+			},
+			AnnotationAssertion: []owl.AnnotationAssertion{
+				{AnnotationProperty: owl.AnnotationProperty{AbbreviatedIRI: "o2p:module"}, AbbreviatedIRI: "ex:Storage", Literal: "storage"},
+				{AnnotationProperty: owl.AnnotationProperty{AbbreviatedIRI: "o2p:module"}, AbbreviatedIRI: "ex:Resource", Literal: " "},
+			},
+		}
+	)
+
+	prepared, err := Prepare(src, "ex:Resource")
+	if err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	if want := map[string]string{"http://example.com/cloud/Storage": "storage"}; !reflect.DeepEqual(prepared.Modules, want) {
+		t.Errorf("Prepare() modules = %v, want %v", prepared.Modules, want)
+	}
+	if len(prepared.Warnings) != 1 || prepared.Warnings[0].Message != "empty module" {
+		t.Errorf("Prepare() warnings = %v, want empty module", prepared.Warnings)
+	}
+
+	type args struct {
+		iri          string
+		byAnnotation bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Named prefix is preferred",
+			args: args{iri: "http://example.com/cloud/Resource"},
+			want: "ex",
+		},
+		{
+			name: "Longest prefix",
+			args: args{iri: "http://example.com/cloud/security/Firewall", byAnnotation: true},
+			want: "sec",
+		},
+		{
+			name: "Annotation is ignored",
+			args: args{iri: "http://example.com/cloud/Storage"},
+			want: "ex",
+		},
+		{
+			name: "Annotation",
+			args: args{iri: "http://example.com/cloud/Storage", byAnnotation: true},
+			want: "storage",
+		},
+		{
+			name: "Annotation of super-class",
+			args: args{iri: "http://example.com/cloud/BlockStorage", byAnnotation: true},
+			want: "storage",
+		},
+		{
+			name: "No prefix",
+			args: args{iri: "http://example.org/Other", byAnnotation: true},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prepared.ModuleOf(tt.args.iri, tt.args.byAnnotation); got != tt.want {
+				t.Errorf("OntologyPrepared.ModuleOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
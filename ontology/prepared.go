@@ -2,6 +2,7 @@ package ontology
 
 import (
 	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/oxisto/owl2proto/internal/util"
@@ -10,6 +11,10 @@ import (
 
 // OntologyPrepared contains an [owl.Ontology] in a way that is "prepared" for the translation to protobuf messages.
 type OntologyPrepared struct {
+	// IRI and VersionIRI identify the ontology and its version
+	IRI        string
+	VersionIRI string
+
 	Resources           map[string]*Resource
 	SubClasses          map[string]*owl.SubClassOf
 	AnnotationAssertion map[string]*AnnotationAssertion
@@ -18,33 +23,71 @@ type OntologyPrepared struct {
 	Prefixes map[string]*owl.Prefix
 
 	RootResourceName string
+
+	// SuperProperties contains the IRIs of the direct super-properties of object and data properties, indexed by the
+	// IRI of the sub-property
+	SuperProperties map[string][]string
+
+	// Enums contains the enumerations of individuals and literals, indexed by the IRI of the class or datatype
+	Enums map[string]*Enum
+
+	// Datatypes contains the named datatypes that are defined as datatype restriction, indexed by their IRI
+	Datatypes map[string]*Datatype
+
+	// TypeMap maps the full IRIs of datatypes to protobuf types
+	TypeMap TypeMap
+
+	// Deprecations contains the reasons (possibly empty) of deprecated entities, indexed by their IRI
+	Deprecations map[string]string
+
+	// Modules contains the modules of classes and enumerations specified by the o2p:module annotation, indexed by
+	// their IRI
+	Modules map[string]string
+
+	// References contains the reference policies of object properties and classes, indexed by their IRI
+	References map[string]Reference
+
+	// Warnings contains problems of the ontology that do not prevent the generation, e.g., unknown datatypes
+	Warnings Diagnostics
 }
 
 type Resource struct {
 	Iri                string
 	Name               string
-	Parent             string
+	Parents            []string // IRIs of the direct parents in the order of their SubClassOf axioms
 	Comment            []string
 	Relationship       []*Relationship
 	ObjectRelationship []*ObjectRelationship
 	SubResources       []*Resource
+	Overrides          []*CardinalityOverride // Cardinality restrictions of the class on inherited properties
+	Deprecated         bool                   // Deprecated is true if the class is marked as owl:deprecated
+	DeprecationReason  string                 // Reason of the deprecation, if any
 }
 
 type Relationship struct {
-	IRI     string
-	Typ     string // Data type
-	Name    string // Name of the IRI
-	Comment string
-	From    string // IRI
+	IRI         string
+	Typ         string // Data type
+	Datatype    string // Full IRI of the datatype or, for datatype restrictions, of their base datatype
+	Name        string // Name of the IRI
+	Comment     string
+	From        string       // IRI
+	Cardinality *Cardinality // Cardinality restrictions, nil if unrestricted
+	Rules       []string     // Validation rules derived from datatype restrictions, e.g., "string.max_len = 255"
+
+	Deprecated        bool   // Deprecated is true if the property is marked as owl:deprecated
+	DeprecationReason string // Reason of the deprecation, if any
 }
 
 type ObjectRelationship struct {
 	ObjectProperty     string
 	ObjectPropertyName string
-	From               string // IRI
-	To                 string // IRI
-	Name               string // Name of To IRI
-	Comment            string // Comment of the property
+	From               string       // IRI
+	To                 string       // IRI
+	Name               string       // Name of To IRI
+	Comment            string       // Comment of the property
+	Cardinality        *Cardinality // Cardinality restrictions, nil if unrestricted
+	Deprecated         bool         // Deprecated is true if the object property is marked as owl:deprecated
+	DeprecationReason  string       // Reason of the deprecation, if any
 }
 
 type AnnotationAssertion struct {
@@ -59,39 +102,90 @@ type NamedIndividual struct {
 	Type string
 }
 
-// FindAllDataProperties adds all object properties for the given entity and the parents
+// FindAllDataProperties adds all data properties for the given entity and all of its parents. Properties that are
+// inherited via several parents are only contained once. If the entity or one of its parents overrides the
+// cardinality of an inherited property, a copy of the relationship with the narrowed cardinality is returned.
 func (po *OntologyPrepared) FindAllDataProperties(iri string) []*Relationship {
-	var (
-		relationships []*Relationship
-		parent        string
-	)
+	var relationships []*Relationship
 
-	res, ok := po.Resources[iri]
-	if !ok {
+	if _, ok := po.Resources[iri]; !ok {
 		slog.Error("Could not find entity", "iri", iri)
 		return nil
 	}
 
-	relationships = append(relationships, res.Relationship...)
+	lineage := po.Lineage(iri)
+	for _, class := range lineage {
+		if res, ok := po.Resources[class]; ok {
+			for _, r := range res.Relationship {
+				if c, ok := po.overriddenCardinality(lineage, r.IRI, "", r.Cardinality); ok {
+					overridden := *r
+					overridden.Cardinality = c
+					r = &overridden
+				}
 
-	parent = po.Resources[iri].Parent
-	if parent == "" || iri == po.RootResourceName {
-		return relationships
-	} else {
-		relationships = append(relationships, po.FindAllDataProperties(parent)...)
+				relationships = append(relationships, r)
+			}
+		}
 	}
 
 	return relationships
 }
 
-// Prepare extracts important information from the owl ontology file that is needed for the protobuf file creation.
-func Prepare(src *owl.Ontology, rootIRI string) *OntologyPrepared {
+// FindAllObjectProperties adds all object properties for the given entity and all of its parents. Properties that
+// are inherited via several parents are only contained once. If the entity or one of its parents overrides the
+// cardinality of an inherited property, a copy of the relationship with the narrowed cardinality is returned.
+func (po *OntologyPrepared) FindAllObjectProperties(iri string) []*ObjectRelationship {
+	var objectRelationships []*ObjectRelationship
+
+	if _, ok := po.Resources[iri]; !ok {
+		slog.Error("Could not find entity", "iri", iri)
+		return nil
+	}
+
+	lineage := po.Lineage(iri)
+	for _, class := range lineage {
+		if res, ok := po.Resources[class]; ok {
+			for _, o := range res.ObjectRelationship {
+				if c, ok := po.overriddenCardinality(lineage, o.ObjectProperty, o.To, o.Cardinality); ok {
+					overridden := *o
+					overridden.Cardinality = c
+					o = &overridden
+				}
+
+				objectRelationships = append(objectRelationships, o)
+			}
+		}
+	}
+
+	return objectRelationships
+}
+
+// Prepare extracts important information from the owl ontology file that is needed for the protobuf file creation
+// using the [DefaultTypeMap]. Axioms that reference entities which are not declared are skipped; all of them are
+// returned as [Diagnostics].
+func Prepare(src *owl.Ontology, rootIRI string) (*OntologyPrepared, error) {
+	return PrepareWithTypeMap(src, rootIRI, nil)
+}
+
+// PrepareWithTypeMap works like [Prepare], but the datatypes of the type map take precedence over the
+// [DefaultTypeMap].
+func PrepareWithTypeMap(src *owl.Ontology, rootIRI string, types TypeMap) (*OntologyPrepared, error) {
+	var diags Diagnostics
+
 	preparedOntology := &OntologyPrepared{
+		IRI:                 src.IRI,
+		VersionIRI:          src.VersionIRI,
 		Prefixes:            map[string]*owl.Prefix{},
 		Resources:           map[string]*Resource{},
 		SubClasses:          map[string]*owl.SubClassOf{},
 		AnnotationAssertion: map[string]*AnnotationAssertion{},
 		NamedIndividual:     map[string]*NamedIndividual{},
+		SuperProperties:     map[string][]string{},
+		Enums:               map[string]*Enum{},
+		Datatypes:           map[string]*Datatype{},
+		Deprecations:        map[string]string{},
+		References:          map[string]Reference{},
+		Modules:             map[string]string{},
 		RootResourceName:    rootIRI,
 	}
 
@@ -100,12 +194,21 @@ func Prepare(src *owl.Ontology, rootIRI string) *OntologyPrepared {
 		preparedOntology.Prefixes[p.Name] = p
 	}
 
+	preparedOntology.prepareTypeMap(types)
+
 	// Make sure our root resource name is definitely not an abbreviated IRI anymore
 	preparedOntology.RootResourceName = preparedOntology.normalizeAbbreviatedIRI(preparedOntology.RootResourceName)
 
 	for _, c := range src.Declarations {
 		iri := NormalizedIRI(preparedOntology, &c.Class.Entity)
 
+		// Abbreviated IRIs with an unknown prefix cannot be resolved to a full IRI
+		for _, e := range []owl.Entity{c.Class.Entity, c.ObjectProperty.Entity, c.DataProperty.Entity, c.NamedIndividual.Entity} {
+			if e.IRI == "" && e.AbbreviatedIRI != "" && !preparedOntology.hasPrefix(e.AbbreviatedIRI) {
+				preparedOntology.Warnings.report("Declaration", e.AbbreviatedIRI, c.Position, "prefix is not declared")
+			}
+		}
+
 		// Prepare ontology classes
 		// We set the name extracted from the IRI and the IRI. If a name label exists we will change the name later.
 		if iri != "" {
@@ -143,12 +246,12 @@ func Prepare(src *owl.Ontology, rootIRI string) *OntologyPrepared {
 
 		// Prepare ontology named individuals
 		if c.NamedIndividual.IRI != "" {
-			preparedOntology.NamedIndividual[c.NamedIndividual.IRI] = &NamedIndividual{
+			preparedOntology.NamedIndividual[NormalizedIRI(preparedOntology, &c.NamedIndividual.Entity)] = &NamedIndividual{
 				IRI:  c.NamedIndividual.IRI,
 				Name: util.CleanString(GetNameFromIri(c.NamedIndividual.IRI)),
 			}
 		} else if c.NamedIndividual.AbbreviatedIRI != "" {
-			preparedOntology.NamedIndividual[c.NamedIndividual.AbbreviatedIRI] = &NamedIndividual{
+			preparedOntology.NamedIndividual[NormalizedIRI(preparedOntology, &c.NamedIndividual.Entity)] = &NamedIndividual{
 				IRI:  c.NamedIndividual.AbbreviatedIRI,
 				Name: util.CleanString(GetDataPropertyNameWithoutPrefix(c.NamedIndividual.AbbreviatedIRI)),
 			}
@@ -191,6 +294,30 @@ func Prepare(src *owl.Ontology, rootIRI string) *OntologyPrepared {
 		}
 	}
 
+	// Prepare enumerations, they are needed to determine the types of the relationships
+	preparedOntology.prepareEnums(src, &diags)
+	preparedOntology.prepareDatatypes(src)
+
+	// Prepare reference policies from the o2p:reference annotation
+	preparedOntology.prepareReferences(src)
+
+	// Prepare deprecated entities from the owl:deprecated annotation
+	preparedOntology.prepareDeprecations(src)
+
+	// Prepare modules from the o2p:module annotation
+	preparedOntology.prepareModules(src)
+
+	// Class expressions that are not supported are ignored, so the generated messages might lack properties or parents
+	for _, u := range src.Unsupported {
+		preparedOntology.Warnings.report(u.Axiom, NormalizedIRI(preparedOntology, &u.Class.Entity), u.Position,
+			"class expression %s is not supported and ignored", u.Expression)
+	}
+
+	// The root resource must be declared, otherwise no messages can be generated
+	if _, ok := preparedOntology.Resources[preparedOntology.RootResourceName]; !ok {
+		diags.report("Declaration", preparedOntology.RootResourceName, owl.Position{}, "root resource is not declared as class")
+	}
+
 	// Prepare SubClasses There are 5 different structures of SubClasses. All Class properties are IRIs:
 	//
 	//  * 2 Classes: The second Class is the parent of the first Class
@@ -205,40 +332,59 @@ func Prepare(src *owl.Ontology, rootIRI string) *OntologyPrepared {
 	//  * Class and ObjectHasValue: Class is the current resource and ObjectHasValue contains the ObjectProperty
 	//    IRI (e.g., "http://graph.clouditor.io/classes/scope") and a named individual
 	//    (e.g., "http://graph.clouditor.io/classes/resourceId")
+	//
+	// Additionally, cardinality restrictions (e.g., ObjectMaxCardinality) restrict the number of values of the
+	// relationships. They are applied afterwards in [OntologyPrepared.prepareCardinalities].
 	for _, sc := range src.SubClasses {
+		if len(sc.Class) == 0 {
+			continue
+		}
+
+		fromIri := NormalizedIRI(preparedOntology, &sc.Class[0].Entity)
+		if _, ok := preparedOntology.Resources[fromIri]; !ok {
+			diags.report("SubClassOf", fromIri, sc.Position, "sub-class is not declared as class")
+			continue
+		}
+
 		if len(sc.Class) == 2 {
-			iri := NormalizedIRI(preparedOntology, &sc.Class[0].Entity)
+			iri := fromIri
 			parentIri := NormalizedIRI(preparedOntology, &sc.Class[1].Entity)
 
 			// "owl#Thing" is the root of the ontology and is not needed for the protobuf files
 			if parentIri != "http://www.w3.org/2002/07/owl#Thing" {
-				// Create resource that has a parent. All resources directly under "owl.Thing" are already created before
-				// (via the Declarations)
-				r := &Resource{
-					Iri:     iri,
-					Name:    preparedOntology.Resources[iri].Name,
-					Parent:  parentIri,
-					Comment: preparedOntology.Resources[iri].Comment,
+				if _, ok := preparedOntology.Resources[parentIri]; !ok {
+					diags.report("SubClassOf", parentIri, sc.Position, "super-class is not declared as class")
+					continue
 				}
 
-				// Add subresources to the parent resource
-				if val, ok := preparedOntology.Resources[parentIri]; ok {
-					if val.SubResources == nil {
-						preparedOntology.Resources[parentIri].SubResources = make([]*Resource, 0)
-					}
-					preparedOntology.Resources[parentIri].SubResources = append(preparedOntology.Resources[parentIri].SubResources, r)
+				// A class can have several parents, but the same SubClassOf axiom might be stated several times, e.g., in
+				// imported ontologies
+				r := preparedOntology.Resources[iri]
+				if slices.Contains(r.Parents, parentIri) {
+					continue
 				}
 
-				// Add parent IRI to resource (not subresource!). We couldn't do this beforehand (Declarations) because we only get the information here,
-				preparedOntology.Resources[iri].Parent = parentIri
+				// Add the resource to the subresources of the parent resource. All resources are already created before
+				// (via the Declarations)
+				preparedOntology.Resources[parentIri].SubResources = append(preparedOntology.Resources[parentIri].SubResources, r)
+
+				// Add parent IRI to resource. We couldn't do this beforehand (Declarations) because we only get the
+				// information here
+				r.Parents = append(r.Parents, parentIri)
 			}
 		} else if sc.DataSomeValuesFrom != nil {
 			// Add data values, e.g. "enabled xsd:bool" ("enabled" is a data property and "xsd:bool" is a datatype) or
 			for _, v := range sc.DataSomeValuesFrom {
-				fromIri := NormalizedIRI(preparedOntology, &sc.Class[0].Entity)
 				var (
 					comment string
 				)
+
+				prop, ok := preparedOntology.AnnotationAssertion[NormalizedIRI(preparedOntology, &v.DataProperty.Entity)]
+				if !ok {
+					diags.report("DataSomeValuesFrom", NormalizedIRI(preparedOntology, &v.DataProperty.Entity), sc.Position, "data property is not declared")
+					continue
+				}
+
 				// Check if comment is available
 				if val, ok := preparedOntology.AnnotationAssertion[v.DataProperty.IRI]; ok {
 					comment = strings.Join(val.Comment[:], "\n\t ")
@@ -246,13 +392,17 @@ func Prepare(src *owl.Ontology, rootIRI string) *OntologyPrepared {
 					comment = strings.Join(val.Comment[:], "\n\t ")
 				}
 
+				typ, datatype, rules := preparedOntology.dataRange(v.Datatype, v.DatatypeRestriction, "DataSomeValuesFrom", sc.Position)
+
 				// Get DataProperty name
 				preparedOntology.Resources[fromIri].Relationship = append(preparedOntology.Resources[fromIri].Relationship, &Relationship{
-					IRI:     NormalizedIRI(preparedOntology, &v.DataProperty.Entity),
-					Typ:     util.GetProtoType(v.Datatype.AbbreviatedIRI),
-					Name:    preparedOntology.AnnotationAssertion[NormalizedIRI(preparedOntology, &v.DataProperty.Entity)].Name,
-					From:    fromIri,
-					Comment: comment,
+					IRI:      NormalizedIRI(preparedOntology, &v.DataProperty.Entity),
+					Typ:      typ,
+					Datatype: datatype,
+					Name:     prop.Name,
+					From:     fromIri,
+					Comment:  comment,
+					Rules:    rules,
 				})
 
 			}
@@ -263,20 +413,24 @@ func Prepare(src *owl.Ontology, rootIRI string) *OntologyPrepared {
 					comment string
 				)
 
-				fromIri := NormalizedIRI(preparedOntology, &sc.Class[0].Entity)
 				relationshipIri := NormalizedIRI(preparedOntology, &v.DataProperty.Entity)
 
-				// Check if comment is available
-				if val, ok := preparedOntology.AnnotationAssertion[relationshipIri]; ok {
-					comment = strings.Join(val.Comment[:], "\n\t ")
+				prop, ok := preparedOntology.AnnotationAssertion[relationshipIri]
+				if !ok {
+					diags.report("DataHasValue", relationshipIri, sc.Position, "data property is not declared")
+					continue
 				}
 
-				preparedOntology.Resources[fromIri].Relationship = append(preparedOntology.Resources[NormalizedIRI(preparedOntology, &sc.Class[0].Entity)].Relationship, &Relationship{
-					IRI:     relationshipIri,
-					Typ:     util.GetProtoType(v.Literal),
-					Name:    preparedOntology.AnnotationAssertion[NormalizedIRI(preparedOntology, &v.DataProperty.Entity)].Name,
-					From:    fromIri,
-					Comment: comment,
+				// Check if comment is available
+				comment = strings.Join(prop.Comment[:], "\n\t ")
+
+				preparedOntology.Resources[fromIri].Relationship = append(preparedOntology.Resources[fromIri].Relationship, &Relationship{
+					IRI:      relationshipIri,
+					Typ:      preparedOntology.protoType(v.Literal, "DataHasValue", sc.Position),
+					Datatype: preparedOntology.expandIRI(v.Literal),
+					Name:     prop.Name,
+					From:     fromIri,
+					Comment:  comment,
 				})
 
 			}
@@ -284,15 +438,23 @@ func Prepare(src *owl.Ontology, rootIRI string) *OntologyPrepared {
 			// Add object values, e.g., "offers ResourceLogging"
 			for _, v := range sc.ObjectSomeValuesFrom {
 				toIri := NormalizedIRI(preparedOntology, &v.Class.Entity)
-				fromIri := NormalizedIRI(preparedOntology, &sc.Class[0].Entity)
 				relationshipIri := NormalizedIRI(preparedOntology, &v.ObjectProperty.Entity)
 
+				to, ok := preparedOntology.Resources[toIri]
+				if !ok {
+					diags.report("ObjectSomeValuesFrom", toIri, sc.Position, "class is not declared")
+					continue
+				} else if _, ok = preparedOntology.AnnotationAssertion[relationshipIri]; !ok {
+					diags.report("ObjectSomeValuesFrom", relationshipIri, sc.Position, "object property is not declared")
+					continue
+				}
+
 				preparedOntology.Resources[fromIri].ObjectRelationship = append(preparedOntology.Resources[fromIri].ObjectRelationship, &ObjectRelationship{
 					From:               fromIri,
 					ObjectProperty:     relationshipIri,
 					ObjectPropertyName: preparedOntology.GetObjectPropertyIRIName(v.ObjectProperty),
 					To:                 toIri,
-					Name:               preparedOntology.Resources[toIri].Name,
+					Name:               to.Name,
 				})
 			}
 		} else if sc.ObjectHasValue != nil {
@@ -302,10 +464,15 @@ func Prepare(src *owl.Ontology, rootIRI string) *OntologyPrepared {
 					comment string
 				)
 
-				fromIri := NormalizedIRI(preparedOntology, &sc.Class[0].Entity)
 				relationshipIri := NormalizedIRI(preparedOntology, &v.ObjectProperty.Entity)
 				typeIri := NormalizedIRI(preparedOntology, &v.NamedIndividual.Entity)
 
+				individual, ok := preparedOntology.NamedIndividual[typeIri]
+				if !ok {
+					diags.report("ObjectHasValue", typeIri, sc.Position, "named individual is not declared")
+					continue
+				}
+
 				// Check if comment is available
 				if val, ok := preparedOntology.AnnotationAssertion[relationshipIri]; ok {
 					comment = strings.Join(val.Comment[:], "\n\t ")
@@ -313,7 +480,7 @@ func Prepare(src *owl.Ontology, rootIRI string) *OntologyPrepared {
 
 				preparedOntology.Resources[fromIri].Relationship = append(preparedOntology.Resources[fromIri].Relationship, &Relationship{
 					IRI:     relationshipIri,
-					Typ:     util.GetProtoType(preparedOntology.NamedIndividual[typeIri].Type),
+					Typ:     preparedOntology.protoType(individual.Type, "ObjectHasValue", sc.Position),
 					Name:    preparedOntology.GetObjectPropertyIRIName(v.ObjectProperty),
 					From:    fromIri,
 					Comment: comment,
@@ -322,7 +489,48 @@ func Prepare(src *owl.Ontology, rootIRI string) *OntologyPrepared {
 		}
 	}
 
-	return preparedOntology
+	preparedOntology.prepareCardinalities(src, &diags)
+	preparedOntology.prepareSubProperties(src, &diags)
+	preparedOntology.applyDeprecations()
+
+	if len(diags) > 0 {
+		return nil, diags
+	}
+
+	return preparedOntology, nil
+}
+
+// protoType returns the protobuf type of the ontology datatype according to the type map, which is the name of the
+// enum for enumerated datatypes. Protobuf scalar types are used as they are. Datatypes without a mapping are reported
+// as warning and represented as string.
+func (ont *OntologyPrepared) protoType(datatype string, axiom string, pos owl.Position) string {
+	if enum, ok := ont.Enums[ont.normalizeAbbreviatedIRI(datatype)]; ok {
+		return enum.Name
+	} else if isScalarType(datatype) {
+		return datatype
+	} else if datatype == "" {
+		ont.Warnings.report(axiom, datatype, pos, "unknown datatype")
+		return ""
+	}
+
+	typ, ok := ont.lookupType(datatype)
+	if !ok {
+		ont.Warnings.report(axiom, datatype, pos, "datatype has no type mapping, using string")
+		return "string"
+	}
+
+	return typ
+}
+
+// hasPrefix returns true if the prefix of the abbreviated IRI is declared.
+func (ont *OntologyPrepared) hasPrefix(iri string) bool {
+	prefix, _, found := strings.Cut(iri, ":")
+	if !found {
+		return false
+	}
+
+	_, ok := ont.Prefixes[prefix]
+	return ok
 }
 
 // GetDataPropertyIRIName return the existing IRI (IRI vs. abbreviatedIRI) from the Data Property
@@ -0,0 +1,175 @@
+package ontology
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oxisto/owl2proto/owl"
+)
+
+func TestPrepare(t *testing.T) {
+	var (
+		prefixes = []owl.Prefix{{Name: "ex", IRI: "http://example.com/cloud/"}}
+		resource = owl.Declaration{Class: owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Resource"}}}
+		storage  = owl.Declaration{Class: owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Storage"}}}
+		pos      = owl.Position{File: "cloud.owx", Line: 12, Column: 5}
+	)
+
+	type args struct {
+		src     *owl.Ontology
+		rootIRI string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantRoot *Resource
+		wantErr  error
+	}{
+		{
+			name: "Happy path",
+			args: args{
+				src: &owl.Ontology{
+					Prefixes:     prefixes,
+					Declarations: []owl.Declaration{resource, storage},
+					SubClasses: []owl.SubClassOf{
+						{Class: []owl.Class{{Entity: owl.Entity{AbbreviatedIRI: "ex:Storage"}}, {Entity: owl.Entity{AbbreviatedIRI: "ex:Resource"}}}},
+					},
+				},
+				rootIRI: "ex:Resource",
+			},
+			wantRoot: &Resource{
+				Iri:  "http://example.com/cloud/Resource",
+				Name: "Resource",
+				SubResources: []*Resource{
+					{Iri: "http://example.com/cloud/Storage", Name: "Storage", Parents: []string{"http://example.com/cloud/Resource"}},
+				},
+			},
+		},
+		{
+			name: "Dangling references",
+			args: args{
+				src: &owl.Ontology{
+					Prefixes:     prefixes,
+					Declarations: []owl.Declaration{resource, storage},
+					SubClasses: []owl.SubClassOf{
+						{
+							Class:    []owl.Class{{Entity: owl.Entity{AbbreviatedIRI: "ex:Compute"}}, {Entity: owl.Entity{AbbreviatedIRI: "ex:Resource"}}},
+							Position: pos,
+						},
+						{
+							Class: []owl.Class{{Entity: owl.Entity{AbbreviatedIRI: "ex:Storage"}}},
+							DataSomeValuesFrom: []owl.DataSomeValuesFrom{{
+								DataProperty: owl.DataProperty{Entity: owl.Entity{AbbreviatedIRI: "ex:name"}},
+								Datatype:     owl.Datatype{AbbreviatedIRI: "xsd:string"},
+							}},
+						},
+						{
+							Class: []owl.Class{{Entity: owl.Entity{AbbreviatedIRI: "ex:Storage"}}},
+							ObjectHasValue: []owl.ObjectHasValue{{
+								ObjectProperty:  owl.ObjectProperty{Entity: owl.Entity{AbbreviatedIRI: "ex:scope"}},
+								NamedIndividual: owl.NamedIndividual{Entity: owl.Entity{AbbreviatedIRI: "ex:resourceId"}},
+							}},
+						},
+					},
+				},
+				rootIRI: "ex:Thing",
+			},
+			wantErr: Diagnostics{
+				{Axiom: "Declaration", IRI: "http://example.com/cloud/Thing", Message: "root resource is not declared as class"},
+				{Axiom: "SubClassOf", IRI: "http://example.com/cloud/Compute", Message: "sub-class is not declared as class", Position: pos},
+				{Axiom: "DataSomeValuesFrom", IRI: "http://example.com/cloud/name", Message: "data property is not declared"},
+				{Axiom: "ObjectHasValue", IRI: "http://example.com/cloud/resourceId", Message: "named individual is not declared"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Prepare(tt.args.src, tt.args.rootIRI)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Prepare() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if root := got.Resources[got.RootResourceName]; !reflect.DeepEqual(root, tt.wantRoot) {
+				t.Errorf("Prepare() root = %v, want %v", root, tt.wantRoot)
+			}
+		})
+	}
+}
+
+func TestDiagnostic_String(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Diagnostic
+		want string
+	}{
+		{
+			name: "With position",
+			d: Diagnostic{
+				Axiom:    "SubClassOf",
+				IRI:      "http://example.com/cloud/Compute",
+				Message:  "sub-class is not declared as class",
+				Position: owl.Position{File: "cloud.owx", Line: 12, Column: 5},
+			},
+			want: "cloud.owx:12:5: SubClassOf: sub-class is not declared as class <http://example.com/cloud/Compute>",
+		},
+		{
+			name: "Without position",
+			d: Diagnostic{
+				Axiom:   "Declaration",
+				IRI:     "http://example.com/cloud/Thing",
+				Message: "root resource is not declared as class",
+			},
+			want: "Declaration: root resource is not declared as class <http://example.com/cloud/Thing>",
+		},
+		{
+			name: "Without IRI",
+			d: Diagnostic{
+				Axiom:    "SubClassOf",
+				Message:  "class expression ObjectUnionOf is not supported and ignored",
+				Position: owl.Position{File: "cloud.ofn", Line: 4, Column: 13},
+			},
+			want: "cloud.ofn:4:13: SubClassOf: class expression ObjectUnionOf is not supported and ignored",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.String(); got != tt.want {
+				t.Errorf("Diagnostic.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrepare_unsupported(t *testing.T) {
+	var (
+		resource = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Resource"}}
+		src      = &owl.Ontology{
+			Prefixes:     []owl.Prefix{{Name: "ex", IRI: "http://example.com/cloud/"}},
+			Declarations: []owl.Declaration{{Class: resource}},
+			Unsupported: []owl.Unsupported{{
+				Axiom:      "SubClassOf",
+				Class:      resource,
+				Expression: "ObjectIntersectionOf",
+				Position:   owl.Position{File: "cloud.ofn", Line: 3, Column: 24},
+			}},
+		}
+	)
+
+	prepared, err := Prepare(src, "ex:Resource")
+	if err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	wantWarnings := Diagnostics{{
+		Axiom:    "SubClassOf",
+		IRI:      "http://example.com/cloud/Resource",
+		Message:  "class expression ObjectIntersectionOf is not supported and ignored",
+		Position: owl.Position{File: "cloud.ofn", Line: 3, Column: 24},
+	}}
+	if !reflect.DeepEqual(prepared.Warnings, wantWarnings) {
+		t.Errorf("Prepare() warnings = %v, want %v", prepared.Warnings, wantWarnings)
+	}
+}
@@ -1,6 +1,7 @@
 package ontology
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/oxisto/owl2proto/internal/util"
@@ -11,29 +12,17 @@ func isResourceAboveX(resource *Resource, preparedOntology *OntologyPrepared, ro
 	if resource == nil {
 		return false
 	}
-	if resource.Parent == "" {
-		return false
-	}
-
-	if resource.Parent == rootResourceName {
-		return true
-	}
 
-	if isResourceAboveX(preparedOntology.Resources[resource.Parent], preparedOntology, rootResourceName) {
-		return true
-	}
-
-	return false
+	return slices.Contains(preparedOntology.Ancestors(resource.Iri), rootResourceName)
 }
 
-// GetNameFromIri gets the last part of the IRI
+// GetNameFromIri gets the last part of the IRI, i.e., the part after the last "/" or "#"
 func GetNameFromIri(s string) string {
 	if s == "" {
 		return ""
 	}
-	split := strings.Split(s, "/")
 
-	return split[len(split)-1]
+	return s[strings.LastIndexAny(s, "/#")+1:]
 }
 
 // GetDataPropertyNameWithoutPrefix returns the abbreviatedIRI name, e.g. "prop:enabled" returns "enabled"
@@ -47,36 +36,32 @@ func GetDataPropertyNameWithoutPrefix(s string) string {
 	return split[1]
 }
 
-// GetObjectDetail returns the object type
-func (ont *OntologyPrepared) GetObjectDetail(s string, resource *Resource) (rep, typ, name string) {
-	rName := resource.Name
-	switch s {
-	case "hasMultiple", "offersMultiple":
-		rep = util.Repeated
-	case "has", "runsOn", "offers", "storage":
-		rep = ""
-	case "to":
-		rep = util.Repeated
-	case "collectionOf":
+// GetObjectDetail returns the object type. Whether the target is embedded or referenced is determined by
+// [OntologyPrepared.ReferenceOf], whether the field is repeated is derived from the cardinality of the object
+// property.
+func (ont *OntologyPrepared) GetObjectDetail(o *ObjectRelationship) (rep, typ, name string) {
+	rName := o.Name
+
+	if o.Cardinality.Repeated() {
 		rep = util.Repeated
-	case "offersInterface":
-		rep = ""
-	case "proxyTarget":
-		return "string", "", rName
-	case "parent":
-		return "", "optional string", "parent_id"
-	default:
-		rep = ""
 	}
 
-	// If the object is a kind of the rootResourceName, the type is string and "_id" is added to the name to show that an ID is stored in the string.
-	if isResourceAboveX(resource, ont, ont.RootResourceName) {
-		// if the property is repeated, than use "ids"
-		if rep == "" {
-			return rep, "optional string", rName + "_id"
-		} else {
-			return rep, "string", rName + "_ids"
+	// Enumerations are always "embedded" as enum value
+	if _, ok := ont.Enums[o.To]; ok {
+		if rep == util.Repeated {
+			return rep, rName, util.ToPlural(rName)
 		}
+
+		return rep, rName, rName
+	}
+
+	switch ont.ReferenceOf(o) {
+	case ReferenceID:
+		// The type is string and "_id" is added to the name to show that an ID is stored in the string.
+		return referenceField(rep, rName, "_id", o.Cardinality)
+	case ReferenceIRI:
+		// The type is string and "_iri" is added to the name to show that an IRI is stored in the string.
+		return referenceField(rep, rName, "_iri", o.Cardinality)
 	}
 
 	// if the property is repeated add "s" to the name
@@ -88,3 +73,15 @@ func (ont *OntologyPrepared) GetObjectDetail(s string, resource *Resource) (rep,
 
 	return rep, rName, name
 }
+
+// referenceField returns a string field that references the target, e.g., "optional string storage_id". If the
+// property is repeated, the suffix is pluralized, e.g., "repeated string storage_ids".
+func referenceField(rep, rName, suffix string, cardinality *Cardinality) (string, string, string) {
+	if rep != "" {
+		return rep, "string", rName + suffix + "s"
+	} else if cardinality.Required() {
+		return rep, "string", rName + suffix
+	} else {
+		return rep, "optional string", rName + suffix
+	}
+}
@@ -56,6 +56,13 @@ func TestGetNameFromIri(t *testing.T) {
 			},
 			want: "Resource",
 		},
+		{
+			name: "Happy path: hash IRI",
+			args: args{
+				s: "https://example.com/cloud#Resource",
+			},
+			want: "Resource",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,152 @@
+package ontology
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oxisto/owl2proto/internal/util"
+	"github.com/oxisto/owl2proto/owl"
+)
+
+// ReferenceAnnotation is the annotation property that specifies how an object property or the instances of a class
+// are referenced, e.g., "o2p:reference" with the prefix o2p = <https://github.com/oxisto/owl2proto#>.
+const ReferenceAnnotation = "https://github.com/oxisto/owl2proto#reference"
+
+// Reference specifies how the target of an object property is represented in the generated message.
+type Reference string
+
+const (
+	// ReferenceEmbed embeds the target as message, e.g., "GeoLocation geo_location".
+	ReferenceEmbed Reference = "embed"
+
+	// ReferenceID references the target by its ID, e.g., "optional string block_storage_id".
+	ReferenceID Reference = "id"
+
+	// ReferenceIRI references the target by its IRI, e.g., "optional string block_storage_iri".
+	ReferenceIRI Reference = "iri"
+)
+
+// ParseReference parses a reference policy. Besides the names of the policies, the boolean values "true" (reference
+// by ID) and "false" (embed) are accepted.
+func ParseReference(s string) (Reference, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "embed", "false":
+		return ReferenceEmbed, nil
+	case "id", "true":
+		return ReferenceID, nil
+	case "iri":
+		return ReferenceIRI, nil
+	default:
+		return "", fmt.Errorf("invalid reference %q, expected embed, id, iri, true or false", s)
+	}
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler] so that reference policies are validated when reading a
+// [ReferenceConfig].
+func (r *Reference) UnmarshalText(text []byte) (err error) {
+	*r, err = ParseReference(string(text))
+	return err
+}
+
+// ReferenceConfig specifies the reference policies outside of the ontology. The keys are (abbreviated) IRIs of object
+// properties or classes. Policies of the configuration take precedence over annotations in the ontology.
+type ReferenceConfig struct {
+	Properties map[string]Reference `yaml:"properties" json:"properties"`
+	Classes    map[string]Reference `yaml:"classes" json:"classes"`
+}
+
+// ReadReferenceConfig reads a reference configuration in YAML or JSON format.
+func ReadReferenceConfig(path string) (config *ReferenceConfig, err error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config = new(ReferenceConfig)
+	err = yaml.Unmarshal(b, config)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse reference config: %w", err)
+	}
+
+	return config, nil
+}
+
+// prepareReferences collects the reference policies specified by the [ReferenceAnnotation]. Invalid values are
+// reported as warning.
+func (ont *OntologyPrepared) prepareReferences(src *owl.Ontology) {
+	for _, aa := range src.AnnotationAssertion {
+		if ont.annotationPropertyIRI(aa.AnnotationProperty) != ReferenceAnnotation {
+			continue
+		}
+
+		iri := NormalizedIRI(ont, aa)
+
+		ref, err := ParseReference(aa.Literal)
+		if err != nil {
+			ont.Warnings.report("AnnotationAssertion", iri, aa.Position, "%v", err)
+			continue
+		}
+
+		ont.References[iri] = ref
+	}
+}
+
+// ApplyReferenceConfig applies the reference policies of the configuration. Entries of the configuration override
+// annotations of the same property or class. Entries for unknown properties or classes are reported as warning.
+func (ont *OntologyPrepared) ApplyReferenceConfig(config *ReferenceConfig) {
+	if config == nil {
+		return
+	}
+
+	for _, key := range util.SortMapKeys(config.Properties) {
+		iri := ont.normalizeAbbreviatedIRI(key)
+		if _, ok := ont.AnnotationAssertion[iri]; !ok {
+			ont.Warnings.report("ReferenceConfig", key, owl.Position{}, "object property is not declared")
+		}
+
+		ont.References[iri] = config.Properties[key]
+	}
+
+	for _, key := range util.SortMapKeys(config.Classes) {
+		iri := ont.normalizeAbbreviatedIRI(key)
+		if _, ok := ont.Resources[iri]; !ok {
+			ont.Warnings.report("ReferenceConfig", key, owl.Position{}, "class is not declared")
+		}
+
+		ont.References[iri] = config.Classes[key]
+	}
+}
+
+// ReferenceOf returns how the target of the object relationship is represented. The policy of the object property
+// takes precedence over the policy of the target class or its super-classes. Without any policy, targets that are
+// a kind of the root resource are referenced by ID and all other targets are embedded.
+func (ont *OntologyPrepared) ReferenceOf(o *ObjectRelationship) Reference {
+	if ref, ok := ont.References[o.ObjectProperty]; ok {
+		return ref
+	}
+
+	// Walk up the class hierarchy of the target, the nearest class with a policy wins
+	for _, iri := range ont.linearize(o.To, false) {
+		if ref, ok := ont.References[iri]; ok {
+			return ref
+		}
+	}
+
+	if isResourceAboveX(ont.Resources[o.To], ont, ont.RootResourceName) {
+		return ReferenceID
+	}
+
+	return ReferenceEmbed
+}
+
+// annotationPropertyIRI returns the full IRI of the annotation property.
+func (ont *OntologyPrepared) annotationPropertyIRI(p owl.AnnotationProperty) string {
+	if p.IRI != "" {
+		return p.IRI
+	}
+
+	return ont.normalizeAbbreviatedIRI(p.AbbreviatedIRI)
+}
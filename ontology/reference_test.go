@@ -0,0 +1,232 @@
+package ontology
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/oxisto/owl2proto/owl"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Reference
+		wantErr bool
+	}{
+		{name: "Embed", s: "embed", want: ReferenceEmbed},
+		{name: "IRI", s: "IRI", want: ReferenceIRI},
+		{name: "Boolean true", s: "true", want: ReferenceID},
+		{name: "Boolean false", s: "false", want: ReferenceEmbed},
+		{name: "Invalid", s: "pointer", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseReference(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseReference() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadReferenceConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		want    *ReferenceConfig
+		wantErr bool
+	}{
+		{
+			name:    "YAML",
+			content: "properties:\n  ex:has: iri\nclasses:\n  ex:Storage: embed\n",
+			want: &ReferenceConfig{
+				Properties: map[string]Reference{"ex:has": ReferenceIRI},
+				Classes:    map[string]Reference{"ex:Storage": ReferenceEmbed},
+			},
+		},
+		{
+			name:    "JSON",
+			content: `{"classes": {"ex:Storage": "true"}}`,
+			want: &ReferenceConfig{
+				Classes: map[string]Reference{"ex:Storage": ReferenceID},
+			},
+		},
+		{
+			name:    "Invalid reference",
+			content: "properties:\n  ex:has: pointer\n",
+			wantErr: true,
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, string(rune('a'+i))+".yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ReadReferenceConfig(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadReferenceConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadReferenceConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOntologyPrepared_GetObjectDetail(t *testing.T) {
+	var (
+		resource = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Resource"}}
+		vm       = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:VirtualMachine"}}
+		storage  = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:BlockStorage"}}
+		location = owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:GeoLocation"}}
+		has      = owl.ObjectProperty{Entity: owl.Entity{AbbreviatedIRI: "ex:has"}}
+		src      = &owl.Ontology{
+			Prefixes: []owl.Prefix{
+				{Name: "ex", IRI: "http://example.com/cloud/"},
+				{Name: "o2p", IRI: "https://github.com/oxisto/owl2proto#"},
+			},
+			Declarations: []owl.Declaration{{Class: resource}, {Class: vm}, {Class: storage}, {Class: location}, {ObjectProperty: has}},
+			SubClasses: []owl.SubClassOf{
+				{Class: []owl.Class{vm, resource}},
+				{Class: []owl.Class{storage, resource}},
+			},
+		}
+	)
+
+	type args struct {
+		to          string
+		cardinality *Cardinality
+	}
+	tests := []struct {
+		name        string
+		annotations []owl.AnnotationAssertion
+		config      *ReferenceConfig
+		args        args
+		wantRep     string
+		wantTyp     string
+		wantName    string
+	}{
+		{
+			name:     "Default: resource is referenced by ID",
+			args:     args{to: "http://example.com/cloud/BlockStorage"},
+			wantTyp:  "optional string",
+			wantName: "BlockStorage_id",
+		},
+		{
+			name:     "Default: other classes are embedded",
+			args:     args{to: "http://example.com/cloud/GeoLocation", cardinality: &Cardinality{Min: 0, Max: Unbounded}},
+			wantRep:  "repeated ",
+			wantTyp:  "GeoLocation",
+			wantName: "GeoLocations",
+		},
+		{
+			name: "Class annotation is inherited",
+			annotations: []owl.AnnotationAssertion{{
+				AnnotationProperty: owl.AnnotationProperty{AbbreviatedIRI: "o2p:reference"},
+				AbbreviatedIRI:     "ex:Resource",
+				Literal:            "iri",
+			}},
+			args:     args{to: "http://example.com/cloud/BlockStorage", cardinality: &Cardinality{Min: 1, Max: 1}},
+			wantTyp:  "string",
+			wantName: "BlockStorage_iri",
+		},
+		{
+			name: "Property annotation takes precedence",
+			annotations: []owl.AnnotationAssertion{
+				{
+					AnnotationProperty: owl.AnnotationProperty{IRI: ReferenceAnnotation},
+					AbbreviatedIRI:     "ex:has",
+					Literal:            "false",
+				},
+				{
+					AnnotationProperty: owl.AnnotationProperty{AbbreviatedIRI: "o2p:reference"},
+					AbbreviatedIRI:     "ex:BlockStorage",
+					Literal:            "id",
+				},
+			},
+			args:     args{to: "http://example.com/cloud/BlockStorage"},
+			wantTyp:  "BlockStorage",
+			wantName: "BlockStorage",
+		},
+		{
+			name: "Config overrides annotation",
+			annotations: []owl.AnnotationAssertion{{
+				AnnotationProperty: owl.AnnotationProperty{AbbreviatedIRI: "o2p:reference"},
+				AbbreviatedIRI:     "ex:GeoLocation",
+				Literal:            "embed",
+			}},
+			config:   &ReferenceConfig{Classes: map[string]Reference{"ex:GeoLocation": ReferenceID}},
+			args:     args{to: "http://example.com/cloud/GeoLocation", cardinality: &Cardinality{Min: 1, Max: Unbounded}},
+			wantRep:  "repeated ",
+			wantTyp:  "string",
+			wantName: "GeoLocation_ids",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ont := *src
+			ont.AnnotationAssertion = tt.annotations
+
+			prepared, err := Prepare(&ont, "ex:Resource")
+			if err != nil {
+				t.Fatalf("Prepare() error = %v", err)
+			}
+			prepared.ApplyReferenceConfig(tt.config)
+
+			gotRep, gotTyp, gotName := prepared.GetObjectDetail(&ObjectRelationship{
+				ObjectProperty: "http://example.com/cloud/has",
+				From:           "http://example.com/cloud/VirtualMachine",
+				To:             tt.args.to,
+				Name:           prepared.Resources[tt.args.to].Name,
+				Cardinality:    tt.args.cardinality,
+			})
+			if gotRep != tt.wantRep {
+				t.Errorf("GetObjectDetail() rep = %v, want %v", gotRep, tt.wantRep)
+			}
+			if gotTyp != tt.wantTyp {
+				t.Errorf("GetObjectDetail() typ = %v, want %v", gotTyp, tt.wantTyp)
+			}
+			if gotName != tt.wantName {
+				t.Errorf("GetObjectDetail() name = %v, want %v", gotName, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestOntologyPrepared_ApplyReferenceConfig(t *testing.T) {
+	prepared, err := Prepare(&owl.Ontology{
+		Prefixes:     []owl.Prefix{{Name: "ex", IRI: "http://example.com/cloud/"}},
+		Declarations: []owl.Declaration{{Class: owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Resource"}}}},
+	}, "ex:Resource")
+	if err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	prepared.ApplyReferenceConfig(&ReferenceConfig{
+		Properties: map[string]Reference{"ex:unknown": ReferenceIRI},
+		Classes:    map[string]Reference{"ex:Resource": ReferenceID},
+	})
+
+	want := map[string]Reference{
+		"http://example.com/cloud/unknown":  ReferenceIRI,
+		"http://example.com/cloud/Resource": ReferenceID,
+	}
+	if !reflect.DeepEqual(prepared.References, want) {
+		t.Errorf("ApplyReferenceConfig() references = %v, want %v", prepared.References, want)
+	}
+	if len(prepared.Warnings) != 1 {
+		t.Errorf("ApplyReferenceConfig() warnings = %v, want 1 warning", prepared.Warnings)
+	}
+}
@@ -0,0 +1,87 @@
+package ontology
+
+import (
+	"github.com/oxisto/owl2proto/owl"
+)
+
+const (
+	// TopObjectProperty is the object property that is the super-property of all object properties
+	TopObjectProperty = "http://www.w3.org/2002/07/owl#topObjectProperty"
+
+	// TopDataProperty is the data property that is the super-property of all data properties
+	TopDataProperty = "http://www.w3.org/2002/07/owl#topDataProperty"
+)
+
+// prepareSubProperties collects the direct super-properties of all object and data properties from the
+// SubObjectPropertyOf and SubDataPropertyOf axioms. The top properties are implied and therefore not stored.
+func (ont *OntologyPrepared) prepareSubProperties(src *owl.Ontology, diags *Diagnostics) {
+	for _, sp := range src.SubObjectProperties {
+		if len(sp.ObjectProperty) != 2 {
+			continue
+		}
+
+		ont.addSuperProperty("SubObjectPropertyOf", NormalizedIRI(ont, sp.ObjectProperty[0]),
+			NormalizedIRI(ont, sp.ObjectProperty[1]), TopObjectProperty, sp.Position, diags)
+	}
+
+	for _, sp := range src.SubDataProperties {
+		if len(sp.DataProperty) != 2 {
+			continue
+		}
+
+		ont.addSuperProperty("SubDataPropertyOf", NormalizedIRI(ont, sp.DataProperty[0]),
+			NormalizedIRI(ont, sp.DataProperty[1]), TopDataProperty, sp.Position, diags)
+	}
+}
+
+// addSuperProperty adds super as direct super-property of sub. Both properties must be declared.
+func (ont *OntologyPrepared) addSuperProperty(axiom string, sub string, super string, top string, pos owl.Position, diags *Diagnostics) {
+	if _, ok := ont.AnnotationAssertion[sub]; !ok {
+		diags.report(axiom, sub, pos, "sub-property is not declared")
+		return
+	}
+
+	if super == top {
+		return
+	}
+
+	if _, ok := ont.AnnotationAssertion[super]; !ok {
+		diags.report(axiom, super, pos, "super-property is not declared")
+		return
+	}
+
+	for _, p := range ont.SuperProperties[sub] {
+		if p == super {
+			return
+		}
+	}
+
+	ont.SuperProperties[sub] = append(ont.SuperProperties[sub], super)
+}
+
+// PropertyAncestors returns the IRIs of all super-properties of the property, ordered from the direct
+// super-properties to the most general ones. The implied top property is not included. Each property is only
+// contained once, even if it is reachable in several ways or the hierarchy contains cycles.
+func (ont *OntologyPrepared) PropertyAncestors(iri string) (ancestors []string) {
+	var (
+		visited = map[string]bool{iri: true}
+		queue   = []string{iri}
+	)
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, super := range ont.SuperProperties[current] {
+			if visited[super] {
+				continue
+			}
+
+			visited[super] = true
+			ancestors = append(ancestors, super)
+			queue = append(queue, super)
+		}
+	}
+
+	return ancestors
+}
@@ -0,0 +1,115 @@
+package ontology
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/oxisto/owl2proto/owl"
+)
+
+func TestOntologyPrepared_PropertyAncestors(t *testing.T) {
+	var (
+		objectProperty = func(iri string) owl.ObjectProperty {
+			return owl.ObjectProperty{Entity: owl.Entity{AbbreviatedIRI: iri}}
+		}
+		dataProperty = func(iri string) owl.DataProperty {
+			return owl.DataProperty{Entity: owl.Entity{AbbreviatedIRI: iri}}
+		}
+		src = &owl.Ontology{
+			Prefixes: []owl.Prefix{
+				{Name: "ex", IRI: "http://example.com/cloud/"},
+				{Name: "owl", IRI: "http://www.w3.org/2002/07/owl#"},
+			},
+			Declarations: []owl.Declaration{
+				{Class: owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Resource"}}},
+				{ObjectProperty: objectProperty("ex:has")},
+				{ObjectProperty: objectProperty("ex:hasMultiple")},
+				{ObjectProperty: objectProperty("ex:contains")},
+				{ObjectProperty: objectProperty("ex:hasStorage")},
+				{DataProperty: dataProperty("ex:name")},
+				{DataProperty: dataProperty("ex:displayName")},
+			},
+			SubObjectProperties: []owl.SubObjectPropertyOf{
+				{ObjectProperty: []owl.ObjectProperty{objectProperty("ex:has"), objectProperty("owl:topObjectProperty")}},
+				{ObjectProperty: []owl.ObjectProperty{objectProperty("ex:hasMultiple"), objectProperty("ex:has")}},
+				{ObjectProperty: []owl.ObjectProperty{objectProperty("ex:contains"), objectProperty("ex:has")}},
+				{ObjectProperty: []owl.ObjectProperty{objectProperty("ex:hasStorage"), objectProperty("ex:hasMultiple")}},
+				{ObjectProperty: []owl.ObjectProperty{objectProperty("ex:hasStorage"), objectProperty("ex:contains")}},
+			},
+			SubDataProperties: []owl.SubDataPropertyOf{
+				{DataProperty: []owl.DataProperty{dataProperty("ex:displayName"), dataProperty("ex:name")}},
+			},
+		}
+	)
+
+	prepared, err := Prepare(src, "ex:Resource")
+	if err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		iri  string
+		want []string
+	}{
+		{
+			name: "No super-property",
+			iri:  "http://example.com/cloud/has",
+		},
+		{
+			name: "Chain",
+			iri:  "http://example.com/cloud/hasMultiple",
+			want: []string{"http://example.com/cloud/has"},
+		},
+		{
+			name: "Several super-properties",
+			iri:  "http://example.com/cloud/hasStorage",
+			want: []string{
+				"http://example.com/cloud/hasMultiple",
+				"http://example.com/cloud/contains",
+				"http://example.com/cloud/has",
+			},
+		},
+		{
+			name: "Data property",
+			iri:  "http://example.com/cloud/displayName",
+			want: []string{"http://example.com/cloud/name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prepared.PropertyAncestors(tt.iri); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OntologyPrepared.PropertyAncestors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrepare_subPropertiesNotDeclared(t *testing.T) {
+	pos := owl.Position{File: "cloud.owx", Line: 7, Column: 3}
+
+	_, err := Prepare(&owl.Ontology{
+		Prefixes: []owl.Prefix{{Name: "ex", IRI: "http://example.com/cloud/"}},
+		Declarations: []owl.Declaration{
+			{Class: owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Resource"}}},
+			{ObjectProperty: owl.ObjectProperty{Entity: owl.Entity{AbbreviatedIRI: "ex:hasMultiple"}}},
+		},
+		SubObjectProperties: []owl.SubObjectPropertyOf{{
+			ObjectProperty: []owl.ObjectProperty{
+				{Entity: owl.Entity{AbbreviatedIRI: "ex:hasMultiple"}},
+				{Entity: owl.Entity{AbbreviatedIRI: "ex:has"}},
+			},
+			Position: pos,
+		}},
+	}, "ex:Resource")
+
+	want := Diagnostics{{
+		Axiom:    "SubObjectPropertyOf",
+		IRI:      "http://example.com/cloud/has",
+		Message:  "super-property is not declared",
+		Position: pos,
+	}}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("Prepare() error = %v, want %v", err, want)
+	}
+}
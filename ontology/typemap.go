@@ -0,0 +1,196 @@
+package ontology
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oxisto/owl2proto/internal/util"
+	"github.com/oxisto/owl2proto/rdf"
+)
+
+// TypeMapping is the protobuf type of a datatype together with the file that needs to be imported to use it, e.g.,
+// "google.protobuf.Timestamp" and "google/protobuf/timestamp.proto".
+type TypeMapping struct {
+	Type   string `yaml:"type" json:"type"`
+	Import string `yaml:"import,omitempty" json:"import,omitempty"`
+}
+
+// UnmarshalYAML decodes a type mapping, which is either an object with type and import or only the type as string.
+func (m *TypeMapping) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		m.Type = value.Value
+		return nil
+	}
+
+	type plain TypeMapping
+	return value.Decode((*plain)(m))
+}
+
+// TypeMap maps datatypes to protobuf types. The keys are the IRIs of the datatypes, either full or abbreviated.
+type TypeMap map[string]TypeMapping
+
+// DefaultTypeMap returns the built-in type mapping of the OWL 2 and XML schema datatypes.
+func DefaultTypeMap() TypeMap {
+	var (
+		xsd       = rdf.NamespaceXSD
+		timestamp = TypeMapping{Type: "google.protobuf.Timestamp", Import: "google/protobuf/timestamp.proto"}
+		duration  = TypeMapping{Type: "google.protobuf.Duration", Import: "google/protobuf/duration.proto"}
+		m         = TypeMap{
+			xsd + "boolean":               {Type: "bool"},
+			xsd + "decimal":               {Type: "double"},
+			xsd + "double":                {Type: "double"},
+			xsd + "float":                 {Type: "float"},
+			xsd + "integer":               {Type: "int32"},
+			xsd + "int":                   {Type: "int32"},
+			xsd + "short":                 {Type: "int32"},
+			xsd + "byte":                  {Type: "int32"},
+			xsd + "long":                  {Type: "int64"},
+			xsd + "nonPositiveInteger":    {Type: "int32"},
+			xsd + "negativeInteger":       {Type: "int32"},
+			xsd + "nonNegativeInteger":    {Type: "uint32"},
+			xsd + "positiveInteger":       {Type: "uint32"},
+			xsd + "unsignedInt":           {Type: "uint32"},
+			xsd + "unsignedShort":         {Type: "uint32"},
+			xsd + "unsignedByte":          {Type: "uint32"},
+			xsd + "unsignedLong":          {Type: "uint64"},
+			xsd + "base64Binary":          {Type: "bytes"},
+			xsd + "hexBinary":             {Type: "bytes"},
+			xsd + "dateTime":              timestamp,
+			xsd + "dateTimeStamp":         timestamp,
+			xsd + "duration":              duration,
+			xsd + "dayTimeDuration":       duration,
+			rdf.NamespaceOWL + "real":     {Type: "double"},
+			rdf.NamespaceOWL + "rational": {Type: "double"},
+
+			// Legacy pseudo types of the Clouditor ontology
+			xsd + "String":                        {Type: "string"},
+			xsd + "Short":                         {Type: "uint32"},
+			xsd + "listString":                    {Type: "repeated string"},
+			xsd + "java.util.ArrayList<String>":   {Type: "repeated string"},
+			xsd + "java.util.ArrayList<Short>":    {Type: "repeated uint32"},
+			xsd + "java.util.Map<String, String>": {Type: "map<string, string>"},
+			xsd + "java.time.Duration":            duration,
+			xsd + "java.time.ZonedDateTime":       timestamp,
+		}
+	)
+
+	// Strings and all datatypes without an equivalent protobuf type are represented by their lexical form
+	for _, s := range []string{
+		xsd + "string", xsd + "normalizedString", xsd + "token", xsd + "language", xsd + "Name", xsd + "NCName",
+		xsd + "NMTOKEN", xsd + "anyURI", xsd + "date", xsd + "time", xsd + "gYear", xsd + "gYearMonth",
+		xsd + "gMonth", xsd + "gMonthDay", xsd + "gDay", xsd + "yearMonthDuration", rdf.NamespaceRDF + "PlainLiteral",
+		rdf.NamespaceRDF + "langString", rdf.NamespaceRDF + "XMLLiteral", rdf.NamespaceRDFS + "Literal",
+	} {
+		m[s] = TypeMapping{Type: "string"}
+	}
+
+	return m
+}
+
+// ReadTypeMap reads a type map from a YAML or JSON file. Each entry maps a datatype either to a protobuf type or to
+// an object containing the type and the file that needs to be imported for it.
+func ReadTypeMap(path string) (m TypeMap, err error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read type map: %w", err)
+	}
+
+	// JSON is a subset of YAML, so we can parse both formats with the YAML decoder
+	err = yaml.Unmarshal(b, &m)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse type map: %w", err)
+	}
+
+	for datatype, mapping := range m {
+		if strings.TrimSpace(mapping.Type) == "" {
+			return nil, fmt.Errorf("type map entry %s has no type", datatype)
+		}
+	}
+
+	return m, nil
+}
+
+// prepareTypeMap merges the type map into the default type map. The keys are normalized to full IRIs.
+func (ont *OntologyPrepared) prepareTypeMap(types TypeMap) {
+	ont.TypeMap = DefaultTypeMap()
+
+	for datatype, mapping := range types {
+		ont.TypeMap[ont.expandIRI(datatype)] = mapping
+	}
+}
+
+// wellKnownImports contains the files of the well-known types of protobuf, indexed by the type.
+var wellKnownImports = map[string]string{
+	"google.protobuf.Any":         "google/protobuf/any.proto",
+	"google.protobuf.Duration":    "google/protobuf/duration.proto",
+	"google.protobuf.Empty":       "google/protobuf/empty.proto",
+	"google.protobuf.FieldMask":   "google/protobuf/field_mask.proto",
+	"google.protobuf.Struct":      "google/protobuf/struct.proto",
+	"google.protobuf.Value":       "google/protobuf/struct.proto",
+	"google.protobuf.ListValue":   "google/protobuf/struct.proto",
+	"google.protobuf.Timestamp":   "google/protobuf/timestamp.proto",
+	"google.protobuf.BoolValue":   "google/protobuf/wrappers.proto",
+	"google.protobuf.BytesValue":  "google/protobuf/wrappers.proto",
+	"google.protobuf.DoubleValue": "google/protobuf/wrappers.proto",
+	"google.protobuf.FloatValue":  "google/protobuf/wrappers.proto",
+	"google.protobuf.Int32Value":  "google/protobuf/wrappers.proto",
+	"google.protobuf.Int64Value":  "google/protobuf/wrappers.proto",
+	"google.protobuf.StringValue": "google/protobuf/wrappers.proto",
+	"google.protobuf.UInt32Value": "google/protobuf/wrappers.proto",
+	"google.protobuf.UInt64Value": "google/protobuf/wrappers.proto",
+}
+
+// lookupType returns the protobuf type of the datatype according to the type map.
+func (ont *OntologyPrepared) lookupType(datatype string) (typ string, ok bool) {
+	m, ok := ont.TypeMap[ont.expandIRI(datatype)]
+	if !ok {
+		return "", false
+	}
+
+	return m.Type, true
+}
+
+// ImportOf returns the file that needs to be imported to use the protobuf type, which is either specified in the
+// type map or the file of a well-known type. Types that do not need an import, e.g., scalar types, return an empty
+// string.
+func (ont *OntologyPrepared) ImportOf(typ string) string {
+	typ = strings.TrimPrefix(typ, "repeated ")
+
+	for _, datatype := range util.SortMapKeys(ont.TypeMap) {
+		if m := ont.TypeMap[datatype]; m.Type == typ && m.Import != "" {
+			return m.Import
+		}
+	}
+
+	return wellKnownImports[typ]
+}
+
+// expandIRI returns the full IRI of an abbreviated IRI whose prefix is either declared in the ontology or well-known.
+// Other IRIs are returned unchanged.
+func (ont *OntologyPrepared) expandIRI(iri string) string {
+	if full := ont.normalizeAbbreviatedIRI(iri); full != iri {
+		return full
+	}
+
+	prefix, name, found := strings.Cut(iri, ":")
+	if ns, ok := rdf.WellKnownPrefixes[prefix]; found && ok {
+		return ns + name
+	}
+
+	return iri
+}
+
+// isScalarType returns true if the type is a protobuf scalar type. These can be used as datatype in the ontology
+// directly.
+func isScalarType(typ string) bool {
+	switch typ {
+	case "double", "float", "int32", "int64", "uint32", "uint64", "sint32", "sint64", "fixed32", "fixed64", "sfixed32",
+		"sfixed64", "bool", "string", "bytes":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,135 @@
+package ontology
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/oxisto/owl2proto/owl"
+)
+
+func TestOntologyPrepared_protoType(t *testing.T) {
+	tests := []struct {
+		name         string
+		types        TypeMap
+		datatype     string
+		want         string
+		wantImport   string
+		wantWarnings int
+	}{
+		{name: "Boolean", datatype: "xsd:boolean", want: "bool"},
+		{name: "Legacy string", datatype: "xsd:String", want: "string"},
+		{name: "Legacy list of strings", datatype: "xsd:listString", want: "repeated string"},
+		{name: "Integer", datatype: "xsd:int", want: "int32"},
+		{name: "Long", datatype: "xsd:long", want: "int64"},
+		{name: "Legacy short", datatype: "xsd:Short", want: "uint32"},
+		{name: "Float", datatype: "xsd:float", want: "float"},
+		{name: "URI", datatype: "xsd:anyURI", want: "string"},
+		{name: "Binary", datatype: "http://www.w3.org/2001/XMLSchema#base64Binary", want: "bytes"},
+		{
+			name:       "Legacy duration",
+			datatype:   "xsd:java.time.Duration",
+			want:       "google.protobuf.Duration",
+			wantImport: "google/protobuf/duration.proto",
+		},
+		{
+			name:       "Timestamp",
+			datatype:   "xsd:dateTime",
+			want:       "google.protobuf.Timestamp",
+			wantImport: "google/protobuf/timestamp.proto",
+		},
+		{name: "Legacy list of shorts", datatype: "xsd:java.util.ArrayList<Short>", want: "repeated uint32"},
+		{name: "Legacy map", datatype: "xsd:java.util.Map<String, String>", want: "map<string, string>"},
+		{name: "Protobuf scalar type", datatype: "int64", want: "int64"},
+		{name: "Unknown datatype", datatype: "ex:Money", want: "string", wantWarnings: 1},
+		{
+			name:       "Custom mapping",
+			types:      TypeMap{"http://example.com/cloud/Money": {Type: "google.type.Money", Import: "google/type/money.proto"}},
+			datatype:   "ex:Money",
+			want:       "google.type.Money",
+			wantImport: "google/type/money.proto",
+		},
+		{
+			name:       "Custom mapping to well-known type",
+			types:      TypeMap{"ex:Config": {Type: "google.protobuf.Struct"}},
+			datatype:   "ex:Config",
+			want:       "google.protobuf.Struct",
+			wantImport: "google/protobuf/struct.proto",
+		},
+		{
+			name:     "Custom mapping overrides default",
+			types:    TypeMap{"xsd:decimal": {Type: "string"}},
+			datatype: "xsd:decimal",
+			want:     "string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prepared, err := PrepareWithTypeMap(&owl.Ontology{
+				Prefixes:     []owl.Prefix{{Name: "ex", IRI: "http://example.com/cloud/"}},
+				Declarations: []owl.Declaration{{Class: owl.Class{Entity: owl.Entity{AbbreviatedIRI: "ex:Resource"}}}},
+			}, "ex:Resource", tt.types)
+			if err != nil {
+				t.Fatalf("PrepareWithTypeMap() error = %v", err)
+			}
+
+			if got := prepared.protoType(tt.datatype, "DataSomeValuesFrom", owl.Position{}); got != tt.want {
+				t.Errorf("OntologyPrepared.protoType() = %v, want %v", got, tt.want)
+			}
+			if got := prepared.ImportOf(tt.want); got != tt.wantImport {
+				t.Errorf("OntologyPrepared.ImportOf() = %v, want %v", got, tt.wantImport)
+			}
+			if len(prepared.Warnings) != tt.wantWarnings {
+				t.Errorf("OntologyPrepared.protoType() warnings = %v, want %d", prepared.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestReadTypeMap(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		want    TypeMap
+		wantErr bool
+	}{
+		{
+			name:    "YAML",
+			content: "xsd:decimal: string\nex:Money:\n  type: google.type.Money\n  import: google/type/money.proto\n",
+			want: TypeMap{
+				"xsd:decimal": {Type: "string"},
+				"ex:Money":    {Type: "google.type.Money", Import: "google/type/money.proto"},
+			},
+		},
+		{
+			name:    "JSON",
+			content: `{"http://www.w3.org/2001/XMLSchema#decimal": {"type": "double"}}`,
+			want:    TypeMap{"http://www.w3.org/2001/XMLSchema#decimal": {Type: "double"}},
+		},
+		{
+			name:    "Missing type",
+			content: "ex:Money:\n  import: google/type/money.proto\n",
+			wantErr: true,
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, string(rune('a'+i))+".yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ReadTypeMap(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadTypeMap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadTypeMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package owl
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/oxisto/owl2proto/rdf"
+)
+
+// Format is a serialization format of an ontology.
+type Format string
+
+const (
+	// FormatOWLXML is the OWL/XML serialization (.owx), see https://www.w3.org/TR/owl2-xml-serialization/
+	FormatOWLXML Format = "owlxml"
+
+	// FormatRDFXML is the RDF/XML serialization (.owl, .rdf), see https://www.w3.org/TR/rdf-syntax-grammar/
+	FormatRDFXML Format = "rdfxml"
+
+	// FormatTurtle is the Turtle serialization (.ttl), see https://www.w3.org/TR/turtle/
+	FormatTurtle Format = "turtle"
+
+	// FormatFunctional is the OWL 2 Functional-Style Syntax (.ofn), see https://www.w3.org/TR/owl2-syntax/
+	FormatFunctional Format = "functional"
+)
+
+// xmlStartRegexp matches the beginning of an XML document, i.e., an XML declaration, a comment, a DOCTYPE or a start
+// element. It does not match the beginning of a Turtle document that starts with an IRI, such as "<http://...>".
+var xmlStartRegexp = regexp.MustCompile(`^(<\?xml|<!|<[A-Za-z_][\w.-]*(:[A-Za-z_][\w.-]*)?[\s/>])`)
+
+// functionalStartRegexp matches the beginning of a document in the OWL 2 Functional-Style Syntax.
+var functionalStartRegexp = regexp.MustCompile(`^(Prefix|Ontology)\s*\(`)
+
+// FormatFromExtension returns the serialization format that is implied by the extension of the file. Since the ".owl"
+// extension is used for different formats, it does not imply a format.
+func FormatFromExtension(path string) (format Format, ok bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".owx":
+		return FormatOWLXML, true
+	case ".rdf":
+		return FormatRDFXML, true
+	case ".ttl":
+		return FormatTurtle, true
+	case ".ofn":
+		return FormatFunctional, true
+	default:
+		return "", false
+	}
+}
+
+// DetectFormat detects the serialization format of an ontology based on its content. Content that neither looks like
+// XML nor like the Functional-Style Syntax is assumed to be [FormatTurtle]. XML content is assumed to be
+// [FormatOWLXML], unless its root element is rdf:RDF.
+func DetectFormat(b []byte) Format {
+	trimmed := skipComments(bytes.TrimLeft(b, "\ufeff \t\r\n"))
+
+	if functionalStartRegexp.Match(trimmed) {
+		return FormatFunctional
+	} else if !xmlStartRegexp.Match(trimmed) {
+		// Anything that does not look like XML is treated as Turtle
+		return FormatTurtle
+	}
+
+	d := xml.NewDecoder(bytes.NewReader(b))
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return FormatOWLXML
+		}
+
+		if start, ok := tok.(xml.StartElement); ok {
+			if start.Name.Space == rdf.NamespaceRDF && start.Name.Local == "RDF" {
+				return FormatRDFXML
+			}
+
+			return FormatOWLXML
+		}
+	}
+}
+
+// Unmarshal decodes an ontology in the given serialization format.
+func Unmarshal(b []byte, format Format) (ont *Ontology, err error) {
+	switch format {
+	case FormatOWLXML:
+		ont = new(Ontology)
+
+		err = xml.Unmarshal(b, ont)
+		if err != nil {
+			return nil, fmt.Errorf("error while un-marshalling OWL/XML: %w", err)
+		}
+
+		return ont, nil
+	case FormatRDFXML:
+		g, err := rdf.ParseRDFXML(bytes.NewReader(b), "")
+		if err != nil {
+			return nil, fmt.Errorf("error while un-marshalling RDF/XML: %w", err)
+		}
+
+		return FromGraph(g), nil
+	case FormatTurtle:
+		g, err := rdf.ParseTurtle(bytes.NewReader(b), "")
+		if err != nil {
+			return nil, fmt.Errorf("error while un-marshalling Turtle: %w", err)
+		}
+
+		return FromGraph(g), nil
+	case FormatFunctional:
+		return UnmarshalFunctional(b)
+	default:
+		return nil, fmt.Errorf("unsupported ontology format %q", format)
+	}
+}
+
+// skipComments skips leading line comments (starting with "#"), which are allowed in Turtle and the Functional-Style
+// Syntax.
+func skipComments(b []byte) []byte {
+	for bytes.HasPrefix(b, []byte("#")) {
+		_, rest, found := bytes.Cut(b, []byte("\n"))
+		if !found {
+			return nil
+		}
+
+		b = bytes.TrimLeft(rest, " \t\r\n")
+	}
+
+	return b
+}
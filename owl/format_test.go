@@ -0,0 +1,211 @@
+package owl
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	type args struct {
+		b []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		want Format
+	}{
+		{
+			name: "OWL/XML",
+			args: args{
+				b: []byte(`<?xml version="1.0"?><Ontology xmlns="http://www.w3.org/2002/07/owl#"></Ontology>`),
+			},
+			want: FormatOWLXML,
+		},
+		{
+			name: "RDF/XML",
+			args: args{
+				b: []byte(`<?xml version="1.0"?><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"></rdf:RDF>`),
+			},
+			want: FormatRDFXML,
+		},
+		{
+			name: "Turtle",
+			args: args{
+				b: []byte("@prefix ex: <http://example.com/cloud/> .\nex:Storage a owl:Class ."),
+			},
+			want: FormatTurtle,
+		},
+		{
+			name: "Functional-Style Syntax",
+			args: args{
+				b: []byte("# Generated\nPrefix(ex:=<http://example.com/cloud/>)\nOntology()"),
+			},
+			want: FormatFunctional,
+		},
+		{
+			name: "Turtle starting with an IRI",
+			args: args{
+				b: []byte("<http://example.com/cloud/Storage> a <http://www.w3.org/2002/07/owl#Class> ."),
+			},
+			want: FormatTurtle,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectFormat(tt.args.b); got != tt.want {
+				t.Errorf("DetectFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	b, err := os.ReadFile("../example/cloud.owl")
+	if err != nil {
+		t.Fatalf("could not read example: %v", err)
+	}
+
+	ont, err := Unmarshal(b, DetectFormat(b))
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if len(ont.Declarations) != 10 {
+		t.Errorf("Unmarshal() got %d declarations, want %d", len(ont.Declarations), 10)
+	}
+
+	want := SubClassOf{
+		Class: []Class{{Entity{IRI: "http://example.com/cloud/Resource"}}},
+		DataSomeValuesFrom: []DataSomeValuesFrom{
+			{
+				DataProperty: DataProperty{Entity{IRI: "http://example.com/cloud/name"}},
+				Datatype:     Datatype{AbbreviatedIRI: "xsd:string"},
+			},
+		},
+	}
+
+	var found bool
+	for _, sc := range ont.SubClasses {
+		if sc.Position.Line == 0 {
+			t.Errorf("Unmarshal() did not record position of %v", sc)
+		}
+
+		sc.Position = Position{}
+		if reflect.DeepEqual(sc, want) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Unmarshal() = %v, want to contain %v", ont.SubClasses, want)
+	}
+}
+
+func TestUnmarshal_subProperties(t *testing.T) {
+	ont, err := Unmarshal([]byte(`<?xml version="1.0"?>
+<Ontology xmlns="http://www.w3.org/2002/07/owl#">
+    <SubObjectPropertyOf>
+        <ObjectProperty abbreviatedIRI="ex:hasMultiple"/>
+        <ObjectProperty abbreviatedIRI="ex:has"/>
+    </SubObjectPropertyOf>
+    <SubDataPropertyOf>
+        <DataProperty IRI="http://example.com/cloud/displayName"/>
+        <DataProperty IRI="http://example.com/cloud/name"/>
+    </SubDataPropertyOf>
+</Ontology>`), FormatOWLXML)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	wantObject := []SubObjectPropertyOf{{
+		ObjectProperty: []ObjectProperty{{Entity{AbbreviatedIRI: "ex:hasMultiple"}}, {Entity{AbbreviatedIRI: "ex:has"}}},
+		Position:       Position{Line: 3, Column: 26},
+	}}
+	if !reflect.DeepEqual(ont.SubObjectProperties, wantObject) {
+		t.Errorf("Unmarshal() sub object properties = %+v, want %+v", ont.SubObjectProperties, wantObject)
+	}
+
+	wantData := []SubDataPropertyOf{{
+		DataProperty: []DataProperty{{Entity{IRI: "http://example.com/cloud/displayName"}}, {Entity{IRI: "http://example.com/cloud/name"}}},
+		Position:     Position{Line: 7, Column: 24},
+	}}
+	if !reflect.DeepEqual(ont.SubDataProperties, wantData) {
+		t.Errorf("Unmarshal() sub data properties = %+v, want %+v", ont.SubDataProperties, wantData)
+	}
+}
+
+func TestUnmarshal_enumerations(t *testing.T) {
+	ont, err := Unmarshal([]byte(`<?xml version="1.0"?>
+<Ontology xmlns="http://www.w3.org/2002/07/owl#">
+    <EquivalentClasses>
+        <Class abbreviatedIRI="ex:Severity"/>
+        <ObjectOneOf>
+            <NamedIndividual abbreviatedIRI="ex:High"/>
+            <NamedIndividual abbreviatedIRI="ex:Low"/>
+        </ObjectOneOf>
+    </EquivalentClasses>
+    <DatatypeDefinition>
+        <Datatype IRI="http://example.com/cloud/TLSVersion"/>
+        <DataOneOf>
+            <Literal>tls1.2</Literal>
+            <Literal>tls1.3</Literal>
+        </DataOneOf>
+    </DatatypeDefinition>
+</Ontology>`), FormatOWLXML)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	wantClasses := []EquivalentClasses{{
+		Class: []Class{{Entity{AbbreviatedIRI: "ex:Severity"}}},
+		ObjectOneOf: []ObjectOneOf{{NamedIndividual: []NamedIndividual{
+			{Entity{AbbreviatedIRI: "ex:High"}},
+			{Entity{AbbreviatedIRI: "ex:Low"}},
+		}}},
+		Position: Position{Line: 3, Column: 24},
+	}}
+	if !reflect.DeepEqual(ont.EquivalentClasses, wantClasses) {
+		t.Errorf("Unmarshal() equivalent classes = %+v, want %+v", ont.EquivalentClasses, wantClasses)
+	}
+
+	wantDatatypes := []DatatypeDefinition{{
+		Datatype:  Datatype{IRI: "http://example.com/cloud/TLSVersion"},
+		DataOneOf: []DataOneOf{{Literal: []string{"tls1.2", "tls1.3"}}},
+		Position:  Position{Line: 10, Column: 25},
+	}}
+	if !reflect.DeepEqual(ont.DatatypeDefinitions, wantDatatypes) {
+		t.Errorf("Unmarshal() datatype definitions = %+v, want %+v", ont.DatatypeDefinitions, wantDatatypes)
+	}
+}
+
+func TestUnmarshal_datatypeRestrictions(t *testing.T) {
+	ont, err := Unmarshal([]byte(`<?xml version="1.0"?>
+<Ontology xmlns="http://www.w3.org/2002/07/owl#">
+    <SubClassOf>
+        <Class abbreviatedIRI="ex:Resource"/>
+        <DataSomeValuesFrom>
+            <DataProperty abbreviatedIRI="ex:port"/>
+            <DatatypeRestriction>
+                <Datatype abbreviatedIRI="xsd:integer"/>
+                <FacetRestriction facet="http://www.w3.org/2001/XMLSchema#maxExclusive">
+                    <Literal datatypeIRI="http://www.w3.org/2001/XMLSchema#integer">65536</Literal>
+                </FacetRestriction>
+            </DatatypeRestriction>
+        </DataSomeValuesFrom>
+    </SubClassOf>
+</Ontology>`), FormatOWLXML)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := []DataSomeValuesFrom{{
+		DataProperty: DataProperty{Entity{AbbreviatedIRI: "ex:port"}},
+		DatatypeRestriction: &DatatypeRestriction{
+			Datatype:         Datatype{AbbreviatedIRI: "xsd:integer"},
+			FacetRestriction: []FacetRestriction{{Facet: "http://www.w3.org/2001/XMLSchema#maxExclusive", Literal: "65536"}},
+		},
+	}}
+	if len(ont.SubClasses) != 1 || !reflect.DeepEqual(ont.SubClasses[0].DataSomeValuesFrom, want) {
+		t.Errorf("Unmarshal() sub-classes = %+v, want %+v", ont.SubClasses, want)
+	}
+}
@@ -0,0 +1,804 @@
+package owl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/oxisto/owl2proto/internal/util"
+	"github.com/oxisto/owl2proto/rdf"
+)
+
+// fssKind is the kind of an expression in the OWL 2 Functional-Style Syntax.
+type fssKind int
+
+const (
+	fssCall    fssKind = iota // e.g., SubClassOf(...)
+	fssIRI                    // e.g., <http://example.com/cloud/Storage>
+	fssName                   // e.g., ex:Storage
+	fssLiteral                // e.g., "Storage"@en
+	fssSymbol                 // e.g., =
+)
+
+// fssExpr is an expression in the OWL 2 Functional-Style Syntax. This is either a call, such as
+// "SubClassOf(ex:BlockStorage ex:Storage)", with its arguments or a single IRI, name, literal or symbol.
+type fssExpr struct {
+	kind  fssKind
+	value string
+	args  []*fssExpr
+
+	// datatype or language of a literal (including the "^^" or "@")
+	suffix string
+
+	line   int
+	column int
+}
+
+// fssParser parses the OWL 2 Functional-Style Syntax (see https://www.w3.org/TR/owl2-syntax/).
+type fssParser struct {
+	src    []rune
+	pos    int
+	line   int
+	column int
+}
+
+// UnmarshalFunctional decodes an ontology in the OWL 2 Functional-Style Syntax (.ofn). It supports prefix and entity
+// declarations, SubClassOf axioms (with ObjectSomeValuesFrom, DataSomeValuesFrom, ObjectHasValue, DataHasValue and
+// cardinality restrictions), SubObjectPropertyOf and SubDataPropertyOf axioms, enumerations (EquivalentClasses with
+// ObjectOneOf and DatatypeDefinition with DataOneOf), datatype restrictions (in DatatypeDefinition and
+// DataSomeValuesFrom) and annotation assertions. All other axioms are ignored. Other class expressions and data ranges
+// in the supported axioms, e.g., ObjectIntersectionOf, are ignored as well and recorded in [Ontology.Unsupported].
+func UnmarshalFunctional(b []byte) (ont *Ontology, err error) {
+	var exprs []*fssExpr
+
+	p := &fssParser{src: []rune(string(b)), line: 1, column: 1}
+
+	for {
+		p.skipWhitespace()
+		if p.eof() {
+			break
+		}
+
+		expr, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, expr)
+	}
+
+	ont = new(Ontology)
+
+	for _, expr := range exprs {
+		switch {
+		case expr.is("Prefix"):
+			err = ont.functionalPrefix(expr)
+		case expr.is("Ontology"):
+			err = ont.functionalOntology(expr)
+		default:
+			err = expr.errorf("expected Prefix or Ontology, got %s", expr)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Well-known prefixes can be used without being declared
+	for _, name := range util.SortMapKeys(rdf.WellKnownPrefixes) {
+		if !hasPrefix(ont.Prefixes, name) {
+			ont.Prefixes = append(ont.Prefixes, Prefix{Name: name, IRI: rdf.WellKnownPrefixes[name]})
+		}
+	}
+
+	return ont, nil
+}
+
+// functionalPrefix adds a prefix declaration, e.g., "Prefix(ex:=<http://example.com/cloud/>)".
+func (ont *Ontology) functionalPrefix(expr *fssExpr) error {
+	if len(expr.args) != 3 || expr.args[0].kind != fssName || !strings.HasSuffix(expr.args[0].value, ":") ||
+		expr.args[1].value != "=" || expr.args[2].kind != fssIRI {
+		return expr.errorf("invalid prefix declaration")
+	}
+
+	ont.Prefixes = append(ont.Prefixes, Prefix{
+		Name: strings.TrimSuffix(expr.args[0].value, ":"),
+		IRI:  expr.args[2].value,
+	})
+
+	return nil
+}
+
+// functionalOntology adds the ontology IRI, version IRI, imports and axioms of an Ontology(...) expression.
+func (ont *Ontology) functionalOntology(expr *fssExpr) (err error) {
+	for i, arg := range expr.args {
+		switch {
+		case arg.kind == fssIRI && i == 0:
+			ont.IRI = arg.value
+		case arg.kind == fssIRI && i == 1:
+			ont.VersionIRI = arg.value
+		case arg.is("Import"):
+			if len(arg.args) != 1 || arg.args[0].kind != fssIRI {
+				return arg.errorf("invalid import")
+			}
+
+			ont.Imports = append(ont.Imports, arg.args[0].value)
+		case arg.kind == fssCall:
+			err = ont.functionalAxiom(arg)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// functionalAxiom adds an axiom of the ontology. Unsupported axioms are ignored.
+func (ont *Ontology) functionalAxiom(expr *fssExpr) error {
+	// Remove axiom annotations, they are not needed
+	args := expr.withoutAnnotations()
+	pos := Position{Line: expr.line, Column: expr.column}
+
+	switch expr.value {
+	case "Declaration":
+		if len(args) != 1 || args[0].kind != fssCall || len(args[0].args) != 1 {
+			return expr.errorf("invalid declaration")
+		}
+
+		entity, err := ont.functionalEntity(args[0].args[0])
+		if err != nil {
+			return err
+		}
+
+		switch args[0].value {
+		case "Class":
+			ont.Declarations = append(ont.Declarations, Declaration{Class: Class{entity}, Position: pos})
+		case "ObjectProperty":
+			ont.Declarations = append(ont.Declarations, Declaration{ObjectProperty: ObjectProperty{entity}, Position: pos})
+		case "DataProperty":
+			ont.Declarations = append(ont.Declarations, Declaration{DataProperty: DataProperty{entity}, Position: pos})
+		case "NamedIndividual":
+			ont.Declarations = append(ont.Declarations, Declaration{NamedIndividual: NamedIndividual{entity}, Position: pos})
+		}
+	case "SubClassOf":
+		if len(args) != 2 {
+			return expr.errorf("SubClassOf expects 2 arguments, got %d", len(args))
+		}
+
+		sc, ok, err := ont.functionalSubClassOf(args[0], args[1])
+		if err != nil {
+			return err
+		} else if ok {
+			sc.Position = pos
+			ont.SubClasses = append(ont.SubClasses, sc)
+		}
+	case "SubObjectPropertyOf", "SubDataPropertyOf":
+		if len(args) != 2 {
+			return expr.errorf("%s expects 2 arguments, got %d", expr.value, len(args))
+		}
+
+		// Property chains, e.g., SubObjectPropertyOf(ObjectPropertyChain(...) ex:p), are not supported
+		if args[0].kind == fssCall {
+			return nil
+		}
+
+		sub, err := ont.functionalEntity(args[0])
+		if err != nil {
+			return err
+		}
+
+		super, err := ont.functionalEntity(args[1])
+		if err != nil {
+			return err
+		}
+
+		if expr.value == "SubObjectPropertyOf" {
+			ont.SubObjectProperties = append(ont.SubObjectProperties, SubObjectPropertyOf{
+				ObjectProperty: []ObjectProperty{{sub}, {super}},
+				Position:       pos,
+			})
+		} else {
+			ont.SubDataProperties = append(ont.SubDataProperties, SubDataPropertyOf{
+				DataProperty: []DataProperty{{sub}, {super}},
+				Position:     pos,
+			})
+		}
+	case "EquivalentClasses":
+		ec, ok, err := ont.functionalEquivalentClasses(args)
+		if err != nil {
+			return err
+		} else if ok {
+			ec.Position = pos
+			ont.EquivalentClasses = append(ont.EquivalentClasses, ec)
+		}
+	case "DatatypeDefinition":
+		if len(args) != 2 {
+			return expr.errorf("DatatypeDefinition expects 2 arguments, got %d", len(args))
+		}
+
+		// Only enumerations of literals and datatype restrictions are supported as data range
+		if !args[1].is("DataOneOf") && !args[1].is("DatatypeRestriction") {
+			return nil
+		}
+
+		datatype, err := ont.functionalEntity(args[0])
+		if err != nil {
+			return err
+		}
+
+		dd := DatatypeDefinition{
+			Datatype: Datatype{AbbreviatedIRI: ont.abbreviate(datatype)},
+			Position: pos,
+		}
+
+		if args[1].is("DatatypeRestriction") {
+			dd.DatatypeRestriction, err = ont.functionalDatatypeRestriction(args[1])
+			if err != nil {
+				return err
+			}
+
+			ont.DatatypeDefinitions = append(ont.DatatypeDefinitions, dd)
+			return nil
+		}
+
+		var oneOf DataOneOf
+		for _, arg := range args[1].args {
+			if arg.kind != fssLiteral {
+				return arg.errorf("expected literal, got %s", arg)
+			}
+
+			oneOf.Literal = append(oneOf.Literal, arg.value)
+		}
+		dd.DataOneOf = append(dd.DataOneOf, oneOf)
+
+		ont.DatatypeDefinitions = append(ont.DatatypeDefinitions, dd)
+	case "AnnotationAssertion":
+		if len(args) != 3 {
+			return expr.errorf("AnnotationAssertion expects 3 arguments, got %d", len(args))
+		}
+
+		property, err := ont.functionalEntity(args[0])
+		if err != nil {
+			return err
+		}
+
+		subject, err := ont.functionalEntity(args[1])
+		if err != nil {
+			return err
+		}
+
+		ont.AnnotationAssertion = append(ont.AnnotationAssertion, AnnotationAssertion{
+			AnnotationProperty: AnnotationProperty{AbbreviatedIRI: ont.abbreviate(property)},
+			IRI:                subject.IRI,
+			AbbreviatedIRI:     subject.AbbreviatedIRI,
+			Literal:            args[2].value,
+			Position:           pos,
+		})
+	}
+
+	return nil
+}
+
+// functionalEquivalentClasses converts the arguments of an EquivalentClasses axiom into an [EquivalentClasses]. Only
+// axioms that contain named classes and an ObjectOneOf enumeration are converted.
+func (ont *Ontology) functionalEquivalentClasses(args []*fssExpr) (ec EquivalentClasses, ok bool, err error) {
+	var unsupported []*fssExpr
+
+	for _, arg := range args {
+		switch {
+		case arg.is("ObjectOneOf"):
+			var oneOf ObjectOneOf
+			for _, individual := range arg.args {
+				entity, err := ont.functionalEntity(individual)
+				if err != nil {
+					return ec, false, err
+				}
+
+				oneOf.NamedIndividual = append(oneOf.NamedIndividual, NamedIndividual{entity})
+			}
+
+			ec.ObjectOneOf = append(ec.ObjectOneOf, oneOf)
+		case arg.kind == fssCall:
+			// Other class expressions are not supported
+			unsupported = append(unsupported, arg)
+		default:
+			class, err := ont.functionalEntity(arg)
+			if err != nil {
+				return ec, false, err
+			}
+
+			ec.Class = append(ec.Class, Class{class})
+		}
+	}
+
+	for _, arg := range unsupported {
+		var class Class
+		if len(ec.Class) > 0 {
+			class = ec.Class[0]
+		}
+
+		ont.unsupported("EquivalentClasses", class, arg)
+	}
+
+	return ec, len(ec.Class) > 0 && len(ec.ObjectOneOf) > 0, nil
+}
+
+// functionalSubClassOf converts the arguments of a SubClassOf axiom into a [SubClassOf]. Only named sub-classes and
+// super-classes that are named classes or supported restrictions are converted.
+func (ont *Ontology) functionalSubClassOf(sub *fssExpr, super *fssExpr) (sc SubClassOf, ok bool, err error) {
+	if sub.kind == fssCall {
+		ont.unsupported("SubClassOf", Class{}, sub)
+		return sc, false, nil
+	}
+
+	class, err := ont.functionalEntity(sub)
+	if err != nil {
+		return sc, false, err
+	}
+
+	sc.Class = []Class{{class}}
+
+	if super.kind != fssCall {
+		class, err = ont.functionalEntity(super)
+		if err != nil {
+			return sc, false, err
+		}
+
+		sc.Class = append(sc.Class, Class{class})
+		return sc, true, nil
+	}
+
+	switch super.value {
+	case "ObjectMinCardinality", "ObjectMaxCardinality", "ObjectExactCardinality", "DataMinCardinality",
+		"DataMaxCardinality", "DataExactCardinality":
+		return ont.functionalCardinality(sc, super)
+	case "ObjectSomeValuesFrom", "DataSomeValuesFrom", "ObjectHasValue", "DataHasValue":
+	default:
+		ont.unsupported("SubClassOf", sc.Class[0], super)
+		return sc, false, nil
+	}
+
+	// All other supported restrictions have exactly two arguments: the property and the class, datatype or value
+	if len(super.args) != 2 {
+		return sc, false, super.errorf("%s expects 2 arguments, got %d", super.value, len(super.args))
+	}
+
+	property, err := ont.functionalEntity(super.args[0])
+	if err != nil {
+		return sc, false, err
+	}
+
+	value := super.args[1]
+
+	switch super.value {
+	case "ObjectSomeValuesFrom":
+		if value.kind == fssCall {
+			ont.unsupported("SubClassOf", sc.Class[0], value)
+			return sc, false, nil
+		}
+
+		class, err = ont.functionalEntity(value)
+		if err != nil {
+			return sc, false, err
+		}
+
+		sc.ObjectSomeValuesFrom = append(sc.ObjectSomeValuesFrom, ObjectSomeValuesFrom{
+			ObjectProperty: ObjectProperty{property},
+			Class:          Class{class},
+		})
+	case "DataSomeValuesFrom":
+		if value.is("DatatypeRestriction") {
+			restriction, err := ont.functionalDatatypeRestriction(value)
+			if err != nil {
+				return sc, false, err
+			}
+
+			sc.DataSomeValuesFrom = append(sc.DataSomeValuesFrom, DataSomeValuesFrom{
+				DataProperty:        DataProperty{property},
+				DatatypeRestriction: restriction,
+			})
+			break
+		} else if value.kind == fssCall {
+			ont.unsupported("SubClassOf", sc.Class[0], value)
+			return sc, false, nil
+		}
+
+		datatype, err := ont.functionalEntity(value)
+		if err != nil {
+			return sc, false, err
+		}
+
+		sc.DataSomeValuesFrom = append(sc.DataSomeValuesFrom, DataSomeValuesFrom{
+			DataProperty: DataProperty{property},
+			Datatype:     Datatype{AbbreviatedIRI: ont.abbreviate(datatype)},
+		})
+	case "ObjectHasValue":
+		individual, err := ont.functionalEntity(value)
+		if err != nil {
+			return sc, false, err
+		}
+
+		sc.ObjectHasValue = append(sc.ObjectHasValue, ObjectHasValue{
+			ObjectProperty:  ObjectProperty{property},
+			NamedIndividual: NamedIndividual{individual},
+		})
+	case "DataHasValue":
+		if value.kind != fssLiteral {
+			return sc, false, value.errorf("expected literal, got %s", value)
+		}
+
+		sc.DataHasValue = append(sc.DataHasValue, DataHasValue{
+			DataProperty: DataProperty{property},
+			Literal:      value.value,
+		})
+	}
+
+	return sc, true, nil
+}
+
+// functionalCardinality adds a (possibly qualified) cardinality restriction, e.g., "ObjectMaxCardinality(1 ex:has
+// ex:GeoLocation)", to the SubClassOf axiom.
+func (ont *Ontology) functionalCardinality(sc SubClassOf, expr *fssExpr) (SubClassOf, bool, error) {
+	var qualifier Entity
+
+	if len(expr.args) < 2 || len(expr.args) > 3 {
+		return sc, false, expr.errorf("%s expects 2 or 3 arguments, got %d", expr.value, len(expr.args))
+	}
+
+	n, err := strconv.Atoi(expr.args[0].value)
+	if err != nil || n < 0 || expr.args[0].kind != fssName {
+		return sc, false, expr.args[0].errorf("invalid cardinality %s", expr.args[0])
+	}
+
+	property, err := ont.functionalEntity(expr.args[1])
+	if err != nil {
+		return sc, false, err
+	}
+
+	// Complex class expressions and data ranges are not supported as qualifier
+	if len(expr.args) == 3 {
+		if expr.args[2].kind == fssCall {
+			ont.unsupported("SubClassOf", sc.Class[0], expr.args[2])
+			return sc, false, nil
+		}
+
+		qualifier, err = ont.functionalEntity(expr.args[2])
+		if err != nil {
+			return sc, false, err
+		}
+	}
+
+	object := ObjectCardinality{Cardinality: n, ObjectProperty: ObjectProperty{property}, Class: Class{qualifier}}
+	data := DataCardinality{Cardinality: n, DataProperty: DataProperty{property}}
+	if len(expr.args) == 3 {
+		data.Datatype = Datatype{AbbreviatedIRI: ont.abbreviate(qualifier)}
+	}
+
+	switch expr.value {
+	case "ObjectMinCardinality":
+		sc.ObjectMinCardinality = append(sc.ObjectMinCardinality, object)
+	case "ObjectMaxCardinality":
+		sc.ObjectMaxCardinality = append(sc.ObjectMaxCardinality, object)
+	case "ObjectExactCardinality":
+		sc.ObjectExactCardinality = append(sc.ObjectExactCardinality, object)
+	case "DataMinCardinality":
+		sc.DataMinCardinality = append(sc.DataMinCardinality, data)
+	case "DataMaxCardinality":
+		sc.DataMaxCardinality = append(sc.DataMaxCardinality, data)
+	case "DataExactCardinality":
+		sc.DataExactCardinality = append(sc.DataExactCardinality, data)
+	}
+
+	return sc, true, nil
+}
+
+// functionalDatatypeRestriction parses a datatype restriction, e.g., "DatatypeRestriction(xsd:integer
+// xsd:minInclusive "0"^^xsd:integer)". Facets are expanded to their full IRI.
+func (ont *Ontology) functionalDatatypeRestriction(expr *fssExpr) (*DatatypeRestriction, error) {
+	if len(expr.args) < 3 || len(expr.args)%2 == 0 {
+		return nil, expr.errorf("DatatypeRestriction expects a datatype and pairs of facets and literals")
+	}
+
+	datatype, err := ont.functionalEntity(expr.args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	restriction := &DatatypeRestriction{Datatype: Datatype{AbbreviatedIRI: ont.abbreviate(datatype)}}
+
+	for i := 1; i < len(expr.args); i += 2 {
+		facet, err := ont.functionalEntity(expr.args[i])
+		if err != nil {
+			return nil, err
+		}
+
+		value := expr.args[i+1]
+		if value.kind != fssLiteral {
+			return nil, value.errorf("expected literal, got %s", value)
+		}
+
+		restriction.FacetRestriction = append(restriction.FacetRestriction, FacetRestriction{
+			Facet:   ont.expand(facet),
+			Literal: value.value,
+		})
+	}
+
+	return restriction, nil
+}
+
+// unsupported records a class expression or data range of an axiom that is not supported and therefore ignored.
+func (ont *Ontology) unsupported(axiom string, class Class, expr *fssExpr) {
+	ont.Unsupported = append(ont.Unsupported, Unsupported{
+		Axiom:      axiom,
+		Class:      class,
+		Expression: expr.value,
+		Position:   Position{Line: expr.line, Column: expr.column},
+	})
+}
+
+// functionalEntity converts a full or abbreviated IRI into an [Entity].
+func (ont *Ontology) functionalEntity(expr *fssExpr) (Entity, error) {
+	switch expr.kind {
+	case fssIRI:
+		return Entity{IRI: expr.value}, nil
+	case fssName:
+		prefix, _, found := strings.Cut(expr.value, ":")
+		if !found {
+			return Entity{}, expr.errorf("expected IRI, got %s", expr)
+		} else if !hasPrefix(ont.Prefixes, prefix) && rdf.WellKnownPrefixes[prefix] == "" {
+			return Entity{}, expr.errorf("undeclared prefix %q", prefix)
+		}
+
+		return Entity{AbbreviatedIRI: expr.value}, nil
+	default:
+		return Entity{}, expr.errorf("expected IRI, got %s", expr)
+	}
+}
+
+// abbreviate returns the abbreviated IRI of the entity, which is needed for datatypes and annotation properties.
+func (ont *Ontology) abbreviate(e Entity) string {
+	if e.AbbreviatedIRI != "" {
+		return e.AbbreviatedIRI
+	}
+
+	g := rdf.NewGraph()
+	for _, p := range ont.Prefixes {
+		g.Prefixes[p.Name] = p.IRI
+	}
+
+	return g.Abbreviate(e.IRI)
+}
+
+// expand returns the full IRI of the entity. The prefix of an abbreviated IRI is either declared in the ontology or
+// well-known.
+func (ont *Ontology) expand(e Entity) string {
+	if e.IRI != "" {
+		return e.IRI
+	}
+
+	prefix, name, _ := strings.Cut(e.AbbreviatedIRI, ":")
+	if i := prefixIndex(ont.Prefixes, prefix); i != -1 {
+		return ont.Prefixes[i].IRI + name
+	}
+
+	return rdf.WellKnownPrefixes[prefix] + name
+}
+
+// expr parses a single expression.
+func (p *fssParser) expr() (expr *fssExpr, err error) {
+	p.skipWhitespace()
+
+	expr = &fssExpr{line: p.line, column: p.column}
+
+	c := p.peek()
+	switch {
+	case p.eof():
+		return nil, p.errorf("unexpected end of input")
+	case c == '<':
+		expr.kind = fssIRI
+		p.next()
+
+		var iri strings.Builder
+		for !p.eof() && p.peek() != '>' {
+			if unicode.IsSpace(p.peek()) {
+				return nil, p.errorf("whitespace in IRI")
+			}
+			iri.WriteRune(p.next())
+		}
+		if p.eof() {
+			return nil, expr.errorf("unterminated IRI")
+		}
+		p.next()
+
+		expr.value = iri.String()
+	case c == '"':
+		expr.kind = fssLiteral
+		expr.value, err = p.quoted()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek() == '@' {
+			expr.suffix = string(p.next()) + p.word()
+		} else if p.peek() == '^' && p.peekAt(1) == '^' {
+			p.next()
+			p.next()
+
+			datatype, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+
+			expr.suffix = "^^" + datatype.value
+		}
+	case c == '=':
+		expr.kind = fssSymbol
+		expr.value = string(p.next())
+	case c == '(' || c == ')':
+		return nil, p.errorf("unexpected %q", string(c))
+	default:
+		expr.kind = fssName
+		expr.value = p.word()
+		if expr.value == "" {
+			return nil, p.errorf("unexpected %q", string(c))
+		}
+
+		p.skipWhitespace()
+		if p.peek() != '(' {
+			return expr, nil
+		}
+
+		// We have a call with arguments
+		expr.kind = fssCall
+		p.next()
+
+		for {
+			p.skipWhitespace()
+			if p.eof() {
+				return nil, expr.errorf("missing ')' for %s", expr.value)
+			} else if p.peek() == ')' {
+				p.next()
+				break
+			}
+
+			arg, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+
+			expr.args = append(expr.args, arg)
+		}
+	}
+
+	return expr, nil
+}
+
+// quoted parses a quoted string, in which only \" and \\ are escaped.
+func (p *fssParser) quoted() (string, error) {
+	var (
+		s            strings.Builder
+		line, column = p.line, p.column
+	)
+
+	p.next()
+
+	for {
+		if p.eof() {
+			return "", fmt.Errorf("functional syntax %d:%d: unterminated string literal", line, column)
+		}
+
+		c := p.next()
+		if c == '"' {
+			return s.String(), nil
+		} else if c == '\\' && (p.peek() == '"' || p.peek() == '\\') {
+			c = p.next()
+		}
+
+		s.WriteRune(c)
+	}
+}
+
+// word reads a name, i.e., a keyword, a prefixed name, a blank node or a number.
+func (p *fssParser) word() string {
+	var s strings.Builder
+
+	for !p.eof() {
+		c := p.peek()
+		if unicode.IsSpace(c) || c == '(' || c == ')' || c == '=' || c == '"' || c == '<' || c == '>' || c == '^' || c == '@' {
+			break
+		}
+
+		s.WriteRune(p.next())
+	}
+
+	return s.String()
+}
+
+// skipWhitespace skips whitespace and comments.
+func (p *fssParser) skipWhitespace() {
+	for !p.eof() {
+		c := p.peek()
+
+		if c == '#' {
+			for !p.eof() && p.peek() != '\n' {
+				p.next()
+			}
+		} else if unicode.IsSpace(c) || c == '\uFEFF' {
+			p.next()
+		} else {
+			return
+		}
+	}
+}
+
+// next consumes the next character and keeps track of the line and column.
+func (p *fssParser) next() rune {
+	c := p.src[p.pos]
+	p.pos++
+
+	if c == '\n' {
+		p.line++
+		p.column = 1
+	} else {
+		p.column++
+	}
+
+	return c
+}
+
+func (p *fssParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *fssParser) peek() rune {
+	return p.peekAt(0)
+}
+
+func (p *fssParser) peekAt(offset int) rune {
+	if p.pos+offset >= len(p.src) {
+		return 0
+	}
+
+	return p.src[p.pos+offset]
+}
+
+// errorf returns an error that includes the current line and column.
+func (p *fssParser) errorf(format string, a ...any) error {
+	return fmt.Errorf("functional syntax %d:%d: %w", p.line, p.column, fmt.Errorf(format, a...))
+}
+
+// is returns true if the expression is a call with the given name.
+func (expr *fssExpr) is(name string) bool {
+	return expr.kind == fssCall && expr.value == name
+}
+
+// withoutAnnotations returns the arguments of the expression without any Annotation(...) arguments.
+func (expr *fssExpr) withoutAnnotations() (args []*fssExpr) {
+	for _, arg := range expr.args {
+		if !arg.is("Annotation") {
+			args = append(args, arg)
+		}
+	}
+
+	return
+}
+
+// errorf returns an error that includes the line and column of the expression.
+func (expr *fssExpr) errorf(format string, a ...any) error {
+	return fmt.Errorf("functional syntax %d:%d: %w", expr.line, expr.column, fmt.Errorf(format, a...))
+}
+
+// String returns a short representation of the expression for error messages.
+func (expr *fssExpr) String() string {
+	switch expr.kind {
+	case fssCall:
+		return expr.value + "(...)"
+	case fssIRI:
+		return "<" + expr.value + ">"
+	case fssLiteral:
+		return fmt.Sprintf("%q%s", expr.value, expr.suffix)
+	default:
+		return expr.value
+	}
+}
@@ -0,0 +1,320 @@
+package owl
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshalFunctional(t *testing.T) {
+	type args struct {
+		doc string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *Ontology
+		wantErr string
+	}{
+		{
+			name: "Happy path",
+			args: args{
+				doc: `Prefix(ex:=<http://example.com/cloud/>)
+Ontology(<http://example.com/cloud> <http://example.com/cloud/1.0>
+	Import(<http://example.com/core>)
+	Declaration(Class(ex:Storage))
+	Declaration(DataProperty(<http://example.com/cloud/name>))
+	SubClassOf(Annotation(rdfs:comment "ignored") ex:Storage ex:Resource)
+	SubClassOf(ex:Storage DataSomeValuesFrom(ex:name <http://www.w3.org/2001/XMLSchema#string>))
+	SubClassOf(ex:Storage DataHasValue(ex:interval "xsd:java.time.Duration"))
+	AnnotationAssertion(rdfs:label ex:Storage "Storage \"Service\""@en)
+	DisjointClasses(ex:Storage ex:Compute)
+)`,
+			},
+			want: &Ontology{
+				IRI:        "http://example.com/cloud",
+				VersionIRI: "http://example.com/cloud/1.0",
+				Imports:    []string{"http://example.com/core"},
+				Prefixes: []Prefix{
+					{Name: "ex", IRI: "http://example.com/cloud/"},
+					{Name: "owl", IRI: "http://www.w3.org/2002/07/owl#"},
+					{Name: "rdf", IRI: "http://www.w3.org/1999/02/22-rdf-syntax-ns#"},
+					{Name: "rdfs", IRI: "http://www.w3.org/2000/01/rdf-schema#"},
+					{Name: "xml", IRI: "http://www.w3.org/XML/1998/namespace"},
+					{Name: "xsd", IRI: "http://www.w3.org/2001/XMLSchema#"},
+				},
+				Declarations: []Declaration{
+					{Class: Class{Entity{AbbreviatedIRI: "ex:Storage"}}, Position: Position{Line: 4, Column: 2}},
+					{DataProperty: DataProperty{Entity{IRI: "http://example.com/cloud/name"}}, Position: Position{Line: 5, Column: 2}},
+				},
+				SubClasses: []SubClassOf{
+					{
+						Class:    []Class{{Entity{AbbreviatedIRI: "ex:Storage"}}, {Entity{AbbreviatedIRI: "ex:Resource"}}},
+						Position: Position{Line: 6, Column: 2},
+					},
+					{
+						Class: []Class{{Entity{AbbreviatedIRI: "ex:Storage"}}},
+						DataSomeValuesFrom: []DataSomeValuesFrom{{
+							DataProperty: DataProperty{Entity{AbbreviatedIRI: "ex:name"}},
+							Datatype:     Datatype{AbbreviatedIRI: "xsd:string"},
+						}},
+						Position: Position{Line: 7, Column: 2},
+					},
+					{
+						Class: []Class{{Entity{AbbreviatedIRI: "ex:Storage"}}},
+						DataHasValue: []DataHasValue{{
+							DataProperty: DataProperty{Entity{AbbreviatedIRI: "ex:interval"}},
+							Literal:      "xsd:java.time.Duration",
+						}},
+						Position: Position{Line: 8, Column: 2},
+					},
+				},
+				AnnotationAssertion: []AnnotationAssertion{{
+					AnnotationProperty: AnnotationProperty{AbbreviatedIRI: "rdfs:label"},
+					AbbreviatedIRI:     "ex:Storage",
+					Literal:            `Storage "Service"`,
+					Position:           Position{Line: 9, Column: 2},
+				}},
+			},
+		},
+		{
+			name: "Cardinality restrictions",
+			args: args{
+				doc: `Prefix(ex:=<http://example.com/cloud/>)
+Ontology(
+	SubClassOf(ex:VirtualMachine ObjectMaxCardinality(1 ex:has ex:GeoLocation))
+	SubClassOf(ex:VirtualMachine DataExactCardinality(1 ex:name))
+)`,
+			},
+			want: &Ontology{
+				Prefixes: []Prefix{
+					{Name: "ex", IRI: "http://example.com/cloud/"},
+					{Name: "owl", IRI: "http://www.w3.org/2002/07/owl#"},
+					{Name: "rdf", IRI: "http://www.w3.org/1999/02/22-rdf-syntax-ns#"},
+					{Name: "rdfs", IRI: "http://www.w3.org/2000/01/rdf-schema#"},
+					{Name: "xml", IRI: "http://www.w3.org/XML/1998/namespace"},
+					{Name: "xsd", IRI: "http://www.w3.org/2001/XMLSchema#"},
+				},
+				SubClasses: []SubClassOf{
+					{
+						Class: []Class{{Entity{AbbreviatedIRI: "ex:VirtualMachine"}}},
+						ObjectMaxCardinality: []ObjectCardinality{{
+							Cardinality:    1,
+							ObjectProperty: ObjectProperty{Entity{AbbreviatedIRI: "ex:has"}},
+							Class:          Class{Entity{AbbreviatedIRI: "ex:GeoLocation"}},
+						}},
+						Position: Position{Line: 3, Column: 2},
+					},
+					{
+						Class: []Class{{Entity{AbbreviatedIRI: "ex:VirtualMachine"}}},
+						DataExactCardinality: []DataCardinality{{
+							Cardinality:  1,
+							DataProperty: DataProperty{Entity{AbbreviatedIRI: "ex:name"}},
+						}},
+						Position: Position{Line: 4, Column: 2},
+					},
+				},
+			},
+		},
+		{
+			name: "Sub-properties",
+			args: args{
+				doc: `Prefix(ex:=<http://example.com/cloud/>)
+Ontology(
+	SubObjectPropertyOf(ex:hasMultiple ex:has)
+	SubObjectPropertyOf(ObjectPropertyChain(ex:has ex:has) ex:hasTransitive)
+	SubDataPropertyOf(ex:displayName <http://example.com/cloud/name>)
+)`,
+			},
+			want: &Ontology{
+				Prefixes: []Prefix{
+					{Name: "ex", IRI: "http://example.com/cloud/"},
+					{Name: "owl", IRI: "http://www.w3.org/2002/07/owl#"},
+					{Name: "rdf", IRI: "http://www.w3.org/1999/02/22-rdf-syntax-ns#"},
+					{Name: "rdfs", IRI: "http://www.w3.org/2000/01/rdf-schema#"},
+					{Name: "xml", IRI: "http://www.w3.org/XML/1998/namespace"},
+					{Name: "xsd", IRI: "http://www.w3.org/2001/XMLSchema#"},
+				},
+				SubObjectProperties: []SubObjectPropertyOf{{
+					ObjectProperty: []ObjectProperty{{Entity{AbbreviatedIRI: "ex:hasMultiple"}}, {Entity{AbbreviatedIRI: "ex:has"}}},
+					Position:       Position{Line: 3, Column: 2},
+				}},
+				SubDataProperties: []SubDataPropertyOf{{
+					DataProperty: []DataProperty{{Entity{AbbreviatedIRI: "ex:displayName"}}, {Entity{IRI: "http://example.com/cloud/name"}}},
+					Position:     Position{Line: 5, Column: 2},
+				}},
+			},
+		},
+		{
+			name: "Enumerations",
+			args: args{
+				doc: `Prefix(ex:=<http://example.com/cloud/>)
+Ontology(
+	EquivalentClasses(ex:Severity ObjectOneOf(ex:High <http://example.com/cloud/Low>))
+	EquivalentClasses(ex:Storage ObjectIntersectionOf(ex:Resource ex:Persistent))
+	DatatypeDefinition(ex:TLSVersion DataOneOf("tls1.2" "tls1.3"^^xsd:string))
+)`,
+			},
+			want: &Ontology{
+				Prefixes: []Prefix{
+					{Name: "ex", IRI: "http://example.com/cloud/"},
+					{Name: "owl", IRI: "http://www.w3.org/2002/07/owl#"},
+					{Name: "rdf", IRI: "http://www.w3.org/1999/02/22-rdf-syntax-ns#"},
+					{Name: "rdfs", IRI: "http://www.w3.org/2000/01/rdf-schema#"},
+					{Name: "xml", IRI: "http://www.w3.org/XML/1998/namespace"},
+					{Name: "xsd", IRI: "http://www.w3.org/2001/XMLSchema#"},
+				},
+				EquivalentClasses: []EquivalentClasses{{
+					Class: []Class{{Entity{AbbreviatedIRI: "ex:Severity"}}},
+					ObjectOneOf: []ObjectOneOf{{NamedIndividual: []NamedIndividual{
+						{Entity{AbbreviatedIRI: "ex:High"}},
+						{Entity{IRI: "http://example.com/cloud/Low"}},
+					}}},
+					Position: Position{Line: 3, Column: 2},
+				}},
+				DatatypeDefinitions: []DatatypeDefinition{{
+					Datatype:  Datatype{AbbreviatedIRI: "ex:TLSVersion"},
+					DataOneOf: []DataOneOf{{Literal: []string{"tls1.2", "tls1.3"}}},
+					Position:  Position{Line: 5, Column: 2},
+				}},
+				Unsupported: []Unsupported{{
+					Axiom:      "EquivalentClasses",
+					Class:      Class{Entity{AbbreviatedIRI: "ex:Storage"}},
+					Expression: "ObjectIntersectionOf",
+					Position:   Position{Line: 4, Column: 31},
+				}},
+			},
+		},
+		{
+			name: "Unsupported class expressions",
+			args: args{
+				doc: `Prefix(ex:=<http://example.com/cloud/>)
+Ontology(
+	SubClassOf(ex:Storage ObjectIntersectionOf(ObjectSomeValuesFrom(ex:has ex:Volume) ex:Resource))
+	SubClassOf(ObjectUnionOf(ex:BlockStorage ex:ObjectStorage) ex:Storage)
+	SubClassOf(ex:Storage ObjectSomeValuesFrom(ex:has ObjectUnionOf(ex:Volume ex:Disk)))
+	SubClassOf(ex:Storage ObjectMaxCardinality(1 ex:has ObjectComplementOf(ex:Disk)))
+)`,
+			},
+			want: &Ontology{
+				Prefixes: []Prefix{
+					{Name: "ex", IRI: "http://example.com/cloud/"},
+					{Name: "owl", IRI: "http://www.w3.org/2002/07/owl#"},
+					{Name: "rdf", IRI: "http://www.w3.org/1999/02/22-rdf-syntax-ns#"},
+					{Name: "rdfs", IRI: "http://www.w3.org/2000/01/rdf-schema#"},
+					{Name: "xml", IRI: "http://www.w3.org/XML/1998/namespace"},
+					{Name: "xsd", IRI: "http://www.w3.org/2001/XMLSchema#"},
+				},
+				Unsupported: []Unsupported{
+					{
+						Axiom:      "SubClassOf",
+						Class:      Class{Entity{AbbreviatedIRI: "ex:Storage"}},
+						Expression: "ObjectIntersectionOf",
+						Position:   Position{Line: 3, Column: 24},
+					},
+					{
+						Axiom:      "SubClassOf",
+						Expression: "ObjectUnionOf",
+						Position:   Position{Line: 4, Column: 13},
+					},
+					{
+						Axiom:      "SubClassOf",
+						Class:      Class{Entity{AbbreviatedIRI: "ex:Storage"}},
+						Expression: "ObjectUnionOf",
+						Position:   Position{Line: 5, Column: 52},
+					},
+					{
+						Axiom:      "SubClassOf",
+						Class:      Class{Entity{AbbreviatedIRI: "ex:Storage"}},
+						Expression: "ObjectComplementOf",
+						Position:   Position{Line: 6, Column: 54},
+					},
+				},
+			},
+		},
+		{
+			name: "Datatype restrictions",
+			args: args{
+				doc: `Prefix(ex:=<http://example.com/cloud/>)
+Ontology(
+	DatatypeDefinition(ex:Port DatatypeRestriction(xsd:integer xsd:minInclusive "1"^^xsd:integer <http://www.w3.org/2001/XMLSchema#maxInclusive> "65535"))
+	SubClassOf(ex:Resource DataSomeValuesFrom(ex:name DatatypeRestriction(xsd:string xsd:pattern "[a-z]+")))
+)`,
+			},
+			want: &Ontology{
+				Prefixes: []Prefix{
+					{Name: "ex", IRI: "http://example.com/cloud/"},
+					{Name: "owl", IRI: "http://www.w3.org/2002/07/owl#"},
+					{Name: "rdf", IRI: "http://www.w3.org/1999/02/22-rdf-syntax-ns#"},
+					{Name: "rdfs", IRI: "http://www.w3.org/2000/01/rdf-schema#"},
+					{Name: "xml", IRI: "http://www.w3.org/XML/1998/namespace"},
+					{Name: "xsd", IRI: "http://www.w3.org/2001/XMLSchema#"},
+				},
+				DatatypeDefinitions: []DatatypeDefinition{{
+					Datatype: Datatype{AbbreviatedIRI: "ex:Port"},
+					DatatypeRestriction: &DatatypeRestriction{
+						Datatype: Datatype{AbbreviatedIRI: "xsd:integer"},
+						FacetRestriction: []FacetRestriction{
+							{Facet: "http://www.w3.org/2001/XMLSchema#minInclusive", Literal: "1"},
+							{Facet: "http://www.w3.org/2001/XMLSchema#maxInclusive", Literal: "65535"},
+						},
+					},
+					Position: Position{Line: 3, Column: 2},
+				}},
+				SubClasses: []SubClassOf{{
+					Class: []Class{{Entity{AbbreviatedIRI: "ex:Resource"}}},
+					DataSomeValuesFrom: []DataSomeValuesFrom{{
+						DataProperty: DataProperty{Entity{AbbreviatedIRI: "ex:name"}},
+						DatatypeRestriction: &DatatypeRestriction{
+							Datatype:         Datatype{AbbreviatedIRI: "xsd:string"},
+							FacetRestriction: []FacetRestriction{{Facet: "http://www.w3.org/2001/XMLSchema#pattern", Literal: "[a-z]+"}},
+						},
+					}},
+					Position: Position{Line: 4, Column: 2},
+				}},
+			},
+		},
+		{
+			name: "Invalid datatype restriction",
+			args: args{
+				doc: "Prefix(ex:=<http://example.com/cloud/>)\nOntology(\n\tDatatypeDefinition(ex:Port DatatypeRestriction(xsd:integer xsd:minInclusive))\n)",
+			},
+			wantErr: "functional syntax 3:29: DatatypeRestriction expects a datatype and pairs of facets and literals",
+		},
+		{
+			name: "Invalid cardinality",
+			args: args{
+				doc: "Prefix(ex:=<http://example.com/cloud/>)\nOntology(\n\tSubClassOf(ex:VirtualMachine ObjectMinCardinality(-1 ex:has))\n)",
+			},
+			wantErr: "functional syntax 3:52: invalid cardinality -1",
+		},
+		{
+			name: "Unbalanced parentheses",
+			args: args{
+				doc: "Prefix(ex:=<http://example.com/cloud/>)\nOntology(\n\tDeclaration(Class(ex:Storage)\n",
+			},
+			wantErr: "functional syntax 3:2: missing ')' for Declaration",
+		},
+		{
+			name: "Undeclared prefix",
+			args: args{
+				doc: "Ontology(\n\tDeclaration(Class(foo:Storage))\n)",
+			},
+			wantErr: "functional syntax 2:20: undeclared prefix \"foo\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalFunctional([]byte(tt.args.doc))
+			if err != nil || tt.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Errorf("UnmarshalFunctional() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnmarshalFunctional() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package owl
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// CatalogFile is the name of the XML catalog that Protégé uses to map ontology IRIs to local files.
+const CatalogFile = "catalog-v001.xml"
+
+// ErrUnresolvedImport is returned if an imported ontology cannot be found locally.
+var ErrUnresolvedImport = errors.New("could not resolve import")
+
+// Catalog maps ontology IRIs to local files, see https://www.oasis-open.org/committees/download.php/14809/xml-catalogs.html.
+type Catalog struct {
+	URIs   []CatalogURI   `xml:"uri"`
+	Groups []CatalogGroup `xml:"group"`
+
+	// dir is the directory of the catalog file, against which relative file names are resolved
+	dir string
+}
+
+type CatalogGroup struct {
+	URIs []CatalogURI `xml:"uri"`
+}
+
+type CatalogURI struct {
+	Name string `xml:"name,attr"`
+	URI  string `xml:"uri,attr"`
+}
+
+// ReadCatalog reads an XML catalog file.
+func ReadCatalog(path string) (c *Catalog, err error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading catalog: %w", err)
+	}
+
+	c = &Catalog{dir: filepath.Dir(path)}
+
+	err = xml.Unmarshal(b, c)
+	if err != nil {
+		return nil, fmt.Errorf("error while un-marshalling catalog %s: %w", path, err)
+	}
+
+	return c, nil
+}
+
+// Resolve returns the local file of the ontology with the given IRI, if it is listed in the catalog.
+func (c *Catalog) Resolve(iri string) (path string, ok bool) {
+	if c == nil {
+		return "", false
+	}
+
+	uris := c.URIs
+	for _, g := range c.Groups {
+		uris = append(uris, g.URIs...)
+	}
+
+	for _, u := range uris {
+		if u.Name == iri {
+			return localPath(c.dir, u.URI)
+		}
+	}
+
+	return "", false
+}
+
+// Load reads an ontology file. If format is empty, the format is implied by the file extension or, if the extension
+// is ambiguous, detected from the content.
+func Load(path string, format Format) (ont *Ontology, err error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading ontology file: %w", err)
+	}
+
+	if format == "" {
+		var ok bool
+		if format, ok = FormatFromExtension(path); !ok {
+			format = DetectFormat(b)
+		}
+	}
+
+	ont, err = Unmarshal(b, format)
+	if err != nil {
+		return nil, err
+	}
+
+	ont.setFile(path)
+
+	return ont, nil
+}
+
+// ResolveImports loads all ontologies that are (transitively) imported by the ontology, which was read from path, and
+// merges them into it. Imports are only resolved locally, either using the catalog or, for file IRIs and relative
+// IRIs, relative to the importing file. The network is never accessed.
+func ResolveImports(ont *Ontology, path string, catalog *Catalog) (err error) {
+	var (
+		visited = map[string]bool{ont.IRI: true}
+		queue   []string
+		dirs    = make(map[string]string)
+	)
+
+	for _, iri := range ont.Imports {
+		queue = append(queue, iri)
+		dirs[iri] = filepath.Dir(path)
+	}
+
+	for len(queue) > 0 {
+		iri := queue[0]
+		queue = queue[1:]
+
+		if visited[iri] {
+			continue
+		}
+		visited[iri] = true
+
+		file, ok := catalog.Resolve(iri)
+		if !ok {
+			file, ok = localPath(dirs[iri], iri)
+		}
+		if !ok {
+			return fmt.Errorf("%w %s: it is neither listed in a catalog nor a local file", ErrUnresolvedImport, iri)
+		}
+
+		imported, err := Load(file, "")
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%w %s: file %s does not exist", ErrUnresolvedImport, iri, file)
+		} else if err != nil {
+			return fmt.Errorf("error while loading import %s: %w", iri, err)
+		}
+
+		// The imported ontology might be known under its own IRI as well, e.g., if the catalog uses a version IRI
+		visited[imported.IRI] = true
+
+		ont.Merge(imported)
+
+		for _, next := range imported.Imports {
+			if _, ok := dirs[next]; !ok {
+				dirs[next] = filepath.Dir(file)
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
+// Merge adds the prefixes and axioms of the other ontology. The abbreviated IRIs of the other ontology are expanded
+// using its own prefixes first, since a prefix may be declared differently in both ontologies, e.g., the default
+// prefix ":". Only the prefixes that are not declared differently in the ontology are added.
+func (ont *Ontology) Merge(other *Ontology) {
+	other.expandAbbreviatedIRIs()
+
+	for _, p := range other.Prefixes {
+		if i := prefixIndex(ont.Prefixes, p.Name); i == -1 {
+			ont.Prefixes = append(ont.Prefixes, p)
+		}
+	}
+
+	ont.Declarations = append(ont.Declarations, other.Declarations...)
+	ont.SubClasses = append(ont.SubClasses, other.SubClasses...)
+	ont.SubObjectProperties = append(ont.SubObjectProperties, other.SubObjectProperties...)
+	ont.SubDataProperties = append(ont.SubDataProperties, other.SubDataProperties...)
+	ont.EquivalentClasses = append(ont.EquivalentClasses, other.EquivalentClasses...)
+	ont.DatatypeDefinitions = append(ont.DatatypeDefinitions, other.DatatypeDefinitions...)
+	ont.AnnotationAssertion = append(ont.AnnotationAssertion, other.AnnotationAssertion...)
+	ont.Unsupported = append(ont.Unsupported, other.Unsupported...)
+}
+
+// expandAbbreviatedIRIs replaces all abbreviated IRIs of the axioms, i.e., the AbbreviatedIRI fields of entities,
+// datatypes and annotations, by the full IRI, using the prefixes of the ontology. Abbreviated IRIs whose prefix is
+// not declared, e.g., well-known prefixes such as "xsd", are kept.
+func (ont *Ontology) expandAbbreviatedIRIs() {
+	var expand func(v reflect.Value)
+
+	expand = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Pointer:
+			if !v.IsNil() {
+				expand(v.Elem())
+			}
+		case reflect.Slice:
+			for i := 0; i < v.Len(); i++ {
+				expand(v.Index(i))
+			}
+		case reflect.Struct:
+			abbreviated, full := v.FieldByName("AbbreviatedIRI"), v.FieldByName("IRI")
+			if abbreviated.Kind() == reflect.String && full.Kind() == reflect.String && abbreviated.String() != "" {
+				prefix, name, _ := strings.Cut(abbreviated.String(), ":")
+				if i := prefixIndex(ont.Prefixes, prefix); i != -1 {
+					full.SetString(ont.Prefixes[i].IRI + name)
+					abbreviated.SetString("")
+				}
+			}
+
+			for i := 0; i < v.NumField(); i++ {
+				if v.Type().Field(i).IsExported() {
+					expand(v.Field(i))
+				}
+			}
+		}
+	}
+
+	expand(reflect.ValueOf(ont.Declarations))
+	expand(reflect.ValueOf(ont.SubClasses))
+	expand(reflect.ValueOf(ont.SubObjectProperties))
+	expand(reflect.ValueOf(ont.SubDataProperties))
+	expand(reflect.ValueOf(ont.EquivalentClasses))
+	expand(reflect.ValueOf(ont.DatatypeDefinitions))
+	expand(reflect.ValueOf(ont.AnnotationAssertion))
+	expand(reflect.ValueOf(ont.Unsupported))
+}
+
+// prefixIndex returns the index of the prefix with the given name or -1, if there is no such prefix.
+func prefixIndex(prefixes []Prefix, name string) int {
+	for i, p := range prefixes {
+		if p.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// localPath returns the local file that is referenced by the IRI, which is either a file IRI or a path relative to
+// dir. Other absolute IRIs, such as HTTP IRIs, do not reference a local file.
+func localPath(dir, iri string) (path string, ok bool) {
+	if filepath.IsAbs(iri) {
+		return iri, true
+	}
+
+	u, err := url.Parse(iri)
+	if err != nil {
+		return "", false
+	}
+
+	switch u.Scheme {
+	case "file":
+		return filepath.FromSlash(u.Path), true
+	case "":
+		return filepath.Join(dir, filepath.FromSlash(iri)), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,193 @@
+package owl
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResolveImports(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"main.ofn": `Prefix(ex:=<http://example.com/cloud/>)
+Ontology(<http://example.com/cloud>
+	Import(<http://example.com/core>)
+	Declaration(Class(ex:Storage))
+	SubClassOf(ex:Storage ex:Resource)
+)`,
+		"core.ofn": `Prefix(ex:=<http://example.com/cloud/>)
+Ontology(<http://example.com/core>
+	Import(<base.ofn>)
+	Import(<http://example.com/cloud>)
+	Declaration(Class(ex:Resource))
+)`,
+		"base.ofn": `Prefix(ex:=<http://example.com/cloud/>)
+Ontology(<http://example.com/base>
+	Declaration(DataProperty(ex:name))
+)`,
+		"conflict.ofn": `Prefix(ex:=<http://example.com/other/>)
+Prefix(:=<http://example.com/core#>)
+Ontology(<http://example.com/conflict>
+	Declaration(Class(:Component))
+	SubClassOf(:Component ex:Thing)
+	SubClassOf(:Component DataSomeValuesFrom(:id xsd:string))
+)`,
+		CatalogFile: `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<catalog prefer="public" xmlns="urn:oasis:names:tc:entity:xmlns:xml:catalog">
+    <group id="Folder Repository" prefer="public">
+        <uri id="User Entered Import Resolution" name="http://example.com/core" uri="core.ofn"/>
+    </group>
+    <uri name="http://example.com/conflict" uri="conflict.ofn"/>
+</catalog>`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	catalog, err := ReadCatalog(filepath.Join(dir, CatalogFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type args struct {
+		imports []string
+		catalog *Catalog
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []Declaration
+		wantErr error
+	}{
+		{
+			name: "Transitive imports via catalog and relative IRI",
+			args: args{
+				imports: []string{"http://example.com/core"},
+				catalog: catalog,
+			},
+			want: []Declaration{
+				{
+					Class:    Class{Entity{AbbreviatedIRI: "ex:Storage"}},
+					Position: Position{File: filepath.Join(dir, "main.ofn"), Line: 4, Column: 2},
+				},
+				{
+					Class:    Class{Entity{IRI: "http://example.com/cloud/Resource"}},
+					Position: Position{File: filepath.Join(dir, "core.ofn"), Line: 5, Column: 2},
+				},
+				{
+					DataProperty: DataProperty{Entity{IRI: "http://example.com/cloud/name"}},
+					Position:     Position{File: filepath.Join(dir, "base.ofn"), Line: 3, Column: 2},
+				},
+			},
+		},
+		{
+			name: "Import not in catalog",
+			args: args{
+				imports: []string{"http://example.com/core"},
+			},
+			wantErr: ErrUnresolvedImport,
+		},
+		{
+			name: "Missing file",
+			args: args{
+				imports: []string{"missing.ofn"},
+			},
+			wantErr: ErrUnresolvedImport,
+		},
+		{
+			name: "Conflicting prefixes are expanded",
+			args: args{
+				imports: []string{"http://example.com/conflict"},
+				catalog: catalog,
+			},
+			want: []Declaration{
+				{
+					Class:    Class{Entity{AbbreviatedIRI: "ex:Storage"}},
+					Position: Position{File: filepath.Join(dir, "main.ofn"), Line: 4, Column: 2},
+				},
+				{
+					Class:    Class{Entity{IRI: "http://example.com/core#Component"}},
+					Position: Position{File: filepath.Join(dir, "conflict.ofn"), Line: 4, Column: 2},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ont, err := Load(filepath.Join(dir, "main.ofn"), "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			ont.Imports = tt.args.imports
+
+			err = ResolveImports(ont, filepath.Join(dir, "main.ofn"), tt.args.catalog)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveImports() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(ont.Declarations, tt.want) {
+				t.Errorf("ResolveImports() declarations = %v, want %v", ont.Declarations, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveImports_defaultPrefix(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"cloud.ofn": `Prefix(:=<http://example.com/cloud#>)
+Prefix(core:=<http://example.com/core#>)
+Ontology(<http://example.com/cloud>
+	Import(<core.ofn>)
+	Declaration(Class(:Storage))
+	SubClassOf(:Storage core:Resource)
+)`,
+		"core.ofn": `Prefix(:=<http://example.com/core#>)
+Ontology(<http://example.com/core>
+	Declaration(Class(:Resource))
+	Declaration(DataProperty(:name))
+	SubClassOf(:Resource DataSomeValuesFrom(:name xsd:string))
+)`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ont, err := Load(filepath.Join(dir, "cloud.ofn"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ResolveImports(ont, filepath.Join(dir, "cloud.ofn"), nil)
+	if err != nil {
+		t.Fatalf("ResolveImports() error = %v", err)
+	}
+
+	wantPrefixes := []Prefix{{Name: "", IRI: "http://example.com/cloud#"}, {Name: "core", IRI: "http://example.com/core#"}}
+	if len(ont.Prefixes) < 2 || !reflect.DeepEqual(ont.Prefixes[:2], wantPrefixes) {
+		t.Errorf("ResolveImports() prefixes = %v, want %v", ont.Prefixes, wantPrefixes)
+	}
+
+	want := SubClassOf{
+		Class: []Class{{Entity{IRI: "http://example.com/core#Resource"}}},
+		DataSomeValuesFrom: []DataSomeValuesFrom{{
+			DataProperty: DataProperty{Entity{IRI: "http://example.com/core#name"}},
+			Datatype:     Datatype{IRI: "http://www.w3.org/2001/XMLSchema#string"},
+		}},
+		Position: Position{File: filepath.Join(dir, "core.ofn"), Line: 5, Column: 2},
+	}
+	if len(ont.SubClasses) != 2 || !reflect.DeepEqual(ont.SubClasses[1], want) {
+		t.Errorf("ResolveImports() sub-classes = %v, want %v", ont.SubClasses, want)
+	}
+}
@@ -76,6 +76,116 @@ func (x *EntityEntry) GetParent() []string {
 	return nil
 }
 
+type PropertyEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Iri      string   `protobuf:"bytes,1,opt,name=iri,proto3" json:"iri,omitempty"`
+	Parent   []string `protobuf:"bytes,2,rep,name=parent,proto3" json:"parent,omitempty"`
+	ClassIri string   `protobuf:"bytes,3,opt,name=class_iri,json=classIri,proto3" json:"class_iri,omitempty"`
+}
+
+func (x *PropertyEntry) Reset() {
+	*x = PropertyEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_owl_owl_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PropertyEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PropertyEntry) ProtoMessage() {}
+
+func (x *PropertyEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_owl_owl_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PropertyEntry.ProtoReflect.Descriptor instead.
+func (*PropertyEntry) Descriptor() ([]byte, []int) {
+	return file_owl_owl_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PropertyEntry) GetIri() string {
+	if x != nil {
+		return x.Iri
+	}
+	return ""
+}
+
+func (x *PropertyEntry) GetParent() []string {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+func (x *PropertyEntry) GetClassIri() string {
+	if x != nil {
+		return x.ClassIri
+	}
+	return ""
+}
+
+type IndividualEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Iri string `protobuf:"bytes,1,opt,name=iri,proto3" json:"iri,omitempty"`
+}
+
+func (x *IndividualEntry) Reset() {
+	*x = IndividualEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_owl_owl_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndividualEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndividualEntry) ProtoMessage() {}
+
+func (x *IndividualEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_owl_owl_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndividualEntry.ProtoReflect.Descriptor instead.
+func (*IndividualEntry) Descriptor() ([]byte, []int) {
+	return file_owl_owl_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IndividualEntry) GetIri() string {
+	if x != nil {
+		return x.Iri
+	}
+	return ""
+}
+
 type PrefixEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -88,7 +198,7 @@ type PrefixEntry struct {
 func (x *PrefixEntry) Reset() {
 	*x = PrefixEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_owl_owl_proto_msgTypes[1]
+		mi := &file_owl_owl_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -101,7 +211,7 @@ func (x *PrefixEntry) String() string {
 func (*PrefixEntry) ProtoMessage() {}
 
 func (x *PrefixEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_owl_owl_proto_msgTypes[1]
+	mi := &file_owl_owl_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -114,7 +224,7 @@ func (x *PrefixEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PrefixEntry.ProtoReflect.Descriptor instead.
 func (*PrefixEntry) Descriptor() ([]byte, []int) {
-	return file_owl_owl_proto_rawDescGZIP(), []int{1}
+	return file_owl_owl_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *PrefixEntry) GetPrefix() string {
@@ -142,7 +252,7 @@ type Meta struct {
 func (x *Meta) Reset() {
 	*x = Meta{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_owl_owl_proto_msgTypes[2]
+		mi := &file_owl_owl_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -155,7 +265,7 @@ func (x *Meta) String() string {
 func (*Meta) ProtoMessage() {}
 
 func (x *Meta) ProtoReflect() protoreflect.Message {
-	mi := &file_owl_owl_proto_msgTypes[2]
+	mi := &file_owl_owl_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -168,7 +278,7 @@ func (x *Meta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Meta.ProtoReflect.Descriptor instead.
 func (*Meta) Descriptor() ([]byte, []int) {
-	return file_owl_owl_proto_rawDescGZIP(), []int{2}
+	return file_owl_owl_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Meta) GetPrefixes() []*PrefixEntry {
@@ -189,12 +299,36 @@ var file_owl_owl_proto_extTypes = []protoimpl.ExtensionInfo{
 	},
 	{
 		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
-		ExtensionType: (*EntityEntry)(nil),
+		ExtensionType: (*PropertyEntry)(nil),
 		Field:         50000,
 		Name:          "owl.property",
 		Tag:           "bytes,50000,opt,name=property",
 		Filename:      "owl/owl.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.EnumOptions)(nil),
+		ExtensionType: (*EntityEntry)(nil),
+		Field:         50000,
+		Name:          "owl.enum",
+		Tag:           "bytes,50000,opt,name=enum",
+		Filename:      "owl/owl.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.EnumValueOptions)(nil),
+		ExtensionType: (*IndividualEntry)(nil),
+		Field:         50000,
+		Name:          "owl.individual",
+		Tag:           "bytes,50000,opt,name=individual",
+		Filename:      "owl/owl.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.EnumValueOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50001,
+		Name:          "owl.literal",
+		Tag:           "bytes,50001,opt,name=literal",
+		Filename:      "owl/owl.proto",
+	},
 	{
 		ExtendedType:  (*descriptorpb.FileOptions)(nil),
 		ExtensionType: (*Meta)(nil),
@@ -213,14 +347,28 @@ var (
 
 // Extension fields to descriptorpb.FieldOptions.
 var (
-	// optional owl.EntityEntry property = 50000;
+	// optional owl.PropertyEntry property = 50000;
 	E_Property = &file_owl_owl_proto_extTypes[1]
 )
 
+// Extension fields to descriptorpb.EnumOptions.
+var (
+	// optional owl.EntityEntry enum = 50000;
+	E_Enum = &file_owl_owl_proto_extTypes[2]
+)
+
+// Extension fields to descriptorpb.EnumValueOptions.
+var (
+	// optional owl.IndividualEntry individual = 50000;
+	E_Individual = &file_owl_owl_proto_extTypes[3]
+	// optional string literal = 50001;
+	E_Literal = &file_owl_owl_proto_extTypes[4]
+)
+
 // Extension fields to descriptorpb.FileOptions.
 var (
 	// optional owl.Meta meta = 50000;
-	E_Meta = &file_owl_owl_proto_extTypes[2]
+	E_Meta = &file_owl_owl_proto_extTypes[5]
 )
 
 var File_owl_owl_proto protoreflect.FileDescriptor
@@ -233,30 +381,53 @@ var file_owl_owl_proto_rawDesc = []byte{
 	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x72, 0x69, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x03, 0x69, 0x72, 0x69, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e,
 	0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x22,
-	0x37, 0x0a, 0x0b, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x16,
-	0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x72, 0x69, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x69, 0x72, 0x69, 0x22, 0x34, 0x0a, 0x04, 0x4d, 0x65, 0x74, 0x61,
-	0x12, 0x2c, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f, 0x77, 0x6c, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x3a, 0x4c,
-	0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd0, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x10, 0x2e, 0x6f, 0x77, 0x6c, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x88, 0x01, 0x01, 0x3a, 0x50, 0x0a, 0x08,
-	0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x56, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x69, 0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x69,
+	0x72, 0x69, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c,
+	0x61, 0x73, 0x73, 0x5f, 0x69, 0x72, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x6c, 0x61, 0x73, 0x73, 0x49, 0x72, 0x69, 0x22, 0x23, 0x0a, 0x0f, 0x49, 0x6e, 0x64, 0x69, 0x76,
+	0x69, 0x64, 0x75, 0x61, 0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x72,
+	0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x69, 0x72, 0x69, 0x22, 0x37, 0x0a, 0x0b,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x72, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x69, 0x72, 0x69, 0x22, 0x34, 0x0a, 0x04, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x2c, 0x0a,
+	0x08, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x6f, 0x77, 0x6c, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x08, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x3a, 0x4c, 0x0a, 0x05, 0x63,
+	0x6c, 0x61, 0x73, 0x73, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd0, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x6f, 0x77, 0x6c, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x88, 0x01, 0x01, 0x3a, 0x52, 0x0a, 0x08, 0x70, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x79, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd0, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6f,
+	0x77, 0x6c, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x88, 0x01, 0x01, 0x3a, 0x47, 0x0a,
+	0x04, 0x65, 0x6e, 0x75, 0x6d, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0xd0, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f, 0x77,
+	0x6c, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x65,
+	0x6e, 0x75, 0x6d, 0x88, 0x01, 0x01, 0x3a, 0x5c, 0x0a, 0x0a, 0x69, 0x6e, 0x64, 0x69, 0x76, 0x69,
+	0x64, 0x75, 0x61, 0x6c, 0x12, 0x21, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65,
 	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd0, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x10, 0x2e, 0x6f, 0x77, 0x6c, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x88, 0x01, 0x01, 0x3a, 0x40,
-	0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd0, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6f,
-	0x77, 0x6c, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x88, 0x01, 0x01,
-	0x42, 0x21, 0x5a, 0x1f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f,
-	0x78, 0x69, 0x73, 0x74, 0x6f, 0x2f, 0x6f, 0x77, 0x6c, 0x32, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
-	0x6f, 0x77, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x14, 0x2e, 0x6f, 0x77, 0x6c, 0x2e, 0x49, 0x6e, 0x64, 0x69, 0x76, 0x69, 0x64, 0x75, 0x61, 0x6c,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x69, 0x6e, 0x64, 0x69, 0x76, 0x69, 0x64, 0x75, 0x61,
+	0x6c, 0x88, 0x01, 0x01, 0x3a, 0x40, 0x0a, 0x07, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x12,
+	0x21, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0xd1, 0x86, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x69, 0x74, 0x65,
+	0x72, 0x61, 0x6c, 0x88, 0x01, 0x01, 0x3a, 0x40, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x1c,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd0, 0x86, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x6f, 0x77, 0x6c, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x52,
+	0x04, 0x6d, 0x65, 0x74, 0x61, 0x88, 0x01, 0x01, 0x42, 0x21, 0x5a, 0x1f, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x78, 0x69, 0x73, 0x74, 0x6f, 0x2f, 0x6f, 0x77,
+	0x6c, 0x32, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6f, 0x77, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -271,28 +442,37 @@ func file_owl_owl_proto_rawDescGZIP() []byte {
 	return file_owl_owl_proto_rawDescData
 }
 
-var file_owl_owl_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_owl_owl_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_owl_owl_proto_goTypes = []any{
-	(*EntityEntry)(nil),                 // 0: owl.EntityEntry
-	(*PrefixEntry)(nil),                 // 1: owl.PrefixEntry
-	(*Meta)(nil),                        // 2: owl.Meta
-	(*descriptorpb.MessageOptions)(nil), // 3: google.protobuf.MessageOptions
-	(*descriptorpb.FieldOptions)(nil),   // 4: google.protobuf.FieldOptions
-	(*descriptorpb.FileOptions)(nil),    // 5: google.protobuf.FileOptions
+	(*EntityEntry)(nil),                   // 0: owl.EntityEntry
+	(*PropertyEntry)(nil),                 // 1: owl.PropertyEntry
+	(*IndividualEntry)(nil),               // 2: owl.IndividualEntry
+	(*PrefixEntry)(nil),                   // 3: owl.PrefixEntry
+	(*Meta)(nil),                          // 4: owl.Meta
+	(*descriptorpb.MessageOptions)(nil),   // 5: google.protobuf.MessageOptions
+	(*descriptorpb.FieldOptions)(nil),     // 6: google.protobuf.FieldOptions
+	(*descriptorpb.EnumOptions)(nil),      // 7: google.protobuf.EnumOptions
+	(*descriptorpb.EnumValueOptions)(nil), // 8: google.protobuf.EnumValueOptions
+	(*descriptorpb.FileOptions)(nil),      // 9: google.protobuf.FileOptions
 }
 var file_owl_owl_proto_depIdxs = []int32{
-	1, // 0: owl.Meta.prefixes:type_name -> owl.PrefixEntry
-	3, // 1: owl.class:extendee -> google.protobuf.MessageOptions
-	4, // 2: owl.property:extendee -> google.protobuf.FieldOptions
-	5, // 3: owl.meta:extendee -> google.protobuf.FileOptions
-	0, // 4: owl.class:type_name -> owl.EntityEntry
-	0, // 5: owl.property:type_name -> owl.EntityEntry
-	2, // 6: owl.meta:type_name -> owl.Meta
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	4, // [4:7] is the sub-list for extension type_name
-	1, // [1:4] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3,  // 0: owl.Meta.prefixes:type_name -> owl.PrefixEntry
+	5,  // 1: owl.class:extendee -> google.protobuf.MessageOptions
+	6,  // 2: owl.property:extendee -> google.protobuf.FieldOptions
+	7,  // 3: owl.enum:extendee -> google.protobuf.EnumOptions
+	8,  // 4: owl.individual:extendee -> google.protobuf.EnumValueOptions
+	8,  // 5: owl.literal:extendee -> google.protobuf.EnumValueOptions
+	9,  // 6: owl.meta:extendee -> google.protobuf.FileOptions
+	0,  // 7: owl.class:type_name -> owl.EntityEntry
+	1,  // 8: owl.property:type_name -> owl.PropertyEntry
+	0,  // 9: owl.enum:type_name -> owl.EntityEntry
+	2,  // 10: owl.individual:type_name -> owl.IndividualEntry
+	4,  // 11: owl.meta:type_name -> owl.Meta
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	7,  // [7:12] is the sub-list for extension type_name
+	1,  // [1:7] is the sub-list for extension extendee
+	0,  // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_owl_owl_proto_init() }
@@ -314,7 +494,7 @@ func file_owl_owl_proto_init() {
 			}
 		}
 		file_owl_owl_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*PrefixEntry); i {
+			switch v := v.(*PropertyEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -326,6 +506,30 @@ func file_owl_owl_proto_init() {
 			}
 		}
 		file_owl_owl_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*IndividualEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_owl_owl_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*PrefixEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_owl_owl_proto_msgTypes[4].Exporter = func(v any, i int) any {
 			switch v := v.(*Meta); i {
 			case 0:
 				return &v.state
@@ -344,8 +548,8 @@ func file_owl_owl_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_owl_owl_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
-			NumExtensions: 3,
+			NumMessages:   5,
+			NumExtensions: 6,
 			NumServices:   0,
 		},
 		GoTypes:           file_owl_owl_proto_goTypes,
@@ -2,10 +2,36 @@ package owl
 
 // Ontology holds all information of one ontology
 type Ontology struct {
+	IRI        string `xml:"ontologyIRI,attr"`
+	VersionIRI string `xml:"versionIRI,attr"`
+
 	Prefixes            []Prefix              `xml:"Prefix"`
+	Imports             []string              `xml:"Import"`
 	Declarations        []Declaration         `xml:"Declaration"`
 	SubClasses          []SubClassOf          `xml:"SubClassOf"`
+	SubObjectProperties []SubObjectPropertyOf `xml:"SubObjectPropertyOf"`
+	SubDataProperties   []SubDataPropertyOf   `xml:"SubDataPropertyOf"`
+	EquivalentClasses   []EquivalentClasses   `xml:"EquivalentClasses"`
+	DatatypeDefinitions []DatatypeDefinition  `xml:"DatatypeDefinition"`
 	AnnotationAssertion []AnnotationAssertion `xml:"AnnotationAssertion"`
+
+	// Unsupported contains the class expressions of axioms that are ignored, because they are not supported
+	Unsupported []Unsupported `xml:"-"`
+}
+
+// Unsupported is a class expression or data range of an axiom that is not supported and therefore ignored, e.g., an
+// ObjectIntersectionOf as super-class of a SubClassOf axiom.
+type Unsupported struct {
+	// Axiom is the kind of the axiom, e.g., "SubClassOf"
+	Axiom string
+
+	// Class is the named class of the axiom, if any
+	Class Class
+
+	// Expression is the kind of the unsupported expression, e.g., "ObjectIntersectionOf"
+	Expression string
+
+	Position Position
 }
 
 type Prefix struct {
@@ -18,6 +44,8 @@ type Declaration struct {
 	ObjectProperty  ObjectProperty  `xml:"ObjectProperty"`
 	DataProperty    DataProperty    `xml:"DataProperty"`
 	NamedIndividual NamedIndividual `xml:"NamedIndividual"`
+
+	Position Position `xml:"-"`
 }
 
 type AnnotationAssertion struct {
@@ -25,12 +53,15 @@ type AnnotationAssertion struct {
 	IRI                string             `xml:"IRI"`
 	AbbreviatedIRI     string             `xml:"AbbreviatedIRI"`
 	Literal            string             `xml:"Literal"`
+
+	Position Position `xml:"-"`
 }
 
 type Literal struct {
 	Literal string `xml:"attr"`
 }
 type AnnotationProperty struct {
+	IRI            string `xml:"IRI,attr"`
 	AbbreviatedIRI string `xml:"abbreviatedIRI,attr"`
 }
 
@@ -55,12 +86,78 @@ type NamedIndividual struct {
 	Entity
 }
 
+// SubObjectPropertyOf states that the first object property is a sub-property of the second one. Property chains
+// are not supported and result in less than two object properties.
+type SubObjectPropertyOf struct {
+	ObjectProperty []ObjectProperty `xml:"ObjectProperty"`
+
+	Position Position `xml:"-"`
+}
+
+// SubDataPropertyOf states that the first data property is a sub-property of the second one.
+type SubDataPropertyOf struct {
+	DataProperty []DataProperty `xml:"DataProperty"`
+
+	Position Position `xml:"-"`
+}
+
+// EquivalentClasses states that the classes are equivalent. Only enumerations, i.e., a named class that is equivalent
+// to an ObjectOneOf, are supported.
+type EquivalentClasses struct {
+	Class       []Class       `xml:"Class"`
+	ObjectOneOf []ObjectOneOf `xml:"ObjectOneOf"`
+
+	Position Position `xml:"-"`
+}
+
+// ObjectOneOf is the enumeration of the named individuals that are the only instances of a class.
+type ObjectOneOf struct {
+	NamedIndividual []NamedIndividual `xml:"NamedIndividual"`
+}
+
+// DatatypeDefinition defines a named datatype. Only enumerations of literals (DataOneOf) and restrictions of a
+// datatype (DatatypeRestriction) are supported.
+type DatatypeDefinition struct {
+	Datatype            Datatype             `xml:"Datatype"`
+	DataOneOf           []DataOneOf          `xml:"DataOneOf"`
+	DatatypeRestriction *DatatypeRestriction `xml:"DatatypeRestriction"`
+
+	Position Position `xml:"-"`
+}
+
+// DataOneOf is the enumeration of the literals that are the only values of a datatype.
+type DataOneOf struct {
+	Literal []string `xml:"Literal"`
+}
+
+// DatatypeRestriction restricts the values of a datatype using constraining facets, e.g., xsd:minInclusive.
+type DatatypeRestriction struct {
+	Datatype         Datatype           `xml:"Datatype"`
+	FacetRestriction []FacetRestriction `xml:"FacetRestriction"`
+}
+
+// FacetRestriction is a constraining facet, identified by its full IRI, e.g.,
+// "http://www.w3.org/2001/XMLSchema#maxLength", and its value.
+type FacetRestriction struct {
+	Facet   string `xml:"facet,attr"`
+	Literal string `xml:"Literal"`
+}
+
 type SubClassOf struct {
 	Class                []Class                `xml:"Class"`
 	ObjectSomeValuesFrom []ObjectSomeValuesFrom `xml:"ObjectSomeValuesFrom"`
 	DataSomeValuesFrom   []DataSomeValuesFrom   `xml:"DataSomeValuesFrom"`
 	ObjectHasValue       []ObjectHasValue       `xml:"ObjectHasValue"`
 	DataHasValue         []DataHasValue         `xml:"DataHasValue"`
+
+	ObjectMinCardinality   []ObjectCardinality `xml:"ObjectMinCardinality"`
+	ObjectMaxCardinality   []ObjectCardinality `xml:"ObjectMaxCardinality"`
+	ObjectExactCardinality []ObjectCardinality `xml:"ObjectExactCardinality"`
+	DataMinCardinality     []DataCardinality   `xml:"DataMinCardinality"`
+	DataMaxCardinality     []DataCardinality   `xml:"DataMaxCardinality"`
+	DataExactCardinality   []DataCardinality   `xml:"DataExactCardinality"`
+
+	Position Position `xml:"-"`
 }
 
 type ObjectSomeValuesFrom struct {
@@ -69,8 +166,9 @@ type ObjectSomeValuesFrom struct {
 }
 
 type DataSomeValuesFrom struct {
-	DataProperty DataProperty `xml:"DataProperty"`
-	Datatype     Datatype     `xml:"Datatype"`
+	DataProperty        DataProperty         `xml:"DataProperty"`
+	Datatype            Datatype             `xml:"Datatype"`
+	DatatypeRestriction *DatatypeRestriction `xml:"DatatypeRestriction"`
 }
 
 type DataHasValue struct {
@@ -78,7 +176,24 @@ type DataHasValue struct {
 	Literal      string       `xml:"Literal"`
 }
 
+// ObjectCardinality is a (possibly qualified) cardinality restriction on an object property, e.g.,
+// ObjectMaxCardinality. If the restriction is not qualified, Class is empty.
+type ObjectCardinality struct {
+	Cardinality    int            `xml:"cardinality,attr"`
+	ObjectProperty ObjectProperty `xml:"ObjectProperty"`
+	Class          Class          `xml:"Class"`
+}
+
+// DataCardinality is a (possibly qualified) cardinality restriction on a data property, e.g., DataExactCardinality.
+// If the restriction is not qualified, Datatype is empty.
+type DataCardinality struct {
+	Cardinality  int          `xml:"cardinality,attr"`
+	DataProperty DataProperty `xml:"DataProperty"`
+	Datatype     Datatype     `xml:"Datatype"`
+}
+
 type Datatype struct {
+	IRI            string `xml:"IRI,attr"`
 	AbbreviatedIRI string `xml:"abbreviatedIRI,attr"`
 }
 
@@ -0,0 +1,136 @@
+package owl
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// Position is the position of an axiom in its source file. Unknown parts are zero.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// String returns the position in the form "file:line:column", omitting unknown parts.
+func (p Position) String() string {
+	switch {
+	case p.Line == 0:
+		return p.File
+	case p.File == "":
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	default:
+		return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+	}
+}
+
+// UnmarshalXML decodes a declaration and records its position.
+func (d *Declaration) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	type plain Declaration
+
+	pos := inputPos(dec)
+	err := dec.DecodeElement((*plain)(d), &start)
+	d.Position = pos
+
+	return err
+}
+
+// UnmarshalXML decodes a SubClassOf axiom and records its position.
+func (sc *SubClassOf) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	type plain SubClassOf
+
+	pos := inputPos(dec)
+	err := dec.DecodeElement((*plain)(sc), &start)
+	sc.Position = pos
+
+	return err
+}
+
+// UnmarshalXML decodes a SubObjectPropertyOf axiom and records its position.
+func (sp *SubObjectPropertyOf) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	type plain SubObjectPropertyOf
+
+	pos := inputPos(dec)
+	err := dec.DecodeElement((*plain)(sp), &start)
+	sp.Position = pos
+
+	return err
+}
+
+// UnmarshalXML decodes a SubDataPropertyOf axiom and records its position.
+func (sp *SubDataPropertyOf) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	type plain SubDataPropertyOf
+
+	pos := inputPos(dec)
+	err := dec.DecodeElement((*plain)(sp), &start)
+	sp.Position = pos
+
+	return err
+}
+
+// UnmarshalXML decodes an EquivalentClasses axiom and records its position.
+func (ec *EquivalentClasses) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	type plain EquivalentClasses
+
+	pos := inputPos(dec)
+	err := dec.DecodeElement((*plain)(ec), &start)
+	ec.Position = pos
+
+	return err
+}
+
+// UnmarshalXML decodes a DatatypeDefinition axiom and records its position.
+func (dd *DatatypeDefinition) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	type plain DatatypeDefinition
+
+	pos := inputPos(dec)
+	err := dec.DecodeElement((*plain)(dd), &start)
+	dd.Position = pos
+
+	return err
+}
+
+// UnmarshalXML decodes an annotation assertion and records its position.
+func (aa *AnnotationAssertion) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	type plain AnnotationAssertion
+
+	pos := inputPos(dec)
+	err := dec.DecodeElement((*plain)(aa), &start)
+	aa.Position = pos
+
+	return err
+}
+
+// inputPos returns the current position of the decoder, which is the end of the start element that was just read.
+func inputPos(dec *xml.Decoder) Position {
+	line, column := dec.InputPos()
+	return Position{Line: line, Column: column}
+}
+
+// setFile sets the file of all axiom positions.
+func (ont *Ontology) setFile(file string) {
+	for i := range ont.Declarations {
+		ont.Declarations[i].Position.File = file
+	}
+	for i := range ont.SubClasses {
+		ont.SubClasses[i].Position.File = file
+	}
+	for i := range ont.SubObjectProperties {
+		ont.SubObjectProperties[i].Position.File = file
+	}
+	for i := range ont.SubDataProperties {
+		ont.SubDataProperties[i].Position.File = file
+	}
+	for i := range ont.EquivalentClasses {
+		ont.EquivalentClasses[i].Position.File = file
+	}
+	for i := range ont.DatatypeDefinitions {
+		ont.DatatypeDefinitions[i].Position.File = file
+	}
+	for i := range ont.AnnotationAssertion {
+		ont.AnnotationAssertion[i].Position.File = file
+	}
+	for i := range ont.Unsupported {
+		ont.Unsupported[i].Position.File = file
+	}
+}
@@ -0,0 +1,384 @@
+package owl
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/oxisto/owl2proto/internal/util"
+	"github.com/oxisto/owl2proto/rdf"
+)
+
+// facets contains the constraining facets that can be used in datatype restrictions.
+var facets = []string{
+	rdf.NamespaceXSD + "minInclusive",
+	rdf.NamespaceXSD + "maxInclusive",
+	rdf.NamespaceXSD + "minExclusive",
+	rdf.NamespaceXSD + "maxExclusive",
+	rdf.NamespaceXSD + "length",
+	rdf.NamespaceXSD + "minLength",
+	rdf.NamespaceXSD + "maxLength",
+	rdf.NamespaceXSD + "pattern",
+	rdf.NamespaceRDF + "langRange",
+}
+
+const (
+	owlClass              = rdf.NamespaceOWL + "Class"
+	owlObjectProperty     = rdf.NamespaceOWL + "ObjectProperty"
+	owlDatatypeProperty   = rdf.NamespaceOWL + "DatatypeProperty"
+	owlAnnotationProperty = rdf.NamespaceOWL + "AnnotationProperty"
+	owlNamedIndividual    = rdf.NamespaceOWL + "NamedIndividual"
+	owlOntology           = rdf.NamespaceOWL + "Ontology"
+	owlImports            = rdf.NamespaceOWL + "imports"
+	owlVersionIRI         = rdf.NamespaceOWL + "versionIRI"
+	owlRestriction        = rdf.NamespaceOWL + "Restriction"
+	owlOnProperty         = rdf.NamespaceOWL + "onProperty"
+	owlSomeValuesFrom     = rdf.NamespaceOWL + "someValuesFrom"
+	owlHasValue           = rdf.NamespaceOWL + "hasValue"
+	owlOnClass            = rdf.NamespaceOWL + "onClass"
+	owlOnDataRange        = rdf.NamespaceOWL + "onDataRange"
+	owlEquivalentClass    = rdf.NamespaceOWL + "equivalentClass"
+	owlOneOf              = rdf.NamespaceOWL + "oneOf"
+	owlOnDatatype         = rdf.NamespaceOWL + "onDatatype"
+	owlDeprecated         = rdf.NamespaceOWL + "deprecated"
+	owlWithRestrictions   = rdf.NamespaceOWL + "withRestrictions"
+	rdfsLiteral           = rdf.NamespaceRDFS + "Literal"
+	rdfsDatatype          = rdf.NamespaceRDFS + "Datatype"
+	rdfsIsDefinedBy       = rdf.NamespaceRDFS + "isDefinedBy"
+)
+
+// FromGraph converts an RDF graph that contains an OWL ontology, e.g., read from RDF/XML or Turtle, into an [Ontology]. The
+// result has the same structure as if the ontology would have been read from OWL/XML, so that it can be prepared in
+// the same way. Entities are referenced by their full IRI, datatypes and annotation properties by their abbreviated
+// IRI.
+func FromGraph(g *rdf.Graph) *Ontology {
+	var (
+		ont      = &Ontology{}
+		declared = make(map[rdf.Triple]bool)
+	)
+
+	// Prefixes declared in the document, followed by well-known prefixes that are not declared
+	for _, prefixes := range []map[string]string{g.Prefixes, rdf.WellKnownPrefixes} {
+		for _, name := range util.SortMapKeys(prefixes) {
+			if !hasPrefix(ont.Prefixes, name) {
+				ont.Prefixes = append(ont.Prefixes, Prefix{Name: name, IRI: prefixes[name]})
+			}
+		}
+	}
+
+	for _, t := range g.Triples {
+		if !t.Subject.IsIRI() {
+			continue
+		}
+
+		var (
+			entity = Entity{IRI: t.Subject.Value}
+			pos    = Position{Line: t.Line, Column: t.Column}
+		)
+
+		switch t.Predicate.Value {
+		case rdf.Type:
+			// The same declaration might be stated several times, e.g., in different documents
+			key := rdf.Triple{Subject: t.Subject, Predicate: t.Predicate, Object: t.Object}
+			if declared[key] {
+				continue
+			}
+			declared[key] = true
+
+			switch t.Object.Value {
+			case owlClass:
+				ont.Declarations = append(ont.Declarations, Declaration{Class: Class{entity}, Position: pos})
+			case owlObjectProperty:
+				ont.Declarations = append(ont.Declarations, Declaration{ObjectProperty: ObjectProperty{entity}, Position: pos})
+			case owlDatatypeProperty:
+				ont.Declarations = append(ont.Declarations, Declaration{DataProperty: DataProperty{entity}, Position: pos})
+			case owlNamedIndividual:
+				ont.Declarations = append(ont.Declarations, Declaration{NamedIndividual: NamedIndividual{entity}, Position: pos})
+			case owlOntology:
+				ont.IRI = entity.IRI
+			}
+		case owlImports:
+			ont.Imports = append(ont.Imports, t.Object.Value)
+		case owlVersionIRI:
+			ont.VersionIRI = t.Object.Value
+		case rdf.SubClassOf:
+			if sc, ok := subClassOf(g, entity, t.Object); ok {
+				sc.Position = pos
+				ont.SubClasses = append(ont.SubClasses, sc)
+			}
+		case owlEquivalentClass:
+			// Enumerations are stated on an anonymous class or datatype
+			if head, ok := g.Object(t.Object, owlOneOf); ok && t.Object.IsBlankNode() {
+				oneOf(g, ont, t.Subject, g.HasType(t.Object, rdfsDatatype), head, pos)
+			} else if restriction, ok := datatypeRestriction(g, t.Object); ok {
+				ont.DatatypeDefinitions = append(ont.DatatypeDefinitions, DatatypeDefinition{
+					Datatype:            Datatype{AbbreviatedIRI: g.Abbreviate(t.Subject.Value)},
+					DatatypeRestriction: restriction,
+					Position:            pos,
+				})
+			}
+		case owlOneOf:
+			oneOf(g, ont, t.Subject, false, t.Object, pos)
+		case rdf.SubPropertyOf:
+			// The kind of the property is determined by its declaration
+			if !t.Object.IsIRI() {
+				continue
+			}
+
+			super := Entity{IRI: t.Object.Value}
+			if g.HasType(t.Subject, owlObjectProperty) {
+				ont.SubObjectProperties = append(ont.SubObjectProperties, SubObjectPropertyOf{
+					ObjectProperty: []ObjectProperty{{entity}, {super}},
+					Position:       pos,
+				})
+			} else if g.HasType(t.Subject, owlDatatypeProperty) {
+				ont.SubDataProperties = append(ont.SubDataProperties, SubDataPropertyOf{
+					DataProperty: []DataProperty{{entity}, {super}},
+					Position:     pos,
+				})
+			}
+		default:
+			if isAnnotationProperty(g, t.Predicate) {
+				ont.AnnotationAssertion = append(ont.AnnotationAssertion, AnnotationAssertion{
+					AnnotationProperty: AnnotationProperty{AbbreviatedIRI: g.Abbreviate(t.Predicate.Value)},
+					IRI:                t.Subject.Value,
+					Literal:            t.Object.Value,
+					Position:           pos,
+				})
+			}
+		}
+	}
+
+	return ont
+}
+
+// subClassOf converts the object of a rdfs:subClassOf triple into a [SubClassOf] axiom. The object is either a named
+// class or a blank node that contains an owl:Restriction.
+func subClassOf(g *rdf.Graph, class Entity, o rdf.Term) (sc SubClassOf, ok bool) {
+	sc.Class = []Class{{class}}
+
+	if o.IsIRI() {
+		sc.Class = append(sc.Class, Class{Entity{IRI: o.Value}})
+		return sc, true
+	}
+
+	if !g.HasType(o, owlRestriction) {
+		return sc, false
+	}
+
+	prop, ok := g.Object(o, owlOnProperty)
+	if !ok || !prop.IsIRI() {
+		return sc, false
+	}
+
+	property := Entity{IRI: prop.Value}
+
+	if v, ok := g.Object(o, owlSomeValuesFrom); ok && v.IsBlankNode() {
+		// Only datatype restrictions are supported as anonymous data range
+		restriction, ok := datatypeRestriction(g, v)
+		if !ok {
+			return sc, false
+		}
+
+		sc.DataSomeValuesFrom = append(sc.DataSomeValuesFrom, DataSomeValuesFrom{
+			DataProperty:        DataProperty{property},
+			DatatypeRestriction: restriction,
+		})
+
+		return sc, true
+	} else if ok && v.IsIRI() {
+		if isDataRestriction(g, prop, v) {
+			sc.DataSomeValuesFrom = append(sc.DataSomeValuesFrom, DataSomeValuesFrom{
+				DataProperty: DataProperty{property},
+				Datatype:     Datatype{AbbreviatedIRI: g.Abbreviate(v.Value)},
+			})
+		} else {
+			sc.ObjectSomeValuesFrom = append(sc.ObjectSomeValuesFrom, ObjectSomeValuesFrom{
+				ObjectProperty: ObjectProperty{property},
+				Class:          Class{Entity{IRI: v.Value}},
+			})
+		}
+
+		return sc, true
+	} else if v, ok := g.Object(o, owlHasValue); ok {
+		if v.IsLiteral() {
+			sc.DataHasValue = append(sc.DataHasValue, DataHasValue{
+				DataProperty: DataProperty{property},
+				Literal:      v.Value,
+			})
+		} else if v.IsIRI() {
+			sc.ObjectHasValue = append(sc.ObjectHasValue, ObjectHasValue{
+				ObjectProperty:  ObjectProperty{property},
+				NamedIndividual: NamedIndividual{Entity{IRI: v.Value}},
+			})
+		}
+
+		return sc, true
+	}
+
+	return cardinality(g, sc, prop, o)
+}
+
+// oneOf adds the enumeration of the members of the list head as EquivalentClasses axiom (for individuals) or
+// DatatypeDefinition (for literals) of the subject.
+func oneOf(g *rdf.Graph, ont *Ontology, subject rdf.Term, datatype bool, head rdf.Term, pos Position) {
+	var (
+		members     = g.List(head)
+		individuals ObjectOneOf
+		literals    DataOneOf
+	)
+
+	datatype = datatype || g.HasType(subject, rdfsDatatype)
+
+	for _, m := range members {
+		if m.IsLiteral() {
+			datatype = true
+			literals.Literal = append(literals.Literal, m.Value)
+		} else if m.IsIRI() {
+			individuals.NamedIndividual = append(individuals.NamedIndividual, NamedIndividual{Entity{IRI: m.Value}})
+		}
+	}
+
+	if datatype {
+		ont.DatatypeDefinitions = append(ont.DatatypeDefinitions, DatatypeDefinition{
+			Datatype:  Datatype{AbbreviatedIRI: g.Abbreviate(subject.Value)},
+			DataOneOf: []DataOneOf{literals},
+			Position:  pos,
+		})
+	} else {
+		ont.EquivalentClasses = append(ont.EquivalentClasses, EquivalentClasses{
+			Class:       []Class{{Entity{IRI: subject.Value}}},
+			ObjectOneOf: []ObjectOneOf{individuals},
+			Position:    pos,
+		})
+	}
+}
+
+// datatypeRestriction converts the data range o into a [DatatypeRestriction], if o is an anonymous datatype with
+// owl:onDatatype and owl:withRestrictions.
+func datatypeRestriction(g *rdf.Graph, o rdf.Term) (*DatatypeRestriction, bool) {
+	if !o.IsBlankNode() {
+		return nil, false
+	}
+
+	datatype, ok := g.Object(o, owlOnDatatype)
+	if !ok || !datatype.IsIRI() {
+		return nil, false
+	}
+
+	head, ok := g.Object(o, owlWithRestrictions)
+	if !ok {
+		return nil, false
+	}
+
+	restriction := &DatatypeRestriction{Datatype: Datatype{AbbreviatedIRI: g.Abbreviate(datatype.Value)}}
+	for _, m := range g.List(head) {
+		for _, facet := range facets {
+			if v, ok := g.Object(m, facet); ok && v.IsLiteral() {
+				restriction.FacetRestriction = append(restriction.FacetRestriction, FacetRestriction{
+					Facet:   facet,
+					Literal: v.Value,
+				})
+			}
+		}
+	}
+
+	return restriction, true
+}
+
+// cardinalityPredicates contains the predicates of (qualified) cardinality restrictions, indexed by the kind of
+// restriction.
+var cardinalityPredicates = map[string][]string{
+	"min":   {rdf.NamespaceOWL + "minCardinality", rdf.NamespaceOWL + "minQualifiedCardinality"},
+	"max":   {rdf.NamespaceOWL + "maxCardinality", rdf.NamespaceOWL + "maxQualifiedCardinality"},
+	"exact": {rdf.NamespaceOWL + "cardinality", rdf.NamespaceOWL + "qualifiedCardinality"},
+}
+
+// cardinality adds the cardinality restriction o on the property prop to the SubClassOf axiom, if o is one.
+func cardinality(g *rdf.Graph, sc SubClassOf, prop rdf.Term, o rdf.Term) (SubClassOf, bool) {
+	for _, kind := range []string{"min", "max", "exact"} {
+		for _, predicate := range cardinalityPredicates[kind] {
+			v, ok := g.Object(o, predicate)
+			if !ok {
+				continue
+			}
+
+			n, err := strconv.Atoi(v.Value)
+			if err != nil || n < 0 {
+				return sc, false
+			}
+
+			var (
+				class, hasClass   = g.Object(o, owlOnClass)
+				datatype, hasData = g.Object(o, owlOnDataRange)
+				property          = Entity{IRI: prop.Value}
+			)
+
+			// Complex class expressions and data ranges are not supported as qualifier
+			if (hasClass && !class.IsIRI()) || (hasData && !datatype.IsIRI()) {
+				return sc, false
+			}
+
+			if hasData || (!hasClass && g.HasType(prop, owlDatatypeProperty)) {
+				c := DataCardinality{Cardinality: n, DataProperty: DataProperty{property}}
+				if hasData {
+					c.Datatype = Datatype{AbbreviatedIRI: g.Abbreviate(datatype.Value)}
+				}
+
+				switch kind {
+				case "min":
+					sc.DataMinCardinality = append(sc.DataMinCardinality, c)
+				case "max":
+					sc.DataMaxCardinality = append(sc.DataMaxCardinality, c)
+				default:
+					sc.DataExactCardinality = append(sc.DataExactCardinality, c)
+				}
+			} else {
+				c := ObjectCardinality{Cardinality: n, ObjectProperty: ObjectProperty{property}}
+				if hasClass {
+					c.Class = Class{Entity{IRI: class.Value}}
+				}
+
+				switch kind {
+				case "min":
+					sc.ObjectMinCardinality = append(sc.ObjectMinCardinality, c)
+				case "max":
+					sc.ObjectMaxCardinality = append(sc.ObjectMaxCardinality, c)
+				default:
+					sc.ObjectExactCardinality = append(sc.ObjectExactCardinality, c)
+				}
+			}
+
+			return sc, true
+		}
+	}
+
+	return sc, false
+}
+
+// isDataRestriction returns true if the restriction on the property with the given value refers to a data property.
+// This is the case if the property is declared as data property or if the value is a datatype.
+func isDataRestriction(g *rdf.Graph, prop rdf.Term, v rdf.Term) bool {
+	if g.HasType(prop, owlDatatypeProperty) {
+		return true
+	} else if g.HasType(prop, owlObjectProperty) {
+		return false
+	}
+
+	return v.Value == rdfsLiteral || strings.HasPrefix(v.Value, rdf.NamespaceXSD)
+}
+
+// isAnnotationProperty returns true if the predicate is a well-known annotation property or is declared as an
+// annotation property.
+func isAnnotationProperty(g *rdf.Graph, p rdf.Term) bool {
+	switch p.Value {
+	case rdf.Label, rdf.Comment, rdf.SeeAlso, rdfsIsDefinedBy, owlDeprecated:
+		return true
+	}
+
+	return g.HasType(p, owlAnnotationProperty)
+}
+
+// hasPrefix returns true if the list of prefixes contains a prefix with the given name.
+func hasPrefix(prefixes []Prefix, name string) bool {
+	return prefixIndex(prefixes, name) != -1
+}
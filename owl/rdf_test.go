@@ -0,0 +1,237 @@
+package owl
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/oxisto/owl2proto/rdf"
+)
+
+func TestFromGraph(t *testing.T) {
+	type args struct {
+		doc string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []SubClassOf
+	}{
+		{
+			name: "Cardinality restrictions",
+			args: args{
+				doc: `@prefix ex: <http://example.com/cloud/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+
+ex:name a owl:DatatypeProperty .
+
+ex:VirtualMachine rdfs:subClassOf
+	[ a owl:Restriction ; owl:onProperty ex:has ; owl:maxQualifiedCardinality "1"^^xsd:nonNegativeInteger ; owl:onClass ex:GeoLocation ] ,
+	[ a owl:Restriction ; owl:onProperty ex:name ; owl:cardinality "1"^^xsd:nonNegativeInteger ] ,
+	[ a owl:Restriction ; owl:onProperty ex:tag ; owl:minQualifiedCardinality 2 ; owl:onDataRange xsd:string ] .`,
+			},
+			want: []SubClassOf{
+				{
+					Class: []Class{{Entity{IRI: "http://example.com/cloud/VirtualMachine"}}},
+					ObjectMaxCardinality: []ObjectCardinality{{
+						Cardinality:    1,
+						ObjectProperty: ObjectProperty{Entity{IRI: "http://example.com/cloud/has"}},
+						Class:          Class{Entity{IRI: "http://example.com/cloud/GeoLocation"}},
+					}},
+				},
+				{
+					Class: []Class{{Entity{IRI: "http://example.com/cloud/VirtualMachine"}}},
+					DataExactCardinality: []DataCardinality{{
+						Cardinality:  1,
+						DataProperty: DataProperty{Entity{IRI: "http://example.com/cloud/name"}},
+					}},
+				},
+				{
+					Class: []Class{{Entity{IRI: "http://example.com/cloud/VirtualMachine"}}},
+					DataMinCardinality: []DataCardinality{{
+						Cardinality:  2,
+						DataProperty: DataProperty{Entity{IRI: "http://example.com/cloud/tag"}},
+						Datatype:     Datatype{AbbreviatedIRI: "xsd:string"},
+					}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := rdf.ParseTurtle(strings.NewReader(tt.args.doc), "")
+			if err != nil {
+				t.Fatalf("ParseTurtle() error = %v", err)
+			}
+
+			got := FromGraph(g).SubClasses
+			for i := range got {
+				got[i].Position = Position{}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromGraph() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromGraph_subProperties(t *testing.T) {
+	g, err := rdf.ParseTurtle(strings.NewReader(`@prefix ex: <http://example.com/cloud/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+
+ex:hasMultiple a owl:ObjectProperty ; rdfs:subPropertyOf ex:has .
+ex:displayName a owl:DatatypeProperty ; rdfs:subPropertyOf ex:name .
+ex:label rdfs:subPropertyOf rdfs:label .`), "")
+	if err != nil {
+		t.Fatalf("ParseTurtle() error = %v", err)
+	}
+
+	ont := FromGraph(g)
+
+	wantObject := []SubObjectPropertyOf{{
+		ObjectProperty: []ObjectProperty{
+			{Entity{IRI: "http://example.com/cloud/hasMultiple"}},
+			{Entity{IRI: "http://example.com/cloud/has"}},
+		},
+		Position: Position{Line: 5, Column: 39},
+	}}
+	if !reflect.DeepEqual(ont.SubObjectProperties, wantObject) {
+		t.Errorf("FromGraph() sub object properties = %+v, want %+v", ont.SubObjectProperties, wantObject)
+	}
+
+	wantData := []SubDataPropertyOf{{
+		DataProperty: []DataProperty{
+			{Entity{IRI: "http://example.com/cloud/displayName"}},
+			{Entity{IRI: "http://example.com/cloud/name"}},
+		},
+		Position: Position{Line: 6, Column: 41},
+	}}
+	if !reflect.DeepEqual(ont.SubDataProperties, wantData) {
+		t.Errorf("FromGraph() sub data properties = %+v, want %+v", ont.SubDataProperties, wantData)
+	}
+}
+
+func TestFromGraph_enumerations(t *testing.T) {
+	g, err := rdf.ParseTurtle(strings.NewReader(`@prefix ex: <http://example.com/cloud/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+
+ex:Severity a owl:Class ; owl:equivalentClass [ a owl:Class ; owl:oneOf ( ex:High ex:Low ) ] .
+ex:Level a owl:Class ; owl:oneOf ( ex:One ) .
+ex:TLSVersion a rdfs:Datatype ; owl:equivalentClass [ a rdfs:Datatype ; owl:oneOf ( "tls1.2" "tls1.3" ) ] .`), "")
+	if err != nil {
+		t.Fatalf("ParseTurtle() error = %v", err)
+	}
+
+	ont := FromGraph(g)
+	for i := range ont.EquivalentClasses {
+		ont.EquivalentClasses[i].Position = Position{}
+	}
+	for i := range ont.DatatypeDefinitions {
+		ont.DatatypeDefinitions[i].Position = Position{}
+	}
+
+	wantClasses := []EquivalentClasses{
+		{
+			Class: []Class{{Entity{IRI: "http://example.com/cloud/Severity"}}},
+			ObjectOneOf: []ObjectOneOf{{NamedIndividual: []NamedIndividual{
+				{Entity{IRI: "http://example.com/cloud/High"}},
+				{Entity{IRI: "http://example.com/cloud/Low"}},
+			}}},
+		},
+		{
+			Class:       []Class{{Entity{IRI: "http://example.com/cloud/Level"}}},
+			ObjectOneOf: []ObjectOneOf{{NamedIndividual: []NamedIndividual{{Entity{IRI: "http://example.com/cloud/One"}}}}},
+		},
+	}
+	if !reflect.DeepEqual(ont.EquivalentClasses, wantClasses) {
+		t.Errorf("FromGraph() equivalent classes = %+v, want %+v", ont.EquivalentClasses, wantClasses)
+	}
+
+	wantDatatypes := []DatatypeDefinition{{
+		Datatype:  Datatype{AbbreviatedIRI: "ex:TLSVersion"},
+		DataOneOf: []DataOneOf{{Literal: []string{"tls1.2", "tls1.3"}}},
+	}}
+	if !reflect.DeepEqual(ont.DatatypeDefinitions, wantDatatypes) {
+		t.Errorf("FromGraph() datatype definitions = %+v, want %+v", ont.DatatypeDefinitions, wantDatatypes)
+	}
+}
+
+func TestFromGraph_datatypeRestrictions(t *testing.T) {
+	g, err := rdf.ParseTurtle(strings.NewReader(`@prefix ex: <http://example.com/cloud/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+
+ex:Port a rdfs:Datatype ;
+    owl:equivalentClass [ a rdfs:Datatype ; owl:onDatatype xsd:integer ; owl:withRestrictions ( [ xsd:minInclusive 1 ] [ xsd:maxInclusive 65535 ] ) ] .
+ex:name a owl:DatatypeProperty .
+ex:Resource a owl:Class ;
+    rdfs:subClassOf [ a owl:Restriction ; owl:onProperty ex:name ;
+        owl:someValuesFrom [ a rdfs:Datatype ; owl:onDatatype xsd:string ; owl:withRestrictions ( [ xsd:maxLength 255 ] ) ] ] .`), "")
+	if err != nil {
+		t.Fatalf("ParseTurtle() error = %v", err)
+	}
+
+	ont := FromGraph(g)
+	for i := range ont.DatatypeDefinitions {
+		ont.DatatypeDefinitions[i].Position = Position{}
+	}
+	for i := range ont.SubClasses {
+		ont.SubClasses[i].Position = Position{}
+	}
+
+	wantDatatypes := []DatatypeDefinition{{
+		Datatype: Datatype{AbbreviatedIRI: "ex:Port"},
+		DatatypeRestriction: &DatatypeRestriction{
+			Datatype: Datatype{AbbreviatedIRI: "xsd:integer"},
+			FacetRestriction: []FacetRestriction{
+				{Facet: "http://www.w3.org/2001/XMLSchema#minInclusive", Literal: "1"},
+				{Facet: "http://www.w3.org/2001/XMLSchema#maxInclusive", Literal: "65535"},
+			},
+		},
+	}}
+	if !reflect.DeepEqual(ont.DatatypeDefinitions, wantDatatypes) {
+		t.Errorf("FromGraph() datatype definitions = %+v, want %+v", ont.DatatypeDefinitions, wantDatatypes)
+	}
+
+	wantSubClasses := []SubClassOf{{
+		Class: []Class{{Entity{IRI: "http://example.com/cloud/Resource"}}},
+		DataSomeValuesFrom: []DataSomeValuesFrom{{
+			DataProperty: DataProperty{Entity{IRI: "http://example.com/cloud/name"}},
+			DatatypeRestriction: &DatatypeRestriction{
+				Datatype:         Datatype{AbbreviatedIRI: "xsd:string"},
+				FacetRestriction: []FacetRestriction{{Facet: "http://www.w3.org/2001/XMLSchema#maxLength", Literal: "255"}},
+			},
+		}},
+	}}
+	if !reflect.DeepEqual(ont.SubClasses, wantSubClasses) {
+		t.Errorf("FromGraph() sub-classes = %+v, want %+v", ont.SubClasses, wantSubClasses)
+	}
+}
+
+func TestFromGraph_deprecated(t *testing.T) {
+	g, err := rdf.ParseTurtle(strings.NewReader(`@prefix ex: <http://example.com/cloud/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+
+ex:Storage a owl:Class ; owl:deprecated true .`), "")
+	if err != nil {
+		t.Fatalf("ParseTurtle() error = %v", err)
+	}
+
+	ont := FromGraph(g)
+
+	want := []AnnotationAssertion{{
+		AnnotationProperty: AnnotationProperty{AbbreviatedIRI: "owl:deprecated"},
+		IRI:                "http://example.com/cloud/Storage",
+		Literal:            "true",
+		Position:           Position{Line: 4, Column: 26},
+	}}
+	if !reflect.DeepEqual(ont.AnnotationAssertion, want) {
+		t.Errorf("FromGraph() annotations = %+v, want %+v", ont.AnnotationAssertion, want)
+	}
+}
@@ -0,0 +1,409 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/oxisto/owl2proto/rdf"
+)
+
+var (
+	// durationPattern matches the lexical form of an xsd:duration that only consists of days and time. Years and
+	// months cannot be represented by a google.protobuf.Duration, since their length varies.
+	durationPattern = regexp.MustCompile(`^(-)?P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)(?:\.(\d{1,9}))?S)?)?$`)
+
+	// localDateTimeLayout is the layout of an xsd:dateTime without time zone, which is interpreted as UTC.
+	localDateTimeLayout = "2006-01-02T15:04:05.999999999"
+)
+
+// Decoder converts the nodes of an RDF graph into messages, based on the (owl.class) and (owl.property) options of
+// the messages. It is the reverse of the [Encoder].
+type Decoder struct {
+	// Base is removed from the IRIs of resources to form their ID, e.g., "http://example.com/resources/". IRIs that do
+	// not start with the base are used as ID as they are.
+	Base string
+
+	// Graph contains the triples to decode
+	Graph *rdf.Graph
+
+	// decoding contains the nodes that are currently decoded, to detect cyclic references
+	decoding map[rdf.Term]bool
+}
+
+// NewDecoder creates a new decoder for the graph.
+func NewDecoder(g *rdf.Graph, base string) *Decoder {
+	return &Decoder{Base: base, Graph: g}
+}
+
+// Unmarshal parses the RDF document in the given format and returns a message of the message type for every node
+// whose type is the class of the message type. For abstract classes, every node whose type is one of the classes in
+// the oneof "type" is returned. N-Triples is parsed as Turtle, since it is a subset of it.
+func Unmarshal(format rdf.Format, b []byte, base string, mt protoreflect.MessageType) ([]proto.Message, error) {
+	var (
+		g   *rdf.Graph
+		err error
+	)
+
+	switch format {
+	case rdf.FormatNTriples, rdf.FormatTurtle:
+		g, err = rdf.ParseTurtle(bytes.NewReader(b), base)
+		if err != nil {
+			return nil, fmt.Errorf("error while parsing %s: %w", format, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+
+	return NewDecoder(g, base).DecodeAll(mt)
+}
+
+// DecodeAll returns a message of the message type for every node of the graph that has a matching class, in the
+// order of their first occurrence.
+func (d *Decoder) DecodeAll(mt protoreflect.MessageType) (msgs []proto.Message, err error) {
+	seen := make(map[rdf.Term]bool)
+
+	for _, t := range d.Graph.Triples {
+		if t.Predicate.Value != rdf.Type || seen[t.Subject] {
+			continue
+		}
+		seen[t.Subject] = true
+
+		if _, c := branch(mt.Descriptor(), d.types(t.Subject)); c == nil {
+			continue
+		}
+
+		msg := mt.New().Interface()
+
+		err = d.Decode(t.Subject, msg)
+		if err != nil {
+			return nil, err
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	return
+}
+
+// Decode populates the message with the properties of the node. If the message is of an abstract class, the branch
+// of its oneof "type" is chosen whose class matches the most specific type of the node. The values of properties are
+// converted as follows:
+//
+//   - Literals are parsed according to the kind of the field, e.g., "xsd:long" literals into int64 fields.
+//   - "xsd:dateTime" and "xsd:duration" literals are converted into timestamps and durations.
+//   - IRIs of individuals and literals of enum values are converted into enum values.
+//   - Nodes are decoded recursively into embedded messages, if their type matches the class of the field.
+//   - IRIs are converted into the ID of the resource (e.g., for "storage_id") or kept as they are (e.g., for
+//     "storage_iri").
+//
+// If the message has an "id" field that is not set by a property, it is set to the ID of the node. An error is
+// returned if the node has no matching class, if a value cannot be converted or if a single field has several values.
+func (d *Decoder) Decode(node rdf.Term, msg proto.Message) (err error) {
+	if d.decoding == nil {
+		d.decoding = make(map[rdf.Term]bool)
+	}
+
+	if d.decoding[node] {
+		return fmt.Errorf("cyclic reference to node %s", node)
+	}
+
+	d.decoding[node] = true
+	defer delete(d.decoding, node)
+
+	m := msg.ProtoReflect()
+
+	path, c := branch(m.Descriptor(), d.types(node))
+	if c == nil {
+		return fmt.Errorf("node %s has no class of message %s", node, m.Descriptor().FullName())
+	}
+
+	for _, fd := range path {
+		m = m.Mutable(fd).Message()
+	}
+
+	var seen = make(map[string]bool)
+
+	for _, p := range c.Properties() {
+		if seen[p.IRI] {
+			continue
+		}
+		seen[p.IRI] = true
+
+		err = d.decodeProperty(node, m, c, p.IRI)
+		if err != nil {
+			return err
+		}
+	}
+
+	if fd := m.Descriptor().Fields().ByName(IDField); fd != nil && fd.Kind() == protoreflect.StringKind && !m.Has(fd) && node.IsIRI() {
+		m.Set(fd, protoreflect.ValueOfString(d.id(node.Value)))
+	}
+
+	return nil
+}
+
+// decodeProperty sets the fields of the property to the objects of the node. Since several fields can have the same
+// property if it has several ranges, each object is converted into the first field that accepts it.
+func (d *Decoder) decodeProperty(node rdf.Term, m protoreflect.Message, c *Class, iri string) error {
+	fields := c.FieldsByIRI(iri)
+
+	for _, o := range d.Graph.Objects(node, iri) {
+		var (
+			fd  protoreflect.FieldDescriptor
+			v   protoreflect.Value
+			err error
+		)
+
+		for _, fd = range fields {
+			if fd.IsMap() {
+				continue
+			}
+
+			v, err = d.value(PropertyOf(fd), m, o)
+			if err == nil {
+				break
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("could not decode %s of property <%s> of node %s: %w", o, iri, node, err)
+		} else if !v.IsValid() {
+			continue
+		}
+
+		if fd.IsList() {
+			m.Mutable(fd).List().Append(v)
+		} else if m.Has(fd) {
+			return fmt.Errorf("node %s has several values of property <%s>, but field %s is not repeated", node, iri, fd.Name())
+		} else {
+			m.Set(fd, v)
+		}
+	}
+
+	return nil
+}
+
+// value returns the value of the field of the property for the object.
+func (d *Decoder) value(p *Property, m protoreflect.Message, o rdf.Term) (v protoreflect.Value, err error) {
+	fd := p.Descriptor
+
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		child := newMessage(m, fd)
+
+		switch fd.Message().FullName() {
+		case timestampMessage:
+			err = parseTimestamp(child, o)
+		case durationMessage:
+			err = parseDuration(child, o)
+		default:
+			if o.IsLiteral() {
+				return v, fmt.Errorf("literal cannot be decoded into message %s", fd.Message().FullName())
+			}
+
+			err = d.Decode(o, child.Interface())
+		}
+
+		return protoreflect.ValueOfMessage(child), err
+	case protoreflect.EnumKind:
+		return enumValue(fd.Enum(), o)
+	case protoreflect.StringKind:
+		if ref, ok := referenceKind(p); ok {
+			if !o.IsIRI() {
+				return v, fmt.Errorf("reference is not an IRI")
+			} else if ref == "iri" {
+				return protoreflect.ValueOfString(o.Value), nil
+			}
+
+			return protoreflect.ValueOfString(d.id(o.Value)), nil
+		}
+	}
+
+	if !o.IsLiteral() {
+		return v, fmt.Errorf("field %s requires a literal", fd.Name())
+	}
+
+	return parseLiteral(fd, o)
+}
+
+// id returns the ID of the resource with the IRI.
+func (d *Decoder) id(iri string) string {
+	if id, ok := strings.CutPrefix(iri, d.Base); ok && d.Base != "" && id != "" {
+		return id
+	}
+
+	return iri
+}
+
+// types returns the IRIs of the types of the node.
+func (d *Decoder) types(node rdf.Term) map[string]bool {
+	types := make(map[string]bool)
+
+	for _, o := range d.Graph.Objects(node, rdf.Type) {
+		types[o.Value] = true
+	}
+
+	return types
+}
+
+// branch returns the most specific class among the types that the message can represent, i.e., its own class or the
+// class of a message in its oneof "type", recursively. The path contains the fields of the oneof "type" that lead to
+// the message of the class. If no class matches, nil is returned.
+func branch(md protoreflect.MessageDescriptor, types map[string]bool) (path []protoreflect.FieldDescriptor, c *Class) {
+	if c = ClassOf(md); c != nil {
+		if types[c.IRI] {
+			return nil, c
+		}
+
+		return nil, nil
+	}
+
+	od := md.Oneofs().ByName(TypeOneof)
+	if od == nil {
+		return nil, nil
+	}
+
+	for i := 0; i < od.Fields().Len(); i++ {
+		fd := od.Fields().Get(i)
+		if fd.Message() == nil {
+			continue
+		}
+
+		// The class with the most super-classes is the most specific one
+		if sub, bc := branch(fd.Message(), types); bc != nil && (c == nil || len(bc.Parents) > len(c.Parents)) {
+			path, c = append([]protoreflect.FieldDescriptor{fd}, sub...), bc
+		}
+	}
+
+	return
+}
+
+// newMessage returns a new message of the (element) type of the field.
+func newMessage(m protoreflect.Message, fd protoreflect.FieldDescriptor) protoreflect.Message {
+	if fd.IsList() {
+		return m.NewField(fd).List().NewElement().Message()
+	}
+
+	return m.NewField(fd).Message()
+}
+
+// enumValue returns the enum value whose individual or literal is the object.
+func enumValue(ed protoreflect.EnumDescriptor, o rdf.Term) (v protoreflect.Value, err error) {
+	values := ed.Values()
+
+	for i := 0; i < values.Len(); i++ {
+		vd := values.Get(i)
+
+		if obj := enumObject(vd, vd.Number()); obj.Kind == o.Kind && obj.Value == o.Value {
+			return protoreflect.ValueOfEnum(vd.Number()), nil
+		}
+	}
+
+	return v, fmt.Errorf("no value of enum %s matches", ed.FullName())
+}
+
+// parseLiteral parses the lexical form of the literal according to the kind of the field.
+func parseLiteral(fd protoreflect.FieldDescriptor, o rdf.Term) (v protoreflect.Value, err error) {
+	var s = strings.TrimSpace(o.Value)
+
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(o.Value), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		i, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(i)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		i, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(i), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		u, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(u)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		u, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(u), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.BytesKind:
+		var b []byte
+		if o.Datatype == rdf.NamespaceXSD+"hexBinary" {
+			b, err = hex.DecodeString(s)
+		} else {
+			b, err = base64.StdEncoding.DecodeString(s)
+		}
+
+		return protoreflect.ValueOfBytes(b), err
+	default:
+		return v, fmt.Errorf("unsupported kind %s", fd.Kind())
+	}
+}
+
+// parseTimestamp sets the google.protobuf.Timestamp to the xsd:dateTime literal. Literals without time zone are
+// interpreted as UTC.
+func parseTimestamp(m protoreflect.Message, o rdf.Term) error {
+	if !o.IsLiteral() {
+		return fmt.Errorf("timestamp requires a literal")
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, o.Value)
+	if err != nil && !strings.ContainsAny(o.Value[min(len(o.Value), len("2006-01-02T15:04:05")):], "Z+-") {
+		t, err = time.Parse(localDateTimeLayout, o.Value)
+	}
+	if err != nil {
+		return err
+	}
+
+	setSecondsAndNanos(m, t.Unix(), int64(t.Nanosecond()))
+
+	return nil
+}
+
+// parseDuration sets the google.protobuf.Duration to the xsd:duration literal, e.g., "PT90.5S" or "P1DT2H".
+func parseDuration(m protoreflect.Message, o rdf.Term) error {
+	var seconds, nanos int64
+
+	match := durationPattern.FindStringSubmatch(o.Value)
+	if !o.IsLiteral() || match == nil || strings.HasSuffix(o.Value, "P") || strings.HasSuffix(o.Value, "T") {
+		return fmt.Errorf("%q is not a duration of days and time", o.Value)
+	}
+
+	for i, unit := range []int64{24 * 60 * 60, 60 * 60, 60, 1} {
+		n, _ := strconv.ParseInt("0"+match[i+2], 10, 64)
+		seconds += n * unit
+	}
+
+	if match[6] != "" {
+		nanos, _ = strconv.ParseInt(match[6]+strings.Repeat("0", 9-len(match[6])), 10, 64)
+	}
+
+	if match[1] == "-" {
+		seconds, nanos = -seconds, -nanos
+	}
+
+	setSecondsAndNanos(m, seconds, nanos)
+
+	return nil
+}
+
+// setSecondsAndNanos sets the fields of a timestamp or duration message.
+func setSecondsAndNanos(m protoreflect.Message, seconds int64, nanos int64) {
+	fields := m.Descriptor().Fields()
+
+	m.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(seconds))
+	m.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(int32(nanos)))
+}
@@ -0,0 +1,184 @@
+package runtime
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/oxisto/owl2proto/example"
+	"github.com/oxisto/owl2proto/rdf"
+)
+
+func TestUnmarshal(t *testing.T) {
+	vm := testMessage(t, "VirtualMachine", `
+		id: "vm1"
+		name: "My \"VM\""
+		cores: 4
+		load: 0.1
+		enabled: true
+		created_at { seconds: 1700000000 nanos: 500000000 }
+		uptime { seconds: -90 nanos: -500000000 }
+		level: LEVEL_HIGH
+		block_storage_ids: ["bs1", "urn:storage:bs2"]
+		owner_iri: "http://example.com/people/alice"
+		geo_location { region: "eu" }
+	`)
+	mt := vm.ProtoReflect().Type()
+
+	type args struct {
+		format rdf.Format
+		doc    string
+		mt     protoreflect.MessageType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []proto.Message
+		wantErr bool
+	}{
+		{
+			name: "Round-trip from N-Triples",
+			args: args{
+				format: rdf.FormatNTriples,
+				doc:    marshal(t, rdf.FormatNTriples, vm),
+				mt:     mt,
+			},
+			want: []proto.Message{vm},
+		},
+		{
+			name: "Round-trip from Turtle",
+			args: args{
+				format: rdf.FormatTurtle,
+				doc:    marshal(t, rdf.FormatTurtle, vm),
+				mt:     mt,
+			},
+			want: []proto.Message{vm},
+		},
+		{
+			name: "Abstract class",
+			args: args{
+				format: rdf.FormatTurtle,
+				doc: `@prefix ex: <http://example.com/cloud/> .
+<http://example.com/resources/bs1> a ex:BlockStorage, ex:Storage, ex:Resource ; ex:name "bs1" .
+<http://example.com/resources/vm1> a ex:VirtualMachine ; ex:name "vm1" ;
+	ex:hasMultiple <http://example.com/resources/bs1> ;
+	ex:has [ a ex:GeoLocation ] .
+<http://example.com/cloud/Europe> a ex:GeoLocation .`,
+				mt: (&example.Resource{}).ProtoReflect().Type(),
+			},
+			want: []proto.Message{
+				&example.Resource{Type: &example.Resource_BlockStorage{BlockStorage: &example.BlockStorage{Name: "bs1"}}},
+				&example.Resource{Type: &example.Resource_VirtualMachine{VirtualMachine: &example.VirtualMachine{
+					Name:            "vm1",
+					BlockStorageIds: []string{"bs1"},
+					GeoLocation:     &example.GeoLocation{},
+				}}},
+			},
+		},
+		{
+			name: "Local date time and days",
+			args: args{
+				format: rdf.FormatTurtle,
+				doc: `@prefix ex: <http://example.com/cloud/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<urn:vm:2> a ex:VirtualMachine ; ex:level "LEVEL_UNSPECIFIED" ;
+	ex:createdAt "2023-11-14T22:13:20"^^xsd:dateTime ; ex:uptime "P1DT1M"^^xsd:duration .`,
+				mt: mt,
+			},
+			want: []proto.Message{testMessage(t, "VirtualMachine", `
+				id: "urn:vm:2"
+				created_at { seconds: 1700000000 }
+				uptime { seconds: 86460 }
+			`)},
+		},
+		{
+			name: "Invalid literal",
+			args: args{
+				format: rdf.FormatTurtle,
+				doc:    `<urn:vm:1> a <http://example.com/cloud/VirtualMachine> ; <http://example.com/cloud/cores> "many" .`,
+				mt:     mt,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Duration with months",
+			args: args{
+				format: rdf.FormatTurtle,
+				doc:    `<urn:vm:1> a <http://example.com/cloud/VirtualMachine> ; <http://example.com/cloud/uptime> "P1M" .`,
+				mt:     mt,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Several values",
+			args: args{
+				format: rdf.FormatTurtle,
+				doc:    `<urn:vm:1> a <http://example.com/cloud/VirtualMachine> ; <http://example.com/cloud/name> "a", "b" .`,
+				mt:     mt,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Embedded node without class",
+			args: args{
+				format: rdf.FormatTurtle,
+				doc:    `<urn:vm:1> a <http://example.com/cloud/VirtualMachine> ; <http://example.com/cloud/has> <urn:geo:1> .`,
+				mt:     mt,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unsupported format",
+			args: args{
+				format: rdf.FormatJSONLD,
+				doc:    `{}`,
+				mt:     mt,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Unmarshal(tt.args.format, []byte(tt.args.doc), "http://example.com/resources/", tt.args.mt)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Unmarshal() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !proto.Equal(got[i], tt.want[i]) {
+					t.Errorf("Unmarshal()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDecoder_Decode_cycle(t *testing.T) {
+	g := rdf.NewGraph()
+	vm := rdf.NewIRI("urn:vm:1")
+
+	g.Add(vm, rdf.NewIRI(rdf.Type), rdf.NewIRI("http://example.com/cloud/VirtualMachine"))
+	g.Add(vm, rdf.NewIRI(rdf.Type), rdf.NewIRI("http://example.com/cloud/GeoLocation"))
+	g.Add(vm, rdf.NewIRI("http://example.com/cloud/has"), vm)
+
+	err := NewDecoder(g, "").Decode(vm, &example.VirtualMachine{})
+	if err == nil {
+		t.Errorf("Decode() error = %v, wantErr true", err)
+	}
+}
+
+// marshal returns the RDF representation of the message.
+func marshal(t *testing.T, format rdf.Format, msg proto.Message) string {
+	t.Helper()
+
+	b, err := Marshal(format, "http://example.com/resources/", msg)
+	if err != nil {
+		t.Fatalf("could not marshal message: %v", err)
+	}
+
+	return string(b)
+}
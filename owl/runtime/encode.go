@@ -0,0 +1,304 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/oxisto/owl2proto/owl"
+	"github.com/oxisto/owl2proto/rdf"
+)
+
+const (
+	// IDField is the name of the field that contains the ID of a resource.
+	IDField = "id"
+
+	timestampMessage = "google.protobuf.Timestamp"
+	durationMessage  = "google.protobuf.Duration"
+)
+
+// kindDatatypes contains the XML schema datatypes of the literals of scalar fields, indexed by their kind.
+var kindDatatypes = map[protoreflect.Kind]string{
+	protoreflect.BoolKind:     rdf.NamespaceXSD + "boolean",
+	protoreflect.Int32Kind:    rdf.NamespaceXSD + "int",
+	protoreflect.Sint32Kind:   rdf.NamespaceXSD + "int",
+	protoreflect.Sfixed32Kind: rdf.NamespaceXSD + "int",
+	protoreflect.Int64Kind:    rdf.NamespaceXSD + "long",
+	protoreflect.Sint64Kind:   rdf.NamespaceXSD + "long",
+	protoreflect.Sfixed64Kind: rdf.NamespaceXSD + "long",
+	protoreflect.Uint32Kind:   rdf.NamespaceXSD + "unsignedInt",
+	protoreflect.Fixed32Kind:  rdf.NamespaceXSD + "unsignedInt",
+	protoreflect.Uint64Kind:   rdf.NamespaceXSD + "unsignedLong",
+	protoreflect.Fixed64Kind:  rdf.NamespaceXSD + "unsignedLong",
+	protoreflect.FloatKind:    rdf.NamespaceXSD + "float",
+	protoreflect.DoubleKind:   rdf.NamespaceXSD + "double",
+	protoreflect.StringKind:   rdf.XSDString,
+	protoreflect.BytesKind:    rdf.NamespaceXSD + "base64Binary",
+}
+
+// Encoder converts messages into RDF triples, based on the (owl.class) and (owl.property) options of the messages.
+type Encoder struct {
+	// Base is prepended to the IDs of resources to form their IRI, e.g., "http://example.com/resources/". IDs that
+	// are already absolute IRIs are used as they are.
+	Base string
+
+	// Graph contains the triples of all encoded messages
+	Graph *rdf.Graph
+}
+
+// NewEncoder creates a new encoder with an empty graph.
+func NewEncoder(base string) *Encoder {
+	return &Encoder{Base: base, Graph: rdf.NewGraph()}
+}
+
+// Marshal returns the RDF representation of the messages in the given format.
+func Marshal(format rdf.Format, base string, msgs ...proto.Message) ([]byte, error) {
+	var (
+		e   = NewEncoder(base)
+		buf bytes.Buffer
+	)
+
+	for _, msg := range msgs {
+		_, err := e.Encode(msg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := rdf.Write(&buf, e.Graph, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Encode adds the triples of the message to the graph and returns the node of the message. The node is the IRI of
+// the resource if the message has an ID, otherwise a blank node. The message has the type of its class and of all of
+// its super-classes. Fields with an (owl.property) option are added as follows:
+//
+//   - Scalar fields are added as typed literals, e.g., "xsd:long" for int64 fields.
+//   - Timestamps and durations are added as "xsd:dateTime" and "xsd:duration" literals.
+//   - Enum values are added as IRI of their individual or as their literal.
+//   - Embedded messages are encoded recursively and linked to the message.
+//   - Fields that reference a resource by its ID (e.g., "storage_id") or IRI (e.g., "storage_iri") are added as link
+//     to the resource.
+//
+// Messages of abstract classes are unwrapped. An error is returned if the message has no class.
+func (e *Encoder) Encode(msg proto.Message) (node rdf.Term, err error) {
+	unwrapped := Unwrap(msg)
+	if unwrapped == nil {
+		return rdf.Term{}, fmt.Errorf("message %s has no class", msg.ProtoReflect().Descriptor().FullName())
+	}
+
+	m := unwrapped.ProtoReflect()
+
+	c := ClassOf(m.Descriptor())
+	if c == nil {
+		return rdf.Term{}, fmt.Errorf("message %s has no class", m.Descriptor().FullName())
+	}
+
+	for prefix, ns := range c.Prefixes {
+		if _, ok := e.Graph.Prefixes[prefix]; !ok {
+			e.Graph.Prefixes[prefix] = ns
+		}
+	}
+
+	if fd := m.Descriptor().Fields().ByName(IDField); fd != nil && fd.Kind() == protoreflect.StringKind && m.Get(fd).String() != "" {
+		node = e.resource(m.Get(fd).String())
+	} else {
+		node = e.Graph.NewBlankNode()
+	}
+
+	e.Graph.Add(node, rdf.NewIRI(rdf.Type), rdf.NewIRI(c.IRI))
+	for _, parent := range c.Parents {
+		e.Graph.Add(node, rdf.NewIRI(rdf.Type), rdf.NewIRI(parent))
+	}
+
+	for _, p := range c.Properties() {
+		fd := p.Descriptor
+		if !m.Has(fd) || fd.IsMap() {
+			continue
+		}
+
+		var values []protoreflect.Value
+		if fd.IsList() {
+			list := m.Get(fd).List()
+			for i := 0; i < list.Len(); i++ {
+				values = append(values, list.Get(i))
+			}
+		} else {
+			values = append(values, m.Get(fd))
+		}
+
+		for _, v := range values {
+			o, ok, err := e.object(p, v)
+			if err != nil {
+				return rdf.Term{}, err
+			} else if ok {
+				e.Graph.Add(node, rdf.NewIRI(p.IRI), o)
+			}
+		}
+	}
+
+	return node, nil
+}
+
+// object returns the object of a triple for the value of the field of the property. If the value cannot be
+// represented, e.g., because it is an embedded message without class, ok is false.
+func (e *Encoder) object(p *Property, v protoreflect.Value) (o rdf.Term, ok bool, err error) {
+	fd := p.Descriptor
+
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch fd.Message().FullName() {
+		case timestampMessage:
+			return rdf.NewLiteral(formatTimestamp(v.Message()), rdf.NamespaceXSD+"dateTime", ""), true, nil
+		case durationMessage:
+			return rdf.NewLiteral(formatDuration(v.Message()), rdf.NamespaceXSD+"duration", ""), true, nil
+		}
+
+		// Embedded messages without class, e.g., of abstract classes without value, cannot be represented
+		if embedded := Unwrap(v.Message().Interface()); embedded == nil || ClassOf(embedded.ProtoReflect().Descriptor()) == nil {
+			return rdf.Term{}, false, nil
+		}
+
+		o, err = e.Encode(v.Message().Interface())
+		return o, err == nil, err
+	case protoreflect.EnumKind:
+		return enumObject(fd.Enum().Values().ByNumber(v.Enum()), v.Enum()), true, nil
+	case protoreflect.StringKind:
+		if ref, ok := referenceKind(p); ok {
+			if ref == "iri" {
+				return rdf.NewIRI(v.String()), true, nil
+			}
+
+			return e.resource(v.String()), true, nil
+		}
+	case protoreflect.BytesKind:
+		return rdf.NewLiteral(base64.StdEncoding.EncodeToString(v.Bytes()), kindDatatypes[fd.Kind()], ""), true, nil
+	case protoreflect.FloatKind:
+		return rdf.NewLiteral(formatFloat(v.Float(), 32), kindDatatypes[fd.Kind()], ""), true, nil
+	case protoreflect.DoubleKind:
+		return rdf.NewLiteral(formatFloat(v.Float(), 64), kindDatatypes[fd.Kind()], ""), true, nil
+	}
+
+	return rdf.NewLiteral(v.String(), kindDatatypes[fd.Kind()], ""), true, nil
+}
+
+// resource returns the IRI of the resource with the ID.
+func (e *Encoder) resource(id string) rdf.Term {
+	if isAbsoluteIRI(id) {
+		return rdf.NewIRI(id)
+	}
+
+	return rdf.NewIRI(e.Base + id)
+}
+
+// referenceKind returns whether a string field of an object property references the resource by its ID ("id") or by
+// its IRI ("iri"), based on the suffix of the field name.
+func referenceKind(p *Property) (ref string, ok bool) {
+	var object bool
+	for _, parent := range p.Parents {
+		object = object || parent == rdf.NamespaceOWL+"topObjectProperty"
+	}
+
+	if !object {
+		return "", false
+	}
+
+	name := string(p.Descriptor.Name())
+	switch {
+	case strings.HasSuffix(name, "_iri"), strings.HasSuffix(name, "_iris"):
+		return "iri", true
+	default:
+		return "id", true
+	}
+}
+
+// enumObject returns the IRI of the individual of the enum value or its literal. Values without option, e.g., the
+// unspecified value, are represented by their name.
+func enumObject(vd protoreflect.EnumValueDescriptor, number protoreflect.EnumNumber) rdf.Term {
+	if vd == nil {
+		return rdf.NewLiteral(strconv.Itoa(int(number)), "", "")
+	}
+
+	if opts, ok := vd.Options().(*descriptorpb.EnumValueOptions); ok && opts != nil {
+		if individual, _ := proto.GetExtension(opts, owl.E_Individual).(*owl.IndividualEntry); individual.GetIri() != "" {
+			return rdf.NewIRI(PrefixesOf(vd.ParentFile()).Expand(individual.GetIri()))
+		}
+
+		if literal, _ := proto.GetExtension(opts, owl.E_Literal).(string); literal != "" {
+			return rdf.NewLiteral(literal, "", "")
+		}
+	}
+
+	return rdf.NewLiteral(string(vd.Name()), "", "")
+}
+
+// formatTimestamp returns the lexical form of a google.protobuf.Timestamp as xsd:dateTime.
+func formatTimestamp(m protoreflect.Message) string {
+	seconds, nanos := secondsAndNanos(m)
+
+	return time.Unix(seconds, nanos).UTC().Format(time.RFC3339Nano)
+}
+
+// formatDuration returns the lexical form of a google.protobuf.Duration as xsd:duration, e.g., "PT90.5S".
+func formatDuration(m protoreflect.Message) string {
+	var sign string
+
+	seconds, nanos := secondsAndNanos(m)
+	if seconds < 0 || nanos < 0 {
+		sign = "-"
+		seconds, nanos = -seconds, -nanos
+	}
+
+	s := strconv.FormatInt(seconds, 10)
+	if nanos != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", nanos), "0")
+	}
+
+	return sign + "PT" + s + "S"
+}
+
+// secondsAndNanos returns the fields of a timestamp or duration message.
+func secondsAndNanos(m protoreflect.Message) (seconds int64, nanos int64) {
+	fields := m.Descriptor().Fields()
+
+	return m.Get(fields.ByName("seconds")).Int(), m.Get(fields.ByName("nanos")).Int()
+}
+
+// formatFloat returns the lexical form of a float or double, e.g., "INF" for positive infinity.
+func formatFloat(f float64, bitSize int) string {
+	switch s := strconv.FormatFloat(f, 'g', -1, bitSize); s {
+	case "+Inf":
+		return "INF"
+	case "-Inf":
+		return "-INF"
+	default:
+		return s
+	}
+}
+
+// isAbsoluteIRI returns true if the string starts with a scheme, e.g., "http:" or "urn:".
+func isAbsoluteIRI(s string) bool {
+	scheme, _, found := strings.Cut(s, ":")
+	if !found || scheme == "" {
+		return false
+	}
+
+	for i, r := range scheme {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && (r >= '0' && r <= '9' || r == '+' || r == '-' || r == '.')) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,288 @@
+package runtime
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/oxisto/owl2proto/example"
+	"github.com/oxisto/owl2proto/rdf"
+)
+
+// testFile is a proto file as generated by owl2proto in full semantic mode, which contains the field types that are
+// not contained in the example.
+const testFile = `
+name: "test/test.proto"
+package: "test.v1"
+dependency: ["owl/owl.proto", "google/protobuf/timestamp.proto", "google/protobuf/duration.proto"]
+syntax: "proto3"
+options { [owl.meta] { prefixes { prefix: "ex" iri: "http://example.com/cloud/" } } }
+enum_type {
+	name: "Level"
+	value { name: "LEVEL_UNSPECIFIED" number: 0 }
+	value { name: "LEVEL_HIGH" number: 1 options { [owl.individual] { iri: "ex:High" } } }
+	value { name: "LEVEL_LOW" number: 2 options { [owl.individual] { iri: "ex:Low" } } }
+}
+message_type {
+	name: "GeoLocation"
+	options { [owl.class] { iri: "ex:GeoLocation" parent: "owl:Thing" } }
+	field {
+		name: "region" number: 1 type: TYPE_STRING label: LABEL_OPTIONAL json_name: "region"
+		options { [owl.property] { iri: "ex:region" parent: "owl:topDataProperty" class_iri: "ex:GeoLocation" } }
+	}
+}
+message_type {
+	name: "VirtualMachine"
+	options { [owl.class] { iri: "ex:VirtualMachine" parent: ["ex:Compute", "ex:Resource", "owl:Thing"] } }
+	field {
+		name: "id" number: 1 type: TYPE_STRING label: LABEL_OPTIONAL json_name: "id"
+		options { [owl.property] { iri: "ex:id" parent: "owl:topDataProperty" class_iri: "ex:Resource" } }
+	}
+	field {
+		name: "name" number: 2 type: TYPE_STRING label: LABEL_OPTIONAL json_name: "name"
+		options { [owl.property] { iri: "ex:name" parent: "owl:topDataProperty" class_iri: "ex:Resource" } }
+	}
+	field {
+		name: "cores" number: 3 type: TYPE_INT64 label: LABEL_OPTIONAL json_name: "cores"
+		options { [owl.property] { iri: "ex:cores" parent: "owl:topDataProperty" class_iri: "ex:Compute" } }
+	}
+	field {
+		name: "load" number: 4 type: TYPE_FLOAT label: LABEL_OPTIONAL json_name: "load"
+		options { [owl.property] { iri: "ex:load" parent: "owl:topDataProperty" class_iri: "ex:Compute" } }
+	}
+	field {
+		name: "enabled" number: 5 type: TYPE_BOOL label: LABEL_OPTIONAL json_name: "enabled"
+		options { [owl.property] { iri: "ex:enabled" parent: "owl:topDataProperty" class_iri: "ex:Compute" } }
+	}
+	field {
+		name: "created_at" number: 6 type: TYPE_MESSAGE type_name: ".google.protobuf.Timestamp" label: LABEL_OPTIONAL json_name: "createdAt"
+		options { [owl.property] { iri: "ex:createdAt" parent: "owl:topDataProperty" class_iri: "ex:Resource" } }
+	}
+	field {
+		name: "uptime" number: 7 type: TYPE_MESSAGE type_name: ".google.protobuf.Duration" label: LABEL_OPTIONAL json_name: "uptime"
+		options { [owl.property] { iri: "ex:uptime" parent: "owl:topDataProperty" class_iri: "ex:Compute" } }
+	}
+	field {
+		name: "level" number: 8 type: TYPE_ENUM type_name: ".test.v1.Level" label: LABEL_OPTIONAL json_name: "level"
+		options { [owl.property] { iri: "ex:level" parent: "owl:topObjectProperty" class_iri: "ex:Resource" } }
+	}
+	field {
+		name: "block_storage_ids" number: 9 type: TYPE_STRING label: LABEL_REPEATED json_name: "blockStorageIds"
+		options { [owl.property] { iri: "ex:hasMultiple" parent: "owl:topObjectProperty" class_iri: "ex:VirtualMachine" } }
+	}
+	field {
+		name: "owner_iri" number: 10 type: TYPE_STRING label: LABEL_OPTIONAL json_name: "ownerIri"
+		options { [owl.property] { iri: "ex:owner" parent: "owl:topObjectProperty" class_iri: "ex:Resource" } }
+	}
+	field {
+		name: "geo_location" number: 11 type: TYPE_MESSAGE type_name: ".test.v1.GeoLocation" label: LABEL_OPTIONAL json_name: "geoLocation"
+		options { [owl.property] { iri: "ex:has" parent: "owl:topObjectProperty" class_iri: "ex:Compute" } }
+	}
+	field {
+		name: "labels" number: 12 type: TYPE_STRING label: LABEL_REPEATED json_name: "labels"
+	}
+}
+`
+
+// testFileDescriptor is the descriptor of the test file, which is created once, so that all test messages have the
+// same type.
+var testFileDescriptor protoreflect.FileDescriptor
+
+// testMessage returns a new message of the test file, populated from the text format.
+func testMessage(t *testing.T, name string, text string) proto.Message {
+	t.Helper()
+
+	if testFileDescriptor == nil {
+		var fdp descriptorpb.FileDescriptorProto
+
+		err := prototext.Unmarshal([]byte(testFile), &fdp)
+		if err != nil {
+			t.Fatalf("could not parse test file: %v", err)
+		}
+
+		testFileDescriptor, err = protodesc.NewFile(&fdp, protoregistry.GlobalFiles)
+		if err != nil {
+			t.Fatalf("could not create test file: %v", err)
+		}
+	}
+
+	msg := dynamicpb.NewMessage(testFileDescriptor.Messages().ByName(protoreflect.Name(name)))
+
+	err := prototext.Unmarshal([]byte(text), msg)
+	if err != nil {
+		t.Fatalf("could not parse test message: %v", err)
+	}
+
+	return msg
+}
+
+func TestMarshal(t *testing.T) {
+	vm := testMessage(t, "VirtualMachine", `
+		id: "vm1"
+		name: "My \"VM\""
+		cores: 4
+		load: 0.1
+		enabled: true
+		created_at { seconds: 1700000000 nanos: 500000000 }
+		uptime { seconds: 90 nanos: 500000000 }
+		level: LEVEL_HIGH
+		block_storage_ids: ["bs1", "urn:storage:bs2"]
+		owner_iri: "http://example.com/people/alice"
+		geo_location { region: "eu" }
+		labels: ["ignored"]
+	`)
+
+	type args struct {
+		format rdf.Format
+		msgs   []proto.Message
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "N-Triples",
+			args: args{format: rdf.FormatNTriples, msgs: []proto.Message{vm}},
+			want: `<http://example.com/resources/vm1> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example.com/cloud/VirtualMachine> .
+<http://example.com/resources/vm1> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example.com/cloud/Compute> .
+<http://example.com/resources/vm1> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example.com/cloud/Resource> .
+<http://example.com/resources/vm1> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/2002/07/owl#Thing> .
+<http://example.com/resources/vm1> <http://example.com/cloud/id> "vm1" .
+<http://example.com/resources/vm1> <http://example.com/cloud/name> "My \"VM\"" .
+<http://example.com/resources/vm1> <http://example.com/cloud/cores> "4"^^<http://www.w3.org/2001/XMLSchema#long> .
+<http://example.com/resources/vm1> <http://example.com/cloud/load> "0.1"^^<http://www.w3.org/2001/XMLSchema#float> .
+<http://example.com/resources/vm1> <http://example.com/cloud/enabled> "true"^^<http://www.w3.org/2001/XMLSchema#boolean> .
+<http://example.com/resources/vm1> <http://example.com/cloud/createdAt> "2023-11-14T22:13:20.5Z"^^<http://www.w3.org/2001/XMLSchema#dateTime> .
+<http://example.com/resources/vm1> <http://example.com/cloud/uptime> "PT90.5S"^^<http://www.w3.org/2001/XMLSchema#duration> .
+<http://example.com/resources/vm1> <http://example.com/cloud/level> <http://example.com/cloud/High> .
+<http://example.com/resources/vm1> <http://example.com/cloud/hasMultiple> <http://example.com/resources/bs1> .
+<http://example.com/resources/vm1> <http://example.com/cloud/hasMultiple> <urn:storage:bs2> .
+<http://example.com/resources/vm1> <http://example.com/cloud/owner> <http://example.com/people/alice> .
+_:b1 <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example.com/cloud/GeoLocation> .
+_:b1 <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/2002/07/owl#Thing> .
+_:b1 <http://example.com/cloud/region> "eu" .
+<http://example.com/resources/vm1> <http://example.com/cloud/has> _:b1 .
+`,
+		},
+		{
+			name: "Turtle",
+			args: args{
+				format: rdf.FormatTurtle,
+				msgs: []proto.Message{
+					&example.Resource{Type: &example.Resource_VirtualMachine{VirtualMachine: &example.VirtualMachine{
+						Name:            "vm2",
+						BlockStorageIds: []string{"bs1"},
+						GeoLocation:     &example.GeoLocation{},
+					}}},
+				},
+			},
+			want: `@prefix ex: <http://example.com/cloud/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+
+_:b1
+	a ex:VirtualMachine, ex:Compute, ex:Resource, owl:Thing ;
+	ex:name "vm2" ;
+	ex:hasMultiple <http://example.com/resources/bs1> ;
+	ex:has _:b2 .
+
+_:b2
+	a ex:GeoLocation, owl:Thing .
+`,
+		},
+		{
+			name: "JSON-LD",
+			args: args{
+				format: rdf.FormatJSONLD,
+				msgs:   []proto.Message{testMessage(t, "GeoLocation", `region: "eu"`)},
+			},
+			want: `{
+  "@context": {
+    "ex": "http://example.com/cloud/",
+    "owl": "http://www.w3.org/2002/07/owl#",
+    "rdf": "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+    "rdfs": "http://www.w3.org/2000/01/rdf-schema#",
+    "xml": "http://www.w3.org/XML/1998/namespace",
+    "xsd": "http://www.w3.org/2001/XMLSchema#"
+  },
+  "@graph": [
+    {
+      "@id": "_:b1",
+      "@type": [
+        "ex:GeoLocation",
+        "owl:Thing"
+      ],
+      "ex:region": [
+        {
+          "@value": "eu"
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+		{
+			name:    "No class",
+			args:    args{format: rdf.FormatNTriples, msgs: []proto.Message{&example.Storage{}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.args.format, "http://example.com/resources/", tt.args.msgs...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Marshal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_formatDuration(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "Seconds", text: `uptime { seconds: 3600 }`, want: "PT3600S"},
+		{name: "Negative", text: `uptime { seconds: -1 nanos: -250000000 }`, want: "-PT1.25S"},
+		{name: "Zero", text: `uptime { }`, want: "PT0S"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testMessage(t, "VirtualMachine", tt.text).ProtoReflect()
+			uptime := m.Get(m.Descriptor().Fields().ByName("uptime")).Message()
+
+			if got := formatDuration(uptime); got != tt.want {
+				t.Errorf("formatDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isAbsoluteIRI(t *testing.T) {
+	for s, want := range map[string]bool{
+		"http://example.com/vm1": true,
+		"urn:uuid:1234":          true,
+		"vm1":                    false,
+		"1:2":                    false,
+		":vm1":                   false,
+	} {
+		if got := isAbsoluteIRI(s); got != want {
+			t.Errorf("isAbsoluteIRI(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package runtime
+
+import (
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/oxisto/owl2proto/owl"
+	"github.com/oxisto/owl2proto/rdf"
+)
+
+// Prefixes maps the names of prefixes to their namespace IRI, e.g., "ex" to "http://example.com/cloud/".
+type Prefixes map[string]string
+
+// PrefixesOf returns the prefixes of the (owl.meta) option of the proto file. The well-known prefixes, such as "owl"
+// and "xsd", are always contained.
+func PrefixesOf(fd protoreflect.FileDescriptor) Prefixes {
+	var prefixes = make(Prefixes)
+
+	for name, iri := range rdf.WellKnownPrefixes {
+		prefixes[name] = iri
+	}
+
+	if opts, ok := fd.Options().(*descriptorpb.FileOptions); ok && opts != nil {
+		meta, _ := proto.GetExtension(opts, owl.E_Meta).(*owl.Meta)
+		for _, p := range meta.GetPrefixes() {
+			prefixes[p.GetPrefix()] = p.GetIri()
+		}
+	}
+
+	return prefixes
+}
+
+// Expand returns the full IRI of an abbreviated IRI, e.g., "http://example.com/cloud/Storage" for "ex:Storage". IRIs
+// whose prefix is unknown, e.g., full IRIs, are returned unchanged.
+func (p Prefixes) Expand(iri string) string {
+	prefix, name, found := strings.Cut(iri, ":")
+	if !found || strings.HasPrefix(name, "//") {
+		return iri
+	}
+
+	if ns, ok := p[prefix]; ok {
+		return ns + name
+	}
+
+	return iri
+}
+
+// Abbreviate returns the abbreviated IRI of a full IRI, e.g., "ex:Storage" for "http://example.com/cloud/Storage". If
+// several prefixes match, the one with the longest IRI is chosen and named prefixes are preferred over the default
+// (empty) prefix. IRIs without a matching prefix are returned unchanged.
+func (p Prefixes) Abbreviate(iri string) string {
+	var (
+		names = make([]string, 0, len(p))
+		match string
+		found bool
+	)
+
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ns := p[name]
+		if ns == "" || !strings.HasPrefix(iri, ns) {
+			continue
+		}
+
+		if !found || len(ns) > len(p[match]) || (len(ns) == len(p[match]) && match == "") {
+			match, found = name, true
+		}
+	}
+
+	if found {
+		return match + ":" + strings.TrimPrefix(iri, p[match])
+	}
+
+	return iri
+}
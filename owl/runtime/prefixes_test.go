@@ -0,0 +1,52 @@
+package runtime
+
+import (
+	"testing"
+)
+
+func TestPrefixes_Expand(t *testing.T) {
+	prefixes := Prefixes{"ex": "http://example.com/cloud/", "": "http://example.com/cloud/"}
+
+	tests := []struct {
+		name string
+		iri  string
+		want string
+	}{
+		{name: "Abbreviated IRI", iri: "ex:Storage", want: "http://example.com/cloud/Storage"},
+		{name: "Default prefix", iri: ":Storage", want: "http://example.com/cloud/Storage"},
+		{name: "Full IRI", iri: "http://example.com/cloud/Storage", want: "http://example.com/cloud/Storage"},
+		{name: "Unknown prefix", iri: "sec:Firewall", want: "sec:Firewall"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prefixes.Expand(tt.iri); got != tt.want {
+				t.Errorf("Prefixes.Expand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrefixes_Abbreviate(t *testing.T) {
+	prefixes := Prefixes{
+		"":    "http://example.com/cloud/",
+		"ex":  "http://example.com/cloud/",
+		"sec": "http://example.com/cloud/security/",
+	}
+
+	tests := []struct {
+		name string
+		iri  string
+		want string
+	}{
+		{name: "Named prefix is preferred", iri: "http://example.com/cloud/Storage", want: "ex:Storage"},
+		{name: "Longest prefix", iri: "http://example.com/cloud/security/Firewall", want: "sec:Firewall"},
+		{name: "No prefix", iri: "http://example.org/Other", want: "http://example.org/Other"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prefixes.Abbreviate(tt.iri); got != tt.want {
+				t.Errorf("Prefixes.Abbreviate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package runtime
+
+import (
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// GlobalRegistry contains the classes of all messages of [protoregistry.GlobalFiles].
+var GlobalRegistry = NewRegistry(protoregistry.GlobalFiles)
+
+// Registry indexes the classes of all messages of a set of proto files by their IRI. It is built lazily, when it is
+// used for the first time, so that all files are registered by then.
+type Registry struct {
+	files *protoregistry.Files
+	once  sync.Once
+
+	// classes contains the classes, indexed by their IRI
+	classes map[string]*Class
+
+	// prefixes contains the prefixes of all files
+	prefixes Prefixes
+}
+
+// NewRegistry creates a new registry of the classes of the files.
+func NewRegistry(files *protoregistry.Files) *Registry {
+	return &Registry{files: files}
+}
+
+// build indexes the classes of all messages, including nested ones.
+func (r *Registry) build() {
+	r.classes = make(map[string]*Class)
+	r.prefixes = make(Prefixes)
+
+	r.files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		for name, iri := range PrefixesOf(fd) {
+			if _, ok := r.prefixes[name]; !ok {
+				r.prefixes[name] = iri
+			}
+		}
+
+		r.addMessages(fd.Messages())
+
+		return true
+	})
+}
+
+// addMessages adds the classes of the messages and their nested messages.
+func (r *Registry) addMessages(messages protoreflect.MessageDescriptors) {
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+
+		if c := ClassOf(md); c != nil {
+			r.classes[c.IRI] = c
+		}
+
+		r.addMessages(md.Messages())
+	}
+}
+
+// Prefixes returns the prefixes of all files. If files declare the same prefix differently, the first one is used.
+func (r *Registry) Prefixes() Prefixes {
+	r.once.Do(r.build)
+
+	return r.prefixes
+}
+
+// FindClass returns the class with the IRI or nil, if no message has this class. The IRI can either be a full IRI or
+// abbreviated using a prefix of any file of the registry.
+func (r *Registry) FindClass(iri string) *Class {
+	r.once.Do(r.build)
+
+	return r.classes[r.prefixes.Expand(iri)]
+}
+
+// SubClasses returns all classes that are the given class or one of its sub-classes, sorted by their IRI. This is
+// especially useful for abstract classes, which have no message of their own.
+func (r *Registry) SubClasses(iri string) (classes []*Class) {
+	r.once.Do(r.build)
+
+	iri = r.prefixes.Expand(iri)
+
+	for _, c := range r.classes {
+		if c.IsSubClassOf(iri) {
+			classes = append(classes, c)
+		}
+	}
+
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].IRI < classes[j].IRI
+	})
+
+	return
+}
@@ -0,0 +1,40 @@
+package runtime
+
+import (
+	"testing"
+)
+
+func TestRegistry_FindClass(t *testing.T) {
+	tests := []struct {
+		name string
+		iri  string
+		want string
+	}{
+		{name: "Abbreviated IRI", iri: "ex:BlockStorage", want: "example.v1.BlockStorage"},
+		{name: "Full IRI", iri: ex + "GeoLocation", want: "example.v1.GeoLocation"},
+		{name: "Abstract class", iri: "ex:Storage", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			if c := GlobalRegistry.FindClass(tt.iri); c != nil {
+				got = string(c.Descriptor.FullName())
+			}
+
+			if got != tt.want {
+				t.Errorf("Registry.FindClass() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistry_SubClasses(t *testing.T) {
+	var got []string
+	for _, c := range GlobalRegistry.SubClasses("ex:Compute") {
+		got = append(got, GlobalRegistry.Prefixes().Abbreviate(c.IRI))
+	}
+
+	if len(got) != 2 || got[0] != "ex:Container" || got[1] != "ex:VirtualMachine" {
+		t.Errorf("Registry.SubClasses() = %v, want [ex:Container ex:VirtualMachine]", got)
+	}
+}
@@ -0,0 +1,212 @@
+// Package runtime provides access to the semantic meta-data of messages that were generated by owl2proto in full
+// semantic mode, i.e., the IRIs of their classes and properties, the class hierarchy and the prefixes of the ontology.
+package runtime
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/oxisto/owl2proto/owl"
+)
+
+// TypeOneof is the name of the oneof that contains the sub-classes of an abstract class.
+const TypeOneof = "type"
+
+// Class is the class of a message, as specified by its (owl.class) options. All IRIs are full IRIs.
+type Class struct {
+	// IRI is the IRI of the class
+	IRI string
+
+	// Parents contains the IRIs of all super-classes, linearized from the direct super-classes up to "owl:Thing"
+	Parents []string
+
+	// Descriptor is the descriptor of the message
+	Descriptor protoreflect.MessageDescriptor
+
+	// Prefixes contains the prefixes of the file of the message
+	Prefixes Prefixes
+}
+
+// Property is the property of a field, as specified by its (owl.property) options. All IRIs are full IRIs.
+type Property struct {
+	// IRI is the IRI of the object or data property
+	IRI string
+
+	// Parents contains the IRIs of all super-properties up to "owl:topObjectProperty" or "owl:topDataProperty"
+	Parents []string
+
+	// ClassIRI is the IRI of the class that declares the property
+	ClassIRI string
+
+	// Descriptor is the descriptor of the field
+	Descriptor protoreflect.FieldDescriptor
+}
+
+// ClassOf returns the class of the message type or nil, if the message has no (owl.class) option, e.g., because it
+// is an abstract class.
+func ClassOf(md protoreflect.MessageDescriptor) *Class {
+	opts, ok := md.Options().(*descriptorpb.MessageOptions)
+	if !ok || opts == nil {
+		return nil
+	}
+
+	entry, _ := proto.GetExtension(opts, owl.E_Class).(*owl.EntityEntry)
+	if entry.GetIri() == "" {
+		return nil
+	}
+
+	c := &Class{
+		Descriptor: md,
+		Prefixes:   PrefixesOf(md.ParentFile()),
+	}
+	c.IRI = c.Prefixes.Expand(entry.GetIri())
+
+	for _, parent := range entry.GetParent() {
+		c.Parents = append(c.Parents, c.Prefixes.Expand(parent))
+	}
+
+	return c
+}
+
+// ClassOfMessage returns the class of the message. Messages of abstract classes are unwrapped, i.e., the class of the
+// message that is set in their oneof "type" is returned. If the message has no class, nil is returned.
+func ClassOfMessage(msg proto.Message) *Class {
+	msg = Unwrap(msg)
+	if msg == nil {
+		return nil
+	}
+
+	return ClassOf(msg.ProtoReflect().Descriptor())
+}
+
+// ClassIRI returns the IRI of the class of the message or an empty string, if the message has no class.
+func ClassIRI(msg proto.Message) string {
+	if c := ClassOfMessage(msg); c != nil {
+		return c.IRI
+	}
+
+	return ""
+}
+
+// Ancestors returns the IRIs of all super-classes of the class of the message, linearized from the direct
+// super-classes up to "owl:Thing".
+func Ancestors(msg proto.Message) []string {
+	if c := ClassOfMessage(msg); c != nil {
+		return c.Parents
+	}
+
+	return nil
+}
+
+// IsSubClassOf returns true if the class of the message is the given class or one of its sub-classes. The IRI can
+// either be a full IRI or abbreviated using a prefix of the file of the message, e.g., "ex:Storage".
+func IsSubClassOf(msg proto.Message, iri string) bool {
+	c := ClassOfMessage(msg)
+
+	return c != nil && c.IsSubClassOf(iri)
+}
+
+// IsSubClassOf returns true if the class is the given class or one of its sub-classes. The IRI can either be a full
+// IRI or abbreviated using a prefix of the file of the class.
+func (c *Class) IsSubClassOf(iri string) bool {
+	iri = c.Prefixes.Expand(iri)
+	if c.IRI == iri {
+		return true
+	}
+
+	for _, parent := range c.Parents {
+		if parent == iri {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Properties returns the properties of all fields of the class that have an (owl.property) option, in the order of
+// the fields.
+func (c *Class) Properties() (properties []*Property) {
+	fields := c.Descriptor.Fields()
+
+	for i := 0; i < fields.Len(); i++ {
+		if p := PropertyOf(fields.Get(i)); p != nil {
+			properties = append(properties, p)
+		}
+	}
+
+	return
+}
+
+// FieldsByIRI returns the fields of the class whose property is the given property. The IRI can either be a full IRI
+// or abbreviated using a prefix of the file of the class. Several fields can have the same property, if it has
+// several ranges.
+func (c *Class) FieldsByIRI(iri string) (fields []protoreflect.FieldDescriptor) {
+	iri = c.Prefixes.Expand(iri)
+
+	for _, p := range c.Properties() {
+		if p.IRI == iri {
+			fields = append(fields, p.Descriptor)
+		}
+	}
+
+	return
+}
+
+// PropertyOf returns the property of the field or nil, if the field has no (owl.property) option.
+func PropertyOf(fd protoreflect.FieldDescriptor) *Property {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil {
+		return nil
+	}
+
+	entry, _ := proto.GetExtension(opts, owl.E_Property).(*owl.PropertyEntry)
+	if entry.GetIri() == "" {
+		return nil
+	}
+
+	prefixes := PrefixesOf(fd.ParentFile())
+
+	p := &Property{
+		IRI:        prefixes.Expand(entry.GetIri()),
+		ClassIRI:   prefixes.Expand(entry.GetClassIri()),
+		Descriptor: fd,
+	}
+
+	for _, parent := range entry.GetParent() {
+		p.Parents = append(p.Parents, prefixes.Expand(parent))
+	}
+
+	return p
+}
+
+// FieldIRI returns the IRI of the property of the field or an empty string, if the field has no property.
+func FieldIRI(fd protoreflect.FieldDescriptor) string {
+	if p := PropertyOf(fd); p != nil {
+		return p.IRI
+	}
+
+	return ""
+}
+
+// Unwrap returns the message that is set in the oneof "type" of a message of an abstract class, recursively. Other
+// messages are returned unchanged. If no message is set, nil is returned.
+func Unwrap(msg proto.Message) proto.Message {
+	for msg != nil {
+		m := msg.ProtoReflect()
+
+		od := m.Descriptor().Oneofs().ByName(TypeOneof)
+		if od == nil || ClassOf(m.Descriptor()) != nil {
+			return msg
+		}
+
+		fd := m.WhichOneof(od)
+		if fd == nil || fd.Message() == nil {
+			return nil
+		}
+
+		msg = m.Get(fd).Message().Interface()
+	}
+
+	return nil
+}
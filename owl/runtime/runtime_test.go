@@ -0,0 +1,109 @@
+package runtime
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/oxisto/owl2proto/example"
+)
+
+const ex = "http://example.com/cloud/"
+
+func TestClassIRI(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  proto.Message
+		want string
+	}{
+		{name: "Entity class", msg: &example.VirtualMachine{}, want: ex + "VirtualMachine"},
+		{
+			name: "Abstract class is unwrapped",
+			msg:  &example.Resource{Type: &example.Resource_BlockStorage{BlockStorage: &example.BlockStorage{}}},
+			want: ex + "BlockStorage",
+		},
+		{name: "Abstract class without value", msg: &example.Storage{}, want: ""},
+		{name: "Class without parents", msg: &example.GeoLocation{}, want: ex + "GeoLocation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassIRI(tt.msg); got != tt.want {
+				t.Errorf("ClassIRI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAncestors(t *testing.T) {
+	want := []string{ex + "Compute", ex + "Resource", "http://www.w3.org/2002/07/owl#Thing"}
+
+	if got := Ancestors(&example.Container{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Ancestors() = %v, want %v", got, want)
+	}
+}
+
+func TestIsSubClassOf(t *testing.T) {
+	type args struct {
+		msg proto.Message
+		iri string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{name: "Same class", args: args{msg: &example.VirtualMachine{}, iri: "ex:VirtualMachine"}, want: true},
+		{name: "Super-class", args: args{msg: &example.VirtualMachine{}, iri: "ex:Compute"}, want: true},
+		{name: "Full IRI", args: args{msg: &example.VirtualMachine{}, iri: ex + "Resource"}, want: true},
+		{name: "owl:Thing", args: args{msg: &example.GeoLocation{}, iri: "owl:Thing"}, want: true},
+		{name: "Other class", args: args{msg: &example.VirtualMachine{}, iri: "ex:Storage"}, want: false},
+		{
+			name: "Abstract class is unwrapped",
+			args: args{
+				msg: &example.Compute{Type: &example.Compute_Container{Container: &example.Container{}}},
+				iri: "ex:Container",
+			},
+			want: true,
+		},
+		{name: "No class", args: args{msg: &example.Compute{}, iri: "ex:Compute"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSubClassOf(tt.args.msg, tt.args.iri); got != tt.want {
+				t.Errorf("IsSubClassOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClass_Properties(t *testing.T) {
+	c := ClassOf((&example.VirtualMachine{}).ProtoReflect().Descriptor())
+	if c == nil {
+		t.Fatalf("ClassOf() = nil")
+	}
+
+	var got = map[string]string{}
+	for _, p := range c.Properties() {
+		got[string(p.Descriptor.Name())] = p.IRI
+	}
+
+	want := map[string]string{
+		"name":              ex + "name",
+		"block_storage_ids": ex + "hasMultiple",
+		"geo_location":      ex + "has",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Class.Properties() = %v, want %v", got, want)
+	}
+
+	fields := c.FieldsByIRI("ex:has")
+	if len(fields) != 1 || fields[0].Name() != "geo_location" {
+		t.Errorf("Class.FieldsByIRI() = %v, want [geo_location]", fields)
+	}
+
+	p := PropertyOf(fields[0])
+	if want := []string{"http://www.w3.org/2002/07/owl#topObjectProperty"}; !reflect.DeepEqual(p.Parents, want) {
+		t.Errorf("PropertyOf() parents = %v, want %v", p.Parents, want)
+	}
+}
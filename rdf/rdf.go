@@ -0,0 +1,318 @@
+// Package rdf contains a minimal RDF graph model as well as readers for RDF serializations. It is used to read
+// ontologies that are not serialized in OWL/XML and to (de-)serialize instance data.
+package rdf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	NamespaceRDF  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	NamespaceRDFS = "http://www.w3.org/2000/01/rdf-schema#"
+	NamespaceOWL  = "http://www.w3.org/2002/07/owl#"
+	NamespaceXSD  = "http://www.w3.org/2001/XMLSchema#"
+	NamespaceXML  = "http://www.w3.org/XML/1998/namespace"
+
+	Type  = NamespaceRDF + "type"
+	First = NamespaceRDF + "first"
+	Rest  = NamespaceRDF + "rest"
+	Nil   = NamespaceRDF + "nil"
+
+	LangString = NamespaceRDF + "langString"
+	XMLLiteral = NamespaceRDF + "XMLLiteral"
+
+	SubClassOf    = NamespaceRDFS + "subClassOf"
+	SubPropertyOf = NamespaceRDFS + "subPropertyOf"
+	Label         = NamespaceRDFS + "label"
+	Comment       = NamespaceRDFS + "comment"
+	SeeAlso       = NamespaceRDFS + "seeAlso"
+
+	XSDString = NamespaceXSD + "string"
+)
+
+// WellKnownPrefixes contains the prefixes that are always known, regardless whether they are declared in a document.
+var WellKnownPrefixes = map[string]string{
+	"rdf":  NamespaceRDF,
+	"rdfs": NamespaceRDFS,
+	"owl":  NamespaceOWL,
+	"xsd":  NamespaceXSD,
+	"xml":  NamespaceXML,
+}
+
+// TermKind is the kind of an RDF term.
+type TermKind int
+
+const (
+	KindIRI TermKind = iota
+	KindBlankNode
+	KindLiteral
+)
+
+// Term is an RDF term, i.e., an IRI, a blank node or a literal.
+type Term struct {
+	Kind  TermKind
+	Value string
+
+	// Datatype is the datatype IRI of a literal
+	Datatype string
+
+	// Language is the language tag of a literal
+	Language string
+}
+
+// NewIRI returns a new IRI term.
+func NewIRI(iri string) Term {
+	return Term{Kind: KindIRI, Value: iri}
+}
+
+// NewBlankNode returns a new blank node term with the given label.
+func NewBlankNode(label string) Term {
+	return Term{Kind: KindBlankNode, Value: label}
+}
+
+// NewLiteral returns a new literal term. An empty datatype defaults to xsd:string (or rdf:langString if a language
+// is given).
+func NewLiteral(value, datatype, language string) Term {
+	if datatype == "" {
+		if language != "" {
+			datatype = LangString
+		} else {
+			datatype = XSDString
+		}
+	}
+
+	return Term{Kind: KindLiteral, Value: value, Datatype: datatype, Language: language}
+}
+
+// IsIRI returns true if the term is an IRI.
+func (t Term) IsIRI() bool {
+	return t.Kind == KindIRI
+}
+
+// IsBlankNode returns true if the term is a blank node.
+func (t Term) IsBlankNode() bool {
+	return t.Kind == KindBlankNode
+}
+
+// IsLiteral returns true if the term is a literal.
+func (t Term) IsLiteral() bool {
+	return t.Kind == KindLiteral
+}
+
+// String returns the N-Triples representation of the term.
+func (t Term) String() string {
+	switch t.Kind {
+	case KindIRI:
+		return "<" + t.Value + ">"
+	case KindBlankNode:
+		return "_:" + t.Value
+	default:
+		s := quote(t.Value)
+		if t.Language != "" {
+			return s + "@" + t.Language
+		} else if t.Datatype != "" && t.Datatype != XSDString {
+			return s + "^^<" + t.Datatype + ">"
+		}
+
+		return s
+	}
+}
+
+// Triple is an RDF triple.
+type Triple struct {
+	Subject   Term
+	Predicate Term
+	Object    Term
+
+	// Line and Column are the position of the triple in the source document, or zero if unknown
+	Line   int
+	Column int
+}
+
+// String returns the N-Triples representation of the triple.
+func (t Triple) String() string {
+	return fmt.Sprintf("%s %s %s .", t.Subject, t.Predicate, t.Object)
+}
+
+// Graph is a set of triples together with the prefixes that were declared in the source document.
+type Graph struct {
+	// Triples contains the triples in the order in which they were added. Triples can be appended directly, but
+	// should otherwise only be added using [Graph.Add] and [Graph.AddAt], because lookups use an index of them.
+	Triples []Triple
+
+	// Prefixes contains the declared namespace IRIs, indexed by their prefix
+	Prefixes map[string]string
+
+	// blank is the counter for generating blank node labels
+	blank int
+
+	// labels contains the blank nodes for the labels used in a document
+	labels map[string]Term
+
+	// subjects and objects contain the positions of the triples in Triples, indexed by their subject and object. Only
+	// the first indexed triples are contained, the others are added on the next lookup.
+	subjects map[Term][]int
+	objects  map[Term][]int
+	indexed  int
+}
+
+// NewGraph returns a new, empty graph.
+func NewGraph() *Graph {
+	return &Graph{Prefixes: map[string]string{}}
+}
+
+// Add adds a triple to the graph.
+func (g *Graph) Add(s, p, o Term) {
+	g.Triples = append(g.Triples, Triple{Subject: s, Predicate: p, Object: o})
+}
+
+// AddAt adds a triple that was read at the given position of the source document.
+func (g *Graph) AddAt(s, p, o Term, line, column int) {
+	g.Triples = append(g.Triples, Triple{Subject: s, Predicate: p, Object: o, Line: line, Column: column})
+}
+
+// NewBlankNode returns a new blank node with a label that is unique within the graph.
+func (g *Graph) NewBlankNode() Term {
+	g.blank++
+	return NewBlankNode(fmt.Sprintf("b%d", g.blank))
+}
+
+// BlankNode returns the blank node for a label that is used in a document (e.g., "_:x" or rdf:nodeID="x"). The same
+// label always results in the same blank node, but never clashes with the blank nodes created by [Graph.NewBlankNode].
+func (g *Graph) BlankNode(label string) Term {
+	if g.labels == nil {
+		g.labels = make(map[string]Term)
+	}
+
+	node, ok := g.labels[label]
+	if !ok {
+		node = g.NewBlankNode()
+		g.labels[label] = node
+	}
+
+	return node
+}
+
+// index adds the triples that were added since the last lookup to the indexes of subjects and objects. Looking up
+// triples by their subject or object is thus independent of the size of the graph.
+func (g *Graph) index() {
+	if g.indexed > len(g.Triples) {
+		// The triples were truncated, so the index needs to be rebuilt
+		g.subjects, g.objects, g.indexed = nil, nil, 0
+	}
+
+	if g.subjects == nil {
+		g.subjects = make(map[Term][]int)
+		g.objects = make(map[Term][]int)
+	}
+
+	for i := g.indexed; i < len(g.Triples); i++ {
+		t := g.Triples[i]
+		g.subjects[t.Subject] = append(g.subjects[t.Subject], i)
+		g.objects[t.Object] = append(g.objects[t.Object], i)
+	}
+
+	g.indexed = len(g.Triples)
+}
+
+// Objects returns the objects of all triples with the given subject and predicate.
+func (g *Graph) Objects(s Term, p string) (objects []Term) {
+	g.index()
+
+	for _, i := range g.subjects[s] {
+		if t := g.Triples[i]; t.Predicate.Value == p {
+			objects = append(objects, t.Object)
+		}
+	}
+
+	return
+}
+
+// Object returns the object of the first triple with the given subject and predicate.
+func (g *Graph) Object(s Term, p string) (o Term, ok bool) {
+	objects := g.Objects(s, p)
+	if len(objects) == 0 {
+		return Term{}, false
+	}
+
+	return objects[0], true
+}
+
+// Subjects returns the subjects of all triples with the given predicate and object.
+func (g *Graph) Subjects(p string, o Term) (subjects []Term) {
+	g.index()
+
+	for _, i := range g.objects[o] {
+		if t := g.Triples[i]; t.Predicate.Value == p {
+			subjects = append(subjects, t.Subject)
+		}
+	}
+
+	return
+}
+
+// HasType returns true if the graph contains the triple (s, rdf:type, typ).
+func (g *Graph) HasType(s Term, typ string) bool {
+	for _, o := range g.Objects(s, Type) {
+		if o.IsIRI() && o.Value == typ {
+			return true
+		}
+	}
+
+	return false
+}
+
+// List returns the members of the RDF collection that starts with the given head.
+func (g *Graph) List(head Term) (members []Term) {
+	seen := make(map[Term]bool)
+
+	for head.Value != Nil && !seen[head] {
+		seen[head] = true
+
+		first, ok := g.Object(head, First)
+		if !ok {
+			break
+		}
+		members = append(members, first)
+
+		head, ok = g.Object(head, Rest)
+		if !ok {
+			break
+		}
+	}
+
+	return
+}
+
+// Abbreviate returns the abbreviated form of the IRI (e.g., "xsd:string") if a matching prefix is declared in the
+// graph or is well-known. Otherwise, the IRI is returned unchanged.
+func (g *Graph) Abbreviate(iri string) string {
+	var (
+		best      string
+		bestShort string
+	)
+
+	// Well-known prefixes take precedence over declared prefixes with the same namespace
+	for _, prefixes := range []map[string]string{WellKnownPrefixes, g.Prefixes} {
+		keys := make([]string, 0, len(prefixes))
+		for short := range prefixes {
+			keys = append(keys, short)
+		}
+		sort.Strings(keys)
+
+		for _, short := range keys {
+			ns := prefixes[short]
+			if ns != "" && strings.HasPrefix(iri, ns) && len(ns) > len(best) {
+				best, bestShort = ns, short
+			}
+		}
+	}
+
+	if best == "" {
+		return iri
+	}
+
+	return bestShort + ":" + strings.TrimPrefix(iri, best)
+}
@@ -0,0 +1,51 @@
+package rdf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraph_index(t *testing.T) {
+	var (
+		storage = NewIRI("http://example.com/cloud/Storage")
+		vm      = NewIRI("http://example.com/cloud/VirtualMachine")
+		class   = NewIRI("http://www.w3.org/2002/07/owl#Class")
+		label   = "http://www.w3.org/2000/01/rdf-schema#label"
+	)
+
+	g := NewGraph()
+	g.Add(storage, NewIRI(Type), class)
+	g.Add(storage, NewIRI(label), NewLiteral("Storage", "", ""))
+
+	if got, want := g.Objects(storage, Type), []Term{class}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Graph.Objects() = %v, want %v", got, want)
+	}
+
+	// Triples that are added after a lookup are found as well
+	g.Add(vm, NewIRI(Type), class)
+	g.Triples = append(g.Triples, Triple{Subject: storage, Predicate: NewIRI(Type), Object: NewIRI(SubClassOf)})
+
+	tests := []struct {
+		name string
+		got  []Term
+		want []Term
+	}{
+		{name: "Objects", got: g.Objects(storage, Type), want: []Term{class, NewIRI(SubClassOf)}},
+		{name: "Objects of other predicate", got: g.Objects(storage, label), want: []Term{NewLiteral("Storage", "", "")}},
+		{name: "Subjects", got: g.Subjects(Type, class), want: []Term{storage, vm}},
+		{name: "Unknown subject", got: g.Objects(NewIRI("http://example.com/cloud/Unknown"), Type)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("Graph.%s() = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+
+	// Replaced triples rebuild the index
+	g.Triples = g.Triples[:1]
+	if got := g.HasType(vm, class.Value); got {
+		t.Errorf("Graph.HasType() = %v, want false", got)
+	}
+}
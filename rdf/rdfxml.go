@@ -0,0 +1,386 @@
+package rdf
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// rdfxmlParser parses the RDF/XML serialization (see https://www.w3.org/TR/rdf-syntax-grammar/) into a [Graph].
+type rdfxmlParser struct {
+	d *xml.Decoder
+	g *Graph
+}
+
+// ParseRDFXML parses an RDF/XML document. Relative IRIs are resolved against base, unless the document declares its
+// own xml:base.
+func ParseRDFXML(r io.Reader, base string) (g *Graph, err error) {
+	p := &rdfxmlParser{
+		d: xml.NewDecoder(r),
+		g: NewGraph(),
+	}
+
+	for {
+		var tok xml.Token
+
+		tok, err = p.d.Token()
+		if errors.Is(err, io.EOF) {
+			return p.g, nil
+		} else if err != nil {
+			return nil, p.errorf("%w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		p.declarePrefixes(start)
+		base, lang := p.scope(start, base, "")
+
+		// The root element is either rdf:RDF, which contains a list of node elements, or a single node element
+		if start.Name.Space == NamespaceRDF && start.Name.Local == "RDF" {
+			err = p.nodeElementList(base, lang)
+		} else {
+			_, err = p.nodeElement(start, base, lang)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// nodeElementList parses all node elements until the end of the current element.
+func (p *rdfxmlParser) nodeElementList(base, lang string) error {
+	for {
+		tok, err := p.d.Token()
+		if err != nil {
+			return p.errorf("%w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			p.declarePrefixes(t)
+			_, err = p.nodeElement(t, base, lang)
+			if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// nodeElement parses a node element, e.g., <owl:Class rdf:about="...">, and returns its subject.
+func (p *rdfxmlParser) nodeElement(start xml.StartElement, base, lang string) (subject Term, err error) {
+	base, lang = p.scope(start, base, lang)
+
+	subject = p.g.NewBlankNode()
+
+	for _, attr := range start.Attr {
+		if attr.Name.Space != NamespaceRDF {
+			continue
+		}
+
+		switch attr.Name.Local {
+		case "about":
+			subject = NewIRI(resolve(base, attr.Value))
+		case "ID":
+			subject = NewIRI(resolve(base, "#"+attr.Value))
+		case "nodeID":
+			subject = p.g.BlankNode(attr.Value)
+		}
+	}
+
+	// Typed node elements carry an implicit rdf:type
+	if start.Name.Space != NamespaceRDF || start.Name.Local != "Description" {
+		p.add(subject, NewIRI(Type), NewIRI(start.Name.Space+start.Name.Local))
+	}
+
+	p.propertyAttributes(subject, start, base, lang)
+
+	li := 0
+	for {
+		tok, err := p.d.Token()
+		if err != nil {
+			return subject, p.errorf("%w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			p.declarePrefixes(t)
+			err = p.propertyElement(subject, t, base, lang, &li)
+			if err != nil {
+				return subject, err
+			}
+		case xml.EndElement:
+			return subject, nil
+		}
+	}
+}
+
+// propertyElement parses a property element, e.g., <rdfs:subClassOf rdf:resource="..."/>, of the given subject.
+func (p *rdfxmlParser) propertyElement(subject Term, start xml.StartElement, base, lang string, li *int) (err error) {
+	var (
+		object           Term
+		hasObject        bool
+		datatype         string
+		parseType        string
+		hasPropertyAttrs bool
+		predicate        = start.Name.Space + start.Name.Local
+		text             strings.Builder
+		nested           []Term
+	)
+
+	base, lang = p.scope(start, base, lang)
+
+	// rdf:li is a shortcut for the container membership properties rdf:_1, rdf:_2, ...
+	if start.Name.Space == NamespaceRDF && start.Name.Local == "li" {
+		*li++
+		predicate = NamespaceRDF + "_" + strconv.Itoa(*li)
+	}
+
+	for _, attr := range start.Attr {
+		if attr.Name.Space == NamespaceRDF {
+			switch attr.Name.Local {
+			case "resource":
+				object, hasObject = NewIRI(resolve(base, attr.Value)), true
+			case "nodeID":
+				object, hasObject = p.g.BlankNode(attr.Value), true
+			case "datatype":
+				datatype = resolve(base, attr.Value)
+			case "parseType":
+				parseType = attr.Value
+			case "ID":
+				// We do not support reification, so we can safely ignore it
+			default:
+				hasPropertyAttrs = true
+			}
+		} else if isPropertyAttribute(attr) {
+			hasPropertyAttrs = true
+		}
+	}
+
+	switch parseType {
+	case "Resource":
+		object = p.g.NewBlankNode()
+		p.add(subject, NewIRI(predicate), object)
+
+		var li int
+		for {
+			tok, err := p.d.Token()
+			if err != nil {
+				return p.errorf("%w", err)
+			}
+
+			switch t := tok.(type) {
+			case xml.StartElement:
+				p.declarePrefixes(t)
+				err = p.propertyElement(object, t, base, lang, &li)
+				if err != nil {
+					return err
+				}
+			case xml.EndElement:
+				return nil
+			}
+		}
+	case "Collection":
+		for {
+			tok, err := p.d.Token()
+			if err != nil {
+				return p.errorf("%w", err)
+			}
+
+			switch t := tok.(type) {
+			case xml.StartElement:
+				p.declarePrefixes(t)
+				member, err := p.nodeElement(t, base, lang)
+				if err != nil {
+					return err
+				}
+				nested = append(nested, member)
+			case xml.EndElement:
+				p.add(subject, NewIRI(predicate), p.collection(nested))
+				return nil
+			}
+		}
+	case "Literal":
+		var depth int
+		for {
+			tok, err := p.d.Token()
+			if err != nil {
+				return p.errorf("%w", err)
+			}
+
+			switch t := tok.(type) {
+			case xml.StartElement:
+				depth++
+				text.WriteString("<" + t.Name.Local + ">")
+			case xml.EndElement:
+				if depth == 0 {
+					p.add(subject, NewIRI(predicate), NewLiteral(text.String(), XMLLiteral, ""))
+					return nil
+				}
+				depth--
+				text.WriteString("</" + t.Name.Local + ">")
+			case xml.CharData:
+				text.Write(t)
+			}
+		}
+	}
+
+	// Otherwise, the content is either a single nested node element or a literal
+	for {
+		tok, err := p.d.Token()
+		if err != nil {
+			return p.errorf("%w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			p.declarePrefixes(t)
+			member, err := p.nodeElement(t, base, lang)
+			if err != nil {
+				return err
+			}
+			nested = append(nested, member)
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			switch {
+			case len(nested) > 0:
+				object = nested[0]
+			case hasObject:
+				// object was already specified by rdf:resource or rdf:nodeID
+			case hasPropertyAttrs:
+				object = p.g.NewBlankNode()
+			default:
+				object = NewLiteral(text.String(), datatype, lang)
+			}
+
+			p.add(subject, NewIRI(predicate), object)
+
+			// Property attributes on an empty property element describe the object
+			if len(nested) == 0 && (hasObject || hasPropertyAttrs) {
+				p.propertyAttributes(object, start, base, lang)
+			}
+
+			return nil
+		}
+	}
+}
+
+// propertyAttributes adds a triple for each property attribute of the element, e.g., <ex:Foo rdfs:label="Foo"/>.
+func (p *rdfxmlParser) propertyAttributes(subject Term, start xml.StartElement, base, lang string) {
+	for _, attr := range start.Attr {
+		if !isPropertyAttribute(attr) && (attr.Name.Space != NamespaceRDF || attr.Name.Local != "type") {
+			continue
+		}
+
+		if attr.Name.Space == NamespaceRDF && attr.Name.Local == "type" {
+			p.add(subject, NewIRI(Type), NewIRI(resolve(base, attr.Value)))
+		} else {
+			p.add(subject, NewIRI(attr.Name.Space+attr.Name.Local), NewLiteral(attr.Value, "", lang))
+		}
+	}
+}
+
+// add adds a triple at the current position of the decoder.
+func (p *rdfxmlParser) add(s, pred, o Term) {
+	line, column := p.d.InputPos()
+	p.g.AddAt(s, pred, o, line, column)
+}
+
+// collection adds an RDF collection (rdf:first/rdf:rest) for the given members and returns its head.
+func (p *rdfxmlParser) collection(members []Term) Term {
+	head := NewIRI(Nil)
+
+	for i := len(members) - 1; i >= 0; i-- {
+		node := p.g.NewBlankNode()
+		p.add(node, NewIRI(First), members[i])
+		p.add(node, NewIRI(Rest), head)
+		head = node
+	}
+
+	return head
+}
+
+// declarePrefixes stores all namespace declarations of the element as prefixes of the graph.
+func (p *rdfxmlParser) declarePrefixes(start xml.StartElement) {
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" {
+			p.g.Prefixes[attr.Name.Local] = attr.Value
+		} else if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+			p.g.Prefixes[""] = attr.Value
+		}
+	}
+}
+
+// scope returns the base IRI and language of the element, which are inherited from the parent element unless
+// overridden by xml:base or xml:lang.
+func (p *rdfxmlParser) scope(start xml.StartElement, base, lang string) (string, string) {
+	for _, attr := range start.Attr {
+		if attr.Name.Space != NamespaceXML && attr.Name.Space != "xml" {
+			continue
+		}
+
+		switch attr.Name.Local {
+		case "base":
+			base = resolve(base, attr.Value)
+		case "lang":
+			lang = attr.Value
+		}
+	}
+
+	return base, lang
+}
+
+// errorf returns an error that includes the current position of the decoder.
+func (p *rdfxmlParser) errorf(format string, a ...any) error {
+	line, column := p.d.InputPos()
+	return fmt.Errorf("RDF/XML %d:%d: %w", line, column, fmt.Errorf(format, a...))
+}
+
+// isPropertyAttribute returns true if the attribute is a property attribute, i.e., not a syntax or XML attribute.
+func isPropertyAttribute(attr xml.Attr) bool {
+	switch attr.Name.Space {
+	case "", "xmlns", "xml", NamespaceXML:
+		return false
+	case NamespaceRDF:
+		switch attr.Name.Local {
+		case "about", "ID", "nodeID", "resource", "datatype", "parseType", "type", "li":
+			return false
+		}
+	}
+
+	return true
+}
+
+// resolve resolves the (possibly relative) IRI reference against the base IRI.
+func resolve(base, ref string) string {
+	if base == "" {
+		return ref
+	}
+
+	r, err := url.Parse(ref)
+	if err != nil || r.IsAbs() {
+		return ref
+	}
+
+	// Fragment-only references are simply appended to the base, which also works for opaque IRIs, such as URNs
+	if strings.HasPrefix(ref, "#") {
+		before, _, _ := strings.Cut(base, "#")
+		return before + ref
+	}
+
+	b, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+
+	return b.ResolveReference(r).String()
+}
@@ -0,0 +1,108 @@
+package rdf
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRDFXML(t *testing.T) {
+	type args struct {
+		doc  string
+		base string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Happy path: typed node and resource",
+			args: args{
+				doc: `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	xmlns:rdfs="http://www.w3.org/2000/01/rdf-schema#"
+	xmlns:owl="http://www.w3.org/2002/07/owl#">
+	<owl:Class rdf:about="http://example.com/cloud/Storage">
+		<rdfs:subClassOf rdf:resource="http://example.com/cloud/Resource"/>
+		<rdfs:label xml:lang="en">Storage</rdfs:label>
+	</owl:Class>
+</rdf:RDF>`,
+			},
+			want: []string{
+				`<http://example.com/cloud/Storage> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/2002/07/owl#Class> .`,
+				`<http://example.com/cloud/Storage> <http://www.w3.org/2000/01/rdf-schema#subClassOf> <http://example.com/cloud/Resource> .`,
+				`<http://example.com/cloud/Storage> <http://www.w3.org/2000/01/rdf-schema#label> "Storage"@en .`,
+			},
+		},
+		{
+			name: "Happy path: nested node and relative IRIs",
+			args: args{
+				doc: `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	xmlns:rdfs="http://www.w3.org/2000/01/rdf-schema#"
+	xmlns:owl="http://www.w3.org/2002/07/owl#">
+	<rdf:Description rdf:about="#Compute">
+		<rdfs:subClassOf>
+			<owl:Restriction>
+				<owl:onProperty rdf:resource="#has"/>
+			</owl:Restriction>
+		</rdfs:subClassOf>
+	</rdf:Description>
+</rdf:RDF>`,
+				base: "http://example.com/cloud",
+			},
+			want: []string{
+				`_:b2 <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/2002/07/owl#Restriction> .`,
+				`_:b2 <http://www.w3.org/2002/07/owl#onProperty> <http://example.com/cloud#has> .`,
+				`<http://example.com/cloud#Compute> <http://www.w3.org/2000/01/rdf-schema#subClassOf> _:b2 .`,
+			},
+		},
+		{
+			name: "Happy path: collection",
+			args: args{
+				doc: `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	xmlns:owl="http://www.w3.org/2002/07/owl#">
+	<owl:Class rdf:about="http://example.com/Level">
+		<owl:oneOf rdf:parseType="Collection">
+			<rdf:Description rdf:about="http://example.com/High"/>
+		</owl:oneOf>
+	</owl:Class>
+</rdf:RDF>`,
+			},
+			want: []string{
+				`<http://example.com/Level> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/2002/07/owl#Class> .`,
+				`_:b3 <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> <http://example.com/High> .`,
+				`_:b3 <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> <http://www.w3.org/1999/02/22-rdf-syntax-ns#nil> .`,
+				`<http://example.com/Level> <http://www.w3.org/2002/07/owl#oneOf> _:b3 .`,
+			},
+		},
+		{
+			name: "Malformed XML",
+			args: args{
+				doc: `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description>`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := ParseRDFXML(strings.NewReader(tt.args.doc), tt.args.base)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRDFXML() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			var got []string
+			for _, triple := range g.Triples {
+				got = append(got, triple.String())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRDFXML() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
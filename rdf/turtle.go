@@ -0,0 +1,659 @@
+package rdf
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	xsdInteger = NamespaceXSD + "integer"
+	xsdDecimal = NamespaceXSD + "decimal"
+	xsdDouble  = NamespaceXSD + "double"
+	xsdBoolean = NamespaceXSD + "boolean"
+)
+
+// turtleParser parses the Turtle serialization (see https://www.w3.org/TR/turtle/) into a [Graph]. Since N-Triples
+// is a subset of Turtle, it can also be used to parse N-Triples.
+type turtleParser struct {
+	src  []rune
+	pos  int
+	base string
+	g    *Graph
+
+	// lines contains the offsets of the beginnings of all lines, which is used to determine the position of triples
+	lines []int
+}
+
+// ParseTurtle parses a Turtle (or N-Triples) document. Relative IRIs are resolved against base, unless the document
+// declares its own @base.
+func ParseTurtle(r io.Reader, base string) (g *Graph, err error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Turtle: %w", err)
+	}
+
+	p := &turtleParser{
+		src:  []rune(string(b)),
+		base: base,
+		g:    NewGraph(),
+	}
+
+	p.lines = []int{0}
+	for i, c := range p.src {
+		if c == '\n' {
+			p.lines = append(p.lines, i+1)
+		}
+	}
+
+	for {
+		p.skipWhitespace()
+		if p.eof() {
+			return p.g, nil
+		}
+
+		err = p.statement()
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// statement parses either a directive or a list of triples that is terminated by ".".
+func (p *turtleParser) statement() (err error) {
+	if p.peek() == '@' {
+		p.pos++
+		word := p.word()
+
+		switch word {
+		case "prefix":
+			err = p.prefixDirective()
+		case "base":
+			err = p.baseDirective()
+		default:
+			return p.errorf("unknown directive @%s", word)
+		}
+		if err != nil {
+			return err
+		}
+
+		return p.expect('.')
+	}
+
+	// SPARQL-style directives are not terminated by "."
+	if p.hasKeyword("PREFIX") {
+		return p.prefixDirective()
+	} else if p.hasKeyword("BASE") {
+		return p.baseDirective()
+	}
+
+	err = p.triples()
+	if err != nil {
+		return err
+	}
+
+	return p.expect('.')
+}
+
+// prefixDirective parses the prefix name and the namespace IRI of a prefix directive.
+func (p *turtleParser) prefixDirective() error {
+	p.skipWhitespace()
+
+	name := p.name()
+	if p.peek() != ':' {
+		return p.errorf("expected ':' after prefix name %q", name)
+	}
+	p.pos++
+
+	p.skipWhitespace()
+	iri, err := p.iriRef()
+	if err != nil {
+		return err
+	}
+
+	p.g.Prefixes[name] = iri
+
+	return nil
+}
+
+// baseDirective parses the IRI of a base directive.
+func (p *turtleParser) baseDirective() error {
+	p.skipWhitespace()
+
+	iri, err := p.iriRef()
+	if err != nil {
+		return err
+	}
+
+	p.base = iri
+
+	return nil
+}
+
+// triples parses a subject followed by a predicate-object list.
+func (p *turtleParser) triples() error {
+	p.skipWhitespace()
+
+	// A blank node property list can be a statement on its own
+	if p.peek() == '[' {
+		subject, err := p.blankNodePropertyList()
+		if err != nil {
+			return err
+		}
+
+		p.skipWhitespace()
+		if p.peek() == '.' {
+			return nil
+		}
+
+		return p.predicateObjectList(subject)
+	}
+
+	subject, err := p.term(false)
+	if err != nil {
+		return err
+	} else if subject.IsLiteral() {
+		return p.errorf("literal %s is not allowed as subject", subject)
+	}
+
+	return p.predicateObjectList(subject)
+}
+
+// predicateObjectList parses a list of predicates and objects, separated by ";" and ",".
+func (p *turtleParser) predicateObjectList(subject Term) error {
+	for {
+		p.skipWhitespace()
+
+		start := p.pos
+		predicate, err := p.term(true)
+		if err != nil {
+			return err
+		} else if !predicate.IsIRI() {
+			return p.errorf("expected IRI as predicate, got %s", predicate)
+		}
+
+		for {
+			p.skipWhitespace()
+
+			object, err := p.term(false)
+			if err != nil {
+				return err
+			}
+
+			p.add(subject, predicate, object, start)
+
+			p.skipWhitespace()
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+
+		if p.peek() != ';' {
+			return nil
+		}
+
+		// Skip all (possibly repeated) ";" and allow a trailing ";" before the end of the list
+		for p.peek() == ';' {
+			p.pos++
+			p.skipWhitespace()
+		}
+
+		switch p.peek() {
+		case '.', ']':
+			return nil
+		}
+	}
+}
+
+// term parses an IRI, a blank node, a collection or a literal. If verb is true, the keyword "a" is accepted as a
+// shortcut for rdf:type.
+func (p *turtleParser) term(verb bool) (Term, error) {
+	c := p.peek()
+
+	switch {
+	case p.eof():
+		return Term{}, p.errorf("unexpected end of input")
+	case c == '<':
+		iri, err := p.iriRef()
+		return NewIRI(iri), err
+	case c == '_' && p.peekAt(1) == ':':
+		p.pos += 2
+		return p.g.BlankNode(p.name()), nil
+	case c == '[':
+		return p.blankNodePropertyList()
+	case c == '(':
+		return p.collection()
+	case c == '"' || c == '\'':
+		return p.literal()
+	case c == '+' || c == '-' || unicode.IsDigit(c) || (c == '.' && unicode.IsDigit(p.peekAt(1))):
+		return p.number()
+	}
+
+	// Otherwise, we have either a prefixed name or a keyword
+	start := p.pos
+	prefix := p.name()
+
+	if p.peek() == ':' {
+		p.pos++
+
+		ns, ok := p.g.Prefixes[prefix]
+		if !ok {
+			p.pos = start
+			return Term{}, p.errorf("undeclared prefix %q", prefix)
+		}
+
+		local, err := p.localName()
+		if err != nil {
+			return Term{}, err
+		}
+
+		return NewIRI(ns + local), nil
+	}
+
+	switch {
+	case prefix == "a" && verb:
+		return NewIRI(Type), nil
+	case prefix == "true" || prefix == "false":
+		return NewLiteral(prefix, xsdBoolean, ""), nil
+	}
+
+	p.pos = start
+	return Term{}, p.errorf("unexpected %q", string(c))
+}
+
+// blankNodePropertyList parses a blank node with its properties, e.g., "[ a owl:Restriction ; ... ]".
+func (p *turtleParser) blankNodePropertyList() (node Term, err error) {
+	err = p.expect('[')
+	if err != nil {
+		return
+	}
+
+	node = p.g.NewBlankNode()
+
+	p.skipWhitespace()
+	if p.peek() != ']' {
+		err = p.predicateObjectList(node)
+		if err != nil {
+			return
+		}
+	}
+
+	return node, p.expect(']')
+}
+
+// collection parses an RDF collection, e.g., "( ex:High ex:Low )", and returns its head.
+func (p *turtleParser) collection() (head Term, err error) {
+	var (
+		members []Term
+		start   = p.pos
+	)
+
+	err = p.expect('(')
+	if err != nil {
+		return
+	}
+
+	for {
+		p.skipWhitespace()
+		if p.peek() == ')' {
+			p.pos++
+			break
+		}
+
+		member, err := p.term(false)
+		if err != nil {
+			return Term{}, err
+		}
+
+		members = append(members, member)
+	}
+
+	head = NewIRI(Nil)
+	for i := len(members) - 1; i >= 0; i-- {
+		node := p.g.NewBlankNode()
+		p.add(node, NewIRI(First), members[i], start)
+		p.add(node, NewIRI(Rest), head, start)
+		head = node
+	}
+
+	return head, nil
+}
+
+// literal parses a quoted literal with an optional language tag or datatype.
+func (p *turtleParser) literal() (Term, error) {
+	var (
+		quote = p.peek()
+		long  = p.peekAt(1) == quote && p.peekAt(2) == quote
+		value strings.Builder
+	)
+
+	if long {
+		p.pos += 3
+	} else {
+		p.pos++
+	}
+
+	for {
+		if p.eof() {
+			return Term{}, p.errorf("unterminated string literal")
+		}
+
+		c := p.src[p.pos]
+
+		if c == quote {
+			if !long {
+				p.pos++
+				break
+			} else if p.peekAt(1) == quote && p.peekAt(2) == quote {
+				p.pos += 3
+				break
+			}
+		} else if c == '\n' && !long {
+			return Term{}, p.errorf("line break in string literal")
+		} else if c == '\\' {
+			r, err := p.escape()
+			if err != nil {
+				return Term{}, err
+			}
+
+			value.WriteRune(r)
+			continue
+		}
+
+		value.WriteRune(c)
+		p.pos++
+	}
+
+	// Language tag or datatype
+	if p.peek() == '@' {
+		p.pos++
+		return NewLiteral(value.String(), "", p.name()), nil
+	} else if p.peek() == '^' && p.peekAt(1) == '^' {
+		p.pos += 2
+
+		datatype, err := p.term(false)
+		if err != nil {
+			return Term{}, err
+		} else if !datatype.IsIRI() {
+			return Term{}, p.errorf("expected IRI as datatype, got %s", datatype)
+		}
+
+		return NewLiteral(value.String(), datatype.Value, ""), nil
+	}
+
+	return NewLiteral(value.String(), "", ""), nil
+}
+
+// escape parses an escape sequence in a string literal or IRI.
+func (p *turtleParser) escape() (rune, error) {
+	p.pos++
+
+	c := p.peek()
+	p.pos++
+
+	switch c {
+	case 't':
+		return '\t', nil
+	case 'b':
+		return '\b', nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 'f':
+		return '\f', nil
+	case '"', '\'', '\\':
+		return c, nil
+	case 'u', 'U':
+		n := 4
+		if c == 'U' {
+			n = 8
+		}
+
+		if p.pos+n > len(p.src) {
+			return 0, p.errorf("invalid unicode escape sequence")
+		}
+
+		code, err := strconv.ParseUint(string(p.src[p.pos:p.pos+n]), 16, 32)
+		if err != nil {
+			return 0, p.errorf("invalid unicode escape sequence: %w", err)
+		}
+
+		p.pos += n
+
+		return rune(code), nil
+	default:
+		return 0, p.errorf("invalid escape sequence \\%c", c)
+	}
+}
+
+// number parses a numeric literal, i.e., an integer, a decimal or a double.
+func (p *turtleParser) number() (Term, error) {
+	var (
+		start    = p.pos
+		datatype = xsdInteger
+	)
+
+	if p.peek() == '+' || p.peek() == '-' {
+		p.pos++
+	}
+
+	p.digits()
+
+	if p.peek() == '.' && unicode.IsDigit(p.peekAt(1)) {
+		p.pos++
+		p.digits()
+		datatype = xsdDecimal
+	}
+
+	if p.peek() == 'e' || p.peek() == 'E' {
+		p.pos++
+		if p.peek() == '+' || p.peek() == '-' {
+			p.pos++
+		}
+		p.digits()
+		datatype = xsdDouble
+	}
+
+	value := string(p.src[start:p.pos])
+	if value == "+" || value == "-" {
+		return Term{}, p.errorf("invalid number %q", value)
+	}
+
+	return NewLiteral(value, datatype, ""), nil
+}
+
+// digits skips all digits.
+func (p *turtleParser) digits() {
+	for !p.eof() && unicode.IsDigit(p.peek()) {
+		p.pos++
+	}
+}
+
+// iriRef parses an IRI reference in angle brackets and resolves it against the current base.
+func (p *turtleParser) iriRef() (string, error) {
+	var iri strings.Builder
+
+	err := p.expect('<')
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated IRI")
+		}
+
+		c := p.peek()
+		if c == '>' {
+			p.pos++
+			break
+		} else if c == '\\' {
+			r, err := p.escape()
+			if err != nil {
+				return "", err
+			}
+
+			iri.WriteRune(r)
+			continue
+		} else if unicode.IsSpace(c) {
+			return "", p.errorf("whitespace in IRI")
+		}
+
+		iri.WriteRune(c)
+		p.pos++
+	}
+
+	return resolve(p.base, iri.String()), nil
+}
+
+// name reads a (possibly empty) name, i.e., a prefix name, a blank node label, a language tag or a keyword.
+func (p *turtleParser) name() string {
+	start := p.pos
+
+	for !p.eof() && isNameChar(p.peek()) {
+		p.pos++
+	}
+
+	// A name must not end with "."
+	for p.pos > start && p.src[p.pos-1] == '.' {
+		p.pos--
+	}
+
+	return string(p.src[start:p.pos])
+}
+
+// localName reads the local part of a prefixed name, which can additionally contain ":", percent-encoded characters
+// and escaped special characters.
+func (p *turtleParser) localName() (string, error) {
+	var local strings.Builder
+
+	for !p.eof() {
+		c := p.peek()
+
+		if c == '\\' {
+			p.pos++
+			if p.eof() {
+				return "", p.errorf("invalid escape sequence in local name")
+			}
+
+			local.WriteRune(p.peek())
+			p.pos++
+		} else if isNameChar(c) || c == ':' || c == '%' {
+			local.WriteRune(c)
+			p.pos++
+		} else {
+			break
+		}
+	}
+
+	// A local name must not end with "."
+	s := local.String()
+	for strings.HasSuffix(s, ".") {
+		s = strings.TrimSuffix(s, ".")
+		p.pos--
+	}
+
+	return s, nil
+}
+
+// word reads a keyword that consists of letters only.
+func (p *turtleParser) word() string {
+	start := p.pos
+
+	for !p.eof() && unicode.IsLetter(p.peek()) {
+		p.pos++
+	}
+
+	return string(p.src[start:p.pos])
+}
+
+// hasKeyword checks (case-insensitive) whether the given keyword follows and consumes it.
+func (p *turtleParser) hasKeyword(keyword string) bool {
+	end := p.pos + len(keyword)
+	if end >= len(p.src) || !strings.EqualFold(string(p.src[p.pos:end]), keyword) || !unicode.IsSpace(p.src[end]) {
+		return false
+	}
+
+	p.pos = end
+
+	return true
+}
+
+// skipWhitespace skips whitespace and comments.
+func (p *turtleParser) skipWhitespace() {
+	for !p.eof() {
+		c := p.peek()
+
+		if c == '#' {
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		} else if unicode.IsSpace(c) || c == '\uFEFF' {
+			p.pos++
+		} else {
+			return
+		}
+	}
+}
+
+// expect skips whitespace and consumes the given character.
+func (p *turtleParser) expect(c rune) error {
+	p.skipWhitespace()
+
+	if p.peek() != c || p.eof() {
+		if p.eof() {
+			return p.errorf("expected %q, got end of input", string(c))
+		}
+
+		return p.errorf("expected %q, got %q", string(c), string(p.peek()))
+	}
+
+	p.pos++
+
+	return nil
+}
+
+func (p *turtleParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *turtleParser) peek() rune {
+	return p.peekAt(0)
+}
+
+func (p *turtleParser) peekAt(offset int) rune {
+	if p.pos+offset >= len(p.src) {
+		return 0
+	}
+
+	return p.src[p.pos+offset]
+}
+
+// add adds a triple that was read at the given offset.
+func (p *turtleParser) add(s, pred, o Term, offset int) {
+	line, column := p.position(offset)
+	p.g.AddAt(s, pred, o, line, column)
+}
+
+// errorf returns an error that includes the current line and column.
+func (p *turtleParser) errorf(format string, a ...any) error {
+	line, column := p.position(p.pos)
+	return fmt.Errorf("Turtle %d:%d: %w", line, column, fmt.Errorf(format, a...))
+}
+
+// position returns the (1-based) line and column of the offset.
+func (p *turtleParser) position(offset int) (line, column int) {
+	line = sort.Search(len(p.lines), func(i int) bool { return p.lines[i] > offset })
+
+	return line, offset - p.lines[line-1] + 1
+}
+
+// isNameChar returns true if the character can be part of a prefix name or local name.
+func isNameChar(c rune) bool {
+	return c == '_' || c == '-' || c == '.' || unicode.IsLetter(c) || unicode.IsDigit(c) || c > 0x7F
+}
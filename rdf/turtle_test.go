@@ -0,0 +1,129 @@
+package rdf
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseTurtle(t *testing.T) {
+	type args struct {
+		doc  string
+		base string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Happy path: prefixes and predicate lists",
+			args: args{
+				doc: `@prefix ex: <http://example.com/cloud/> .
+PREFIX rdfs: <http://www.w3.org/2000/01/rdf-schema#>
+
+# A comment
+ex:Storage a <http://www.w3.org/2002/07/owl#Class> ;
+	rdfs:label "Storage"@en, 'Speicher'@de ;
+	rdfs:comment """A "long"
+comment""" .`,
+			},
+			want: []string{
+				`<http://example.com/cloud/Storage> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/2002/07/owl#Class> .`,
+				`<http://example.com/cloud/Storage> <http://www.w3.org/2000/01/rdf-schema#label> "Storage"@en .`,
+				`<http://example.com/cloud/Storage> <http://www.w3.org/2000/01/rdf-schema#label> "Speicher"@de .`,
+				`<http://example.com/cloud/Storage> <http://www.w3.org/2000/01/rdf-schema#comment> "A \"long\"\ncomment" .`,
+			},
+		},
+		{
+			name: "Happy path: blank nodes, collections and numbers",
+			args: args{
+				doc: `@base <http://example.com/cloud/> .
+<Compute> <restriction> [ <onProperty> <has> ] ;
+	<values> ( 1 2.5 true ) .`,
+			},
+			want: []string{
+				`_:b1 <http://example.com/cloud/onProperty> <http://example.com/cloud/has> .`,
+				`<http://example.com/cloud/Compute> <http://example.com/cloud/restriction> _:b1 .`,
+				`_:b2 <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> "true"^^<http://www.w3.org/2001/XMLSchema#boolean> .`,
+				`_:b2 <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> <http://www.w3.org/1999/02/22-rdf-syntax-ns#nil> .`,
+				`_:b3 <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> "2.5"^^<http://www.w3.org/2001/XMLSchema#decimal> .`,
+				`_:b3 <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> _:b2 .`,
+				`_:b4 <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> "1"^^<http://www.w3.org/2001/XMLSchema#integer> .`,
+				`_:b4 <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> _:b3 .`,
+				`<http://example.com/cloud/Compute> <http://example.com/cloud/values> _:b4 .`,
+			},
+		},
+		{
+			name: "Happy path: N-Triples",
+			args: args{
+				doc: `<http://example.com/a> <http://example.com/p> _:x .
+_:x <http://example.com/p> "1"^^<http://www.w3.org/2001/XMLSchema#int> .`,
+			},
+			want: []string{
+				`<http://example.com/a> <http://example.com/p> _:b1 .`,
+				`_:b1 <http://example.com/p> "1"^^<http://www.w3.org/2001/XMLSchema#int> .`,
+			},
+		},
+		{
+			name: "Undeclared prefix",
+			args: args{
+				doc: `ex:Storage a ex:Class .`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing terminator",
+			args: args{
+				doc: `<http://example.com/a> <http://example.com/p> <http://example.com/b>`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := ParseTurtle(strings.NewReader(tt.args.doc), tt.args.base)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTurtle() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			var got []string
+			for _, triple := range g.Triples {
+				got = append(got, triple.String())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTurtle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTurtle_error(t *testing.T) {
+	_, err := ParseTurtle(strings.NewReader("@prefix ex: <http://example.com/> .\n\nex:a ex:b ;"), "")
+	if err == nil || !strings.HasPrefix(err.Error(), "Turtle 3:") {
+		t.Errorf("ParseTurtle() error = %v, want error in line 3", err)
+	}
+}
+
+func TestParseTurtle_position(t *testing.T) {
+	g, err := ParseTurtle(strings.NewReader("@prefix ex: <http://example.com/cloud/> .\n\nex:Storage a ex:Class ;\n\tex:label \"Storage\" ."), "")
+	if err != nil {
+		t.Fatalf("ParseTurtle() error = %v", err)
+	}
+
+	var got [][2]int
+	for _, triple := range g.Triples {
+		got = append(got, [2]int{triple.Line, triple.Column})
+	}
+
+	want := [][2]int{{3, 12}, {4, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseTurtle() positions = %v, want %v", got, want)
+	}
+}
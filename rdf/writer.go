@@ -0,0 +1,288 @@
+package rdf
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Format is a serialization format of RDF graphs that can be written.
+type Format string
+
+const (
+	FormatNTriples Format = "ntriples"
+	FormatTurtle   Format = "turtle"
+	FormatJSONLD   Format = "jsonld"
+)
+
+var (
+	// prefixNamePattern matches the prefix names that can be used in Turtle.
+	prefixNamePattern = regexp.MustCompile(`^([A-Za-z]([\w.-]*[\w-])?)?$`)
+
+	// localNamePattern matches the local names that can be used in prefixed names of Turtle without escaping.
+	localNamePattern = regexp.MustCompile(`^(\w([\w.-]*[\w-])?)?$`)
+)
+
+// Write writes the graph in the given format.
+func Write(w io.Writer, g *Graph, format Format) error {
+	switch format {
+	case FormatNTriples:
+		return WriteNTriples(w, g)
+	case FormatTurtle:
+		return WriteTurtle(w, g)
+	case FormatJSONLD:
+		return WriteJSONLD(w, g)
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// WriteNTriples writes the triples of the graph in the N-Triples format, one triple per line.
+func WriteNTriples(w io.Writer, g *Graph) error {
+	bw := bufio.NewWriter(w)
+
+	for _, t := range g.Triples {
+		_, err := bw.WriteString(t.String() + "\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// WriteTurtle writes the triples of the graph in the Turtle format. The triples are grouped by their subject and
+// predicate, and IRIs are abbreviated using the prefixes of the graph and the well-known prefixes.
+func WriteTurtle(w io.Writer, g *Graph) error {
+	var (
+		body strings.Builder
+		used = make(map[string]string)
+	)
+
+	iri := func(iri string) string {
+		prefixed := g.Abbreviate(iri)
+		prefix, local, _ := strings.Cut(prefixed, ":")
+
+		if prefixed == iri || !prefixNamePattern.MatchString(prefix) || !localNamePattern.MatchString(local) {
+			return "<" + iri + ">"
+		}
+
+		used[prefix] = strings.TrimSuffix(iri, local)
+
+		return prefixed
+	}
+
+	term := func(t Term) string {
+		switch {
+		case t.IsIRI():
+			return iri(t.Value)
+		case t.IsLiteral() && t.Language == "" && t.Datatype != "" && t.Datatype != XSDString:
+			return quote(t.Value) + "^^" + iri(t.Datatype)
+		default:
+			return t.String()
+		}
+	}
+
+	for i, s := range subjects(g) {
+		if i > 0 {
+			body.WriteString("\n")
+		}
+
+		body.WriteString(term(s))
+
+		for j, p := range predicates(g, s) {
+			if j > 0 {
+				body.WriteString(" ;")
+			}
+
+			if p == Type {
+				body.WriteString("\n\ta ")
+			} else {
+				body.WriteString("\n\t" + iri(p) + " ")
+			}
+
+			for k, o := range g.Objects(s, p) {
+				if k > 0 {
+					body.WriteString(", ")
+				}
+
+				body.WriteString(term(o))
+			}
+		}
+
+		body.WriteString(" .\n")
+	}
+
+	var header strings.Builder
+	for _, prefix := range sortedKeys(used) {
+		header.WriteString(fmt.Sprintf("@prefix %s: <%s> .\n", prefix, used[prefix]))
+	}
+	if header.Len() > 0 {
+		header.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, header.String()+body.String())
+
+	return err
+}
+
+// WriteJSONLD writes the triples of the graph as JSON-LD document. The document contains a node object for each
+// subject in its "@graph". The prefixes of the graph are declared in the "@context" and used to compact the IRIs of
+// properties and types.
+func WriteJSONLD(w io.Writer, g *Graph) error {
+	var (
+		context = make(map[string]string)
+		nodes   []map[string]any
+	)
+
+	for prefix, ns := range WellKnownPrefixes {
+		context[prefix] = ns
+	}
+	for prefix, ns := range g.Prefixes {
+		if prefix != "" {
+			context[prefix] = ns
+		}
+	}
+
+	compact := func(iri string) string {
+		prefixed := g.Abbreviate(iri)
+		if prefix, _, _ := strings.Cut(prefixed, ":"); prefixed == iri || context[prefix] == "" {
+			return iri
+		}
+
+		return prefixed
+	}
+
+	for _, s := range subjects(g) {
+		node := map[string]any{"@id": jsonLDID(s)}
+
+		for _, p := range predicates(g, s) {
+			var (
+				objects = g.Objects(s, p)
+				values  []any
+			)
+
+			if p == Type {
+				var types []string
+				for _, o := range objects {
+					types = append(types, compact(o.Value))
+				}
+
+				node["@type"] = types
+				continue
+			}
+
+			for _, o := range objects {
+				switch {
+				case o.IsLiteral() && o.Language != "":
+					values = append(values, map[string]string{"@value": o.Value, "@language": o.Language})
+				case o.IsLiteral() && o.Datatype != "" && o.Datatype != XSDString:
+					values = append(values, map[string]string{"@value": o.Value, "@type": compact(o.Datatype)})
+				case o.IsLiteral():
+					values = append(values, map[string]string{"@value": o.Value})
+				default:
+					values = append(values, map[string]string{"@id": jsonLDID(o)})
+				}
+			}
+
+			node[compact(p)] = values
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(map[string]any{
+		"@context": context,
+		"@graph":   nodes,
+	})
+}
+
+// jsonLDID returns the identifier of an IRI or blank node in JSON-LD.
+func jsonLDID(t Term) string {
+	if t.IsBlankNode() {
+		return "_:" + t.Value
+	}
+
+	return t.Value
+}
+
+// subjects returns the subjects of the graph in the order of their first occurrence.
+func subjects(g *Graph) (subjects []Term) {
+	seen := make(map[Term]bool)
+
+	for _, t := range g.Triples {
+		if !seen[t.Subject] {
+			seen[t.Subject] = true
+			subjects = append(subjects, t.Subject)
+		}
+	}
+
+	return
+}
+
+// predicates returns the predicates of the subject in the order of their first occurrence.
+func predicates(g *Graph, s Term) (predicates []string) {
+	seen := make(map[string]bool)
+
+	for _, t := range g.Triples {
+		if t.Subject == s && !seen[t.Predicate.Value] {
+			seen[t.Predicate.Value] = true
+			predicates = append(predicates, t.Predicate.Value)
+		}
+	}
+
+	return
+}
+
+// quote returns the string as quoted literal with the escape sequences of N-Triples and Turtle.
+func quote(s string) string {
+	var b strings.Builder
+
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				b.WriteString(fmt.Sprintf(`\u%04X`, r))
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+// sortedKeys returns the keys of the map in ascending order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
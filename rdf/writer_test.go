@@ -0,0 +1,192 @@
+package rdf
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testGraph returns a graph that contains literals with escape sequences, a language tag, a datatype and a blank
+// node, as well as an IRI that cannot be abbreviated.
+func testGraph() *Graph {
+	g := NewGraph()
+	g.Prefixes["ex"] = "http://example.com/cloud/"
+
+	vm := NewIRI("http://example.com/cloud/vm1")
+	geo := g.NewBlankNode()
+
+	g.Add(vm, NewIRI(Type), NewIRI("http://example.com/cloud/VirtualMachine"))
+	g.Add(vm, NewIRI(Type), NewIRI(NamespaceOWL+"Thing"))
+	g.Add(vm, NewIRI("http://example.com/cloud/name"), NewLiteral("My \"VM\"\n", "", ""))
+	g.Add(vm, NewIRI("http://example.com/cloud/name"), NewLiteral("Meine VM", "", "de"))
+	g.Add(vm, NewIRI("http://example.com/cloud/cores"), NewLiteral("4", NamespaceXSD+"long", ""))
+	g.Add(vm, NewIRI("http://example.com/cloud/has"), geo)
+	g.Add(vm, NewIRI("http://example.com/cloud/owner"), NewIRI("http://example.com/cloud/people/a#b"))
+	g.Add(geo, NewIRI("http://example.com/cloud/region"), NewLiteral("eu", XSDString, ""))
+
+	return g
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  Format
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "N-Triples",
+			format: FormatNTriples,
+			want: `<http://example.com/cloud/vm1> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example.com/cloud/VirtualMachine> .
+<http://example.com/cloud/vm1> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/2002/07/owl#Thing> .
+<http://example.com/cloud/vm1> <http://example.com/cloud/name> "My \"VM\"\n" .
+<http://example.com/cloud/vm1> <http://example.com/cloud/name> "Meine VM"@de .
+<http://example.com/cloud/vm1> <http://example.com/cloud/cores> "4"^^<http://www.w3.org/2001/XMLSchema#long> .
+<http://example.com/cloud/vm1> <http://example.com/cloud/has> _:b1 .
+<http://example.com/cloud/vm1> <http://example.com/cloud/owner> <http://example.com/cloud/people/a#b> .
+_:b1 <http://example.com/cloud/region> "eu" .
+`,
+		},
+		{
+			name:   "Turtle",
+			format: FormatTurtle,
+			want: `@prefix ex: <http://example.com/cloud/> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+
+ex:vm1
+	a ex:VirtualMachine, owl:Thing ;
+	ex:name "My \"VM\"\n", "Meine VM"@de ;
+	ex:cores "4"^^xsd:long ;
+	ex:has _:b1 ;
+	ex:owner <http://example.com/cloud/people/a#b> .
+
+_:b1
+	ex:region "eu" .
+`,
+		},
+		{
+			name:   "JSON-LD",
+			format: FormatJSONLD,
+			want: `{
+  "@context": {
+    "ex": "http://example.com/cloud/",
+    "owl": "http://www.w3.org/2002/07/owl#",
+    "rdf": "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+    "rdfs": "http://www.w3.org/2000/01/rdf-schema#",
+    "xml": "http://www.w3.org/XML/1998/namespace",
+    "xsd": "http://www.w3.org/2001/XMLSchema#"
+  },
+  "@graph": [
+    {
+      "@id": "http://example.com/cloud/vm1",
+      "@type": [
+        "ex:VirtualMachine",
+        "owl:Thing"
+      ],
+      "ex:cores": [
+        {
+          "@type": "xsd:long",
+          "@value": "4"
+        }
+      ],
+      "ex:has": [
+        {
+          "@id": "_:b1"
+        }
+      ],
+      "ex:name": [
+        {
+          "@value": "My \"VM\"\n"
+        },
+        {
+          "@language": "de",
+          "@value": "Meine VM"
+        }
+      ],
+      "ex:owner": [
+        {
+          "@id": "http://example.com/cloud/people/a#b"
+        }
+      ]
+    },
+    {
+      "@id": "_:b1",
+      "ex:region": [
+        {
+          "@value": "eu"
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+		{
+			name:    "Unsupported format",
+			format:  "rdfxml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := Write(&buf, testGraph(), tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Write() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteTurtle_roundTrip(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := WriteTurtle(&buf, testGraph())
+	if err != nil {
+		t.Fatalf("WriteTurtle() error = %v", err)
+	}
+
+	g, err := ParseTurtle(strings.NewReader(buf.String()), "")
+	if err != nil {
+		t.Fatalf("ParseTurtle() error = %v", err)
+	}
+
+	var got, want []string
+	for _, triple := range g.Triples {
+		got = append(got, triple.String())
+	}
+	for _, triple := range testGraph().Triples {
+		want = append(want, triple.String())
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseTurtle(WriteTurtle()) = %v, want %v", got, want)
+	}
+}
+
+func Test_quote(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{name: "Plain", s: "vm1", want: `"vm1"`},
+		{name: "Escapes", s: "a\"b\\c\td\r", want: `"a\"b\\c\td\r"`},
+		{name: "Control characters", s: "\x00\x7f", want: `"\u0000\u007F"`},
+		{name: "Unicode", s: "Größe", want: `"Größe"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quote(tt.s); got != tt.want {
+				t.Errorf("quote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
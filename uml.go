@@ -29,10 +29,12 @@ func CreatePlantUMLFile(po *ontology.OntologyPrepared) string {
 		// End class
 		output += "}\n"
 
-		// Draw relationships. First our parent
-		parent, ok := po.Resources[class.Parent]
-		if ok {
-			output += fmt.Sprintf("\n%s <|-- %s\n", parent.Name, class.Name)
+		// Draw relationships. First our parents
+		for _, parentIri := range class.Parents {
+			parent, ok := po.Resources[parentIri]
+			if ok {
+				output += fmt.Sprintf("\n%s <|-- %s\n", parent.Name, class.Name)
+			}
 		}
 
 		// Then, draw all object relationships